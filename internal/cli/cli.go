@@ -0,0 +1,352 @@
+// Package cli implements bpftui's non-interactive subcommands, which print
+// programs and maps as tables or JSON instead of starting the TUI.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/viveksb007/bpftui/internal/tui"
+)
+
+// Usage describes the available subcommands.
+const Usage = `Usage:
+  bpftui [flags]                      Start the interactive TUI
+  bpftui prog list [--json]           List loaded programs
+  bpftui prog show <id> [--json]      Show a program
+  bpftui map list [--json]            List loaded maps
+  bpftui map show <id> [--json]       Show a map
+  bpftui map dump <id> [--json]       Dump a map's entries`
+
+// ErrUsage is returned when the subcommand or its arguments are invalid.
+var ErrUsage = errors.New("invalid usage")
+
+// Run executes the subcommand in args, e.g. ["prog", "list", "--json"],
+// and writes its output to w.
+func Run(args []string, progSvc tui.ProgService, mapsSvc tui.MapsService, w io.Writer) error {
+	if len(args) < 2 {
+		return usageError(fmt.Sprintf("unknown command %q", strings.Join(args, " ")))
+	}
+
+	fs := flag.NewFlagSet(args[0]+" "+args[1], flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(interspersed(args[2:])); err != nil {
+		return usageError(err.Error())
+	}
+	out := printer{w: w, json: *asJSON}
+
+	switch args[0] + " " + args[1] {
+	case "prog list":
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		progs, err := progSvc.List()
+		if err != nil {
+			return err
+		}
+		return out.progList(progs)
+
+	case "prog show":
+		id, err := idArg(fs)
+		if err != nil {
+			return err
+		}
+		p, err := progSvc.Get(id)
+		if err != nil {
+			return err
+		}
+		return out.prog(p)
+
+	case "map list":
+		if err := noArgs(fs); err != nil {
+			return err
+		}
+		maps, err := mapsSvc.List()
+		if err != nil {
+			return err
+		}
+		return out.mapList(maps)
+
+	case "map show":
+		id, err := idArg(fs)
+		if err != nil {
+			return err
+		}
+		m, err := mapsSvc.Get(id)
+		if err != nil {
+			return err
+		}
+		return out.mapInfo(m)
+
+	case "map dump":
+		id, err := idArg(fs)
+		if err != nil {
+			return err
+		}
+		entries, err := mapsSvc.Dump(id)
+		if err != nil {
+			return err
+		}
+		return out.mapDump(entries)
+	}
+	return usageError(fmt.Sprintf("unknown command %q", args[0]+" "+args[1]))
+}
+
+// usageError wraps ErrUsage with the reason the arguments were rejected.
+func usageError(reason string) error {
+	return fmt.Errorf("%w: %s\n\n%s", ErrUsage, reason, Usage)
+}
+
+// interspersed moves flags ahead of positional arguments, so both
+// "map dump 5 --json" and "map dump --json 5" work.
+func interspersed(args []string) []string {
+	var flags, positional []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			flags = append(flags, a)
+		} else {
+			positional = append(positional, a)
+		}
+	}
+	return append(flags, positional...)
+}
+
+// noArgs rejects positional arguments.
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		return usageError(fmt.Sprintf("unexpected argument %q", fs.Arg(0)))
+	}
+	return nil
+}
+
+// idArg parses the single program or map ID argument.
+func idArg(fs *flag.FlagSet) (uint32, error) {
+	if fs.NArg() != 1 {
+		return 0, usageError("expected exactly one ID")
+	}
+	id, err := strconv.ParseUint(fs.Arg(0), 10, 32)
+	if err != nil {
+		return 0, usageError(fmt.Sprintf("invalid ID %q", fs.Arg(0)))
+	}
+	return uint32(id), nil
+}
+
+// printer writes programs, maps and map entries as tables or JSON.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// progJSON is the JSON form of a program, using bpftool's field names.
+type progJSON struct {
+	ID          uint32   `json:"id"`
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Tag         string   `json:"tag"`
+	GPL         bool     `json:"gpl_compatible"`
+	LoadedAt    string   `json:"loaded_at"`
+	UID         uint32   `json:"uid"`
+	BytesXlated uint32   `json:"bytes_xlated"`
+	BytesJIT    uint32   `json:"bytes_jited"`
+	MemLock     uint32   `json:"bytes_memlock"`
+	MapIDs      []uint32 `json:"map_ids"`
+	Pinned      []string `json:"pinned"`
+	RunCount    uint64   `json:"run_cnt"`
+	RunTimeNs   int64    `json:"run_time_ns"`
+}
+
+func newProgJSON(p tui.ProgramInfo) progJSON {
+	return progJSON{
+		ID:          p.ID,
+		Type:        p.Type,
+		Name:        p.Name,
+		Tag:         p.Tag,
+		GPL:         p.GPL,
+		LoadedAt:    p.LoadedAt,
+		UID:         p.UID,
+		BytesXlated: p.BytesXlated,
+		BytesJIT:    p.BytesJIT,
+		MemLock:     p.MemLock,
+		MapIDs:      nonNil(p.MapIDs),
+		Pinned:      nonNil(p.PinnedPaths),
+		RunCount:    p.RunCount,
+		RunTimeNs:   p.RunTime.Nanoseconds(),
+	}
+}
+
+// mapJSON is the JSON form of a map, using bpftool's field names.
+type mapJSON struct {
+	ID         uint32   `json:"id"`
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	KeySize    uint32   `json:"bytes_key"`
+	ValueSize  uint32   `json:"bytes_value"`
+	MaxEntries uint32   `json:"max_entries"`
+	Flags      uint32   `json:"flags"`
+	MemLock    uint32   `json:"bytes_memlock"`
+	LoadedAt   string   `json:"loaded_at"`
+	UID        uint32   `json:"uid"`
+	Pinned     []string `json:"pinned"`
+}
+
+func newMapJSON(m tui.MapInfo) mapJSON {
+	return mapJSON{
+		ID:         m.ID,
+		Type:       m.Type,
+		Name:       m.Name,
+		KeySize:    m.KeySize,
+		ValueSize:  m.ValueSize,
+		MaxEntries: m.MaxEntries,
+		Flags:      m.Flags,
+		MemLock:    m.MemLock,
+		LoadedAt:   m.LoadedAt,
+		UID:        m.UID,
+		Pinned:     nonNil(m.PinnedPaths),
+	}
+}
+
+// entryJSON is the JSON form of a map entry. Like bpftool, bytes are
+// written as arrays of "0x.." strings.
+type entryJSON struct {
+	Key   []string `json:"key"`
+	Value []string `json:"value"`
+}
+
+// hexBytes formats data as bpftool-style "0x.." strings.
+func hexBytes(data []byte) []string {
+	s := make([]string, len(data))
+	for i, b := range data {
+		s[i] = fmt.Sprintf("0x%02x", b)
+	}
+	return s
+}
+
+// nonNil returns s, or an empty slice if s is nil, so JSON shows [] rather
+// than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// writeJSON writes v as indented JSON.
+func (p printer) writeJSON(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p printer) progList(progs []tui.ProgramInfo) error {
+	if p.json {
+		out := make([]progJSON, len(progs))
+		for i, prog := range progs {
+			out[i] = newProgJSON(prog)
+		}
+		return p.writeJSON(out)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tTAG\tMEMLOCK\tUID\tLOADED AT")
+	for _, prog := range progs {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			prog.ID, prog.Type, prog.Name, prog.Tag, prog.MemLock, prog.UID, prog.LoadedAt)
+	}
+	return tw.Flush()
+}
+
+func (p printer) prog(prog *tui.ProgramInfo) error {
+	if p.json {
+		return p.writeJSON(newProgJSON(*prog))
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%d\n", prog.ID)
+	fmt.Fprintf(tw, "Name:\t%s\n", prog.Name)
+	fmt.Fprintf(tw, "Type:\t%s\n", prog.Type)
+	fmt.Fprintf(tw, "Tag:\t%s\n", prog.Tag)
+	fmt.Fprintf(tw, "GPL:\t%t\n", prog.GPL)
+	fmt.Fprintf(tw, "Loaded At:\t%s\n", prog.LoadedAt)
+	fmt.Fprintf(tw, "UID:\t%d\n", prog.UID)
+	fmt.Fprintf(tw, "Bytes Xlated:\t%d\n", prog.BytesXlated)
+	fmt.Fprintf(tw, "Bytes JIT:\t%d\n", prog.BytesJIT)
+	fmt.Fprintf(tw, "Memory Lock:\t%d\n", prog.MemLock)
+	fmt.Fprintf(tw, "Map IDs:\t%s\n", joinIDs(prog.MapIDs))
+	fmt.Fprintf(tw, "Pinned:\t%s\n", strings.Join(prog.PinnedPaths, ", "))
+	if prog.RunCount > 0 {
+		fmt.Fprintf(tw, "Run Count:\t%d\n", prog.RunCount)
+		fmt.Fprintf(tw, "Run Time:\t%s\n", prog.RunTime)
+	}
+	return tw.Flush()
+}
+
+func (p printer) mapList(maps []tui.MapInfo) error {
+	if p.json {
+		out := make([]mapJSON, len(maps))
+		for i, m := range maps {
+			out[i] = newMapJSON(m)
+		}
+		return p.writeJSON(out)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tKEY\tVALUE\tMAX ENTRIES\tMEMLOCK\tUID")
+	for _, m := range maps {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+			m.ID, m.Type, m.Name, m.KeySize, m.ValueSize, m.MaxEntries, m.MemLock, m.UID)
+	}
+	return tw.Flush()
+}
+
+func (p printer) mapInfo(m *tui.MapInfo) error {
+	if p.json {
+		return p.writeJSON(newMapJSON(*m))
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%d\n", m.ID)
+	fmt.Fprintf(tw, "Name:\t%s\n", m.Name)
+	fmt.Fprintf(tw, "Type:\t%s\n", m.Type)
+	fmt.Fprintf(tw, "Key Size:\t%d\n", m.KeySize)
+	fmt.Fprintf(tw, "Value Size:\t%d\n", m.ValueSize)
+	fmt.Fprintf(tw, "Max Entries:\t%d\n", m.MaxEntries)
+	fmt.Fprintf(tw, "Flags:\t0x%x\n", m.Flags)
+	fmt.Fprintf(tw, "Memory Lock:\t%d\n", m.MemLock)
+	fmt.Fprintf(tw, "Loaded At:\t%s\n", m.LoadedAt)
+	fmt.Fprintf(tw, "UID:\t%d\n", m.UID)
+	fmt.Fprintf(tw, "Pinned:\t%s\n", strings.Join(m.PinnedPaths, ", "))
+	return tw.Flush()
+}
+
+func (p printer) mapDump(entries []tui.MapEntry) error {
+	if p.json {
+		out := make([]entryJSON, len(entries))
+		for i, e := range entries {
+			out[i] = entryJSON{Key: hexBytes(e.Key), Value: hexBytes(e.Value)}
+		}
+		return p.writeJSON(out)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\n", tui.FormatHex(e.Key), tui.FormatHex(e.Value))
+	}
+	return tw.Flush()
+}
+
+// joinIDs formats IDs as a comma-separated list.
+func joinIDs(ids []uint32) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viveksb007/bpftui/internal/tui"
+)
+
+type mockProgService struct {
+	programs []tui.ProgramInfo
+	err      error
+}
+
+func (m *mockProgService) List() ([]tui.ProgramInfo, error) {
+	return m.programs, m.err
+}
+
+func (m *mockProgService) Get(id uint32) (*tui.ProgramInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, p := range m.programs {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, errors.New("program not found")
+}
+
+type mockMapsService struct {
+	maps    []tui.MapInfo
+	entries []tui.MapEntry
+}
+
+func (m *mockMapsService) List() ([]tui.MapInfo, error) {
+	return m.maps, nil
+}
+
+func (m *mockMapsService) Get(id uint32) (*tui.MapInfo, error) {
+	for _, mp := range m.maps {
+		if mp.ID == id {
+			return &mp, nil
+		}
+	}
+	return nil, errors.New("map not found")
+}
+
+func (m *mockMapsService) Dump(id uint32) ([]tui.MapEntry, error) {
+	return m.entries, nil
+}
+
+func (m *mockMapsService) Lookup(id uint32, key []byte) ([]byte, error) {
+	return nil, tui.ErrKeyNotFound
+}
+
+func (m *mockMapsService) Update(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsService) Insert(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsService) Delete(id uint32, key []byte) error {
+	return nil
+}
+
+func newServices() (*mockProgService, *mockMapsService) {
+	progSvc := &mockProgService{programs: []tui.ProgramInfo{
+		{ID: 1, Name: "xdp_main", Type: "xdp", Tag: "abc123", MemLock: 4096, MapIDs: []uint32{5},
+			RunCount: 10, RunTime: 2 * time.Microsecond},
+		{ID: 2, Name: "kprobe_open", Type: "kprobe", Tag: "def456"},
+	}}
+	mapsSvc := &mockMapsService{
+		maps: []tui.MapInfo{
+			{ID: 5, Name: "events", Type: "hash", KeySize: 4, ValueSize: 8, MaxEntries: 1024},
+		},
+		entries: []tui.MapEntry{
+			{Key: []byte{1, 0, 0, 0}, Value: []byte{0xff, 0, 0, 0, 0, 0, 0, 0}},
+		},
+	}
+	return progSvc, mapsSvc
+}
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	progSvc, mapsSvc := newServices()
+	var out bytes.Buffer
+	err := Run(args, progSvc, mapsSvc, &out)
+	return out.String(), err
+}
+
+func TestRunProgList(t *testing.T) {
+	out, err := run(t, "prog", "list")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header and 2 programs:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "xdp_main") {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+}
+
+func TestRunProgListJSON(t *testing.T) {
+	out, err := run(t, "prog", "list", "--json")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	var progs []map[string]any
+	if err := json.Unmarshal([]byte(out), &progs); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(progs) != 2 {
+		t.Fatalf("got %d programs, want 2", len(progs))
+	}
+	if progs[0]["name"] != "xdp_main" || progs[0]["run_time_ns"] != 2000.0 {
+		t.Errorf("progs[0] = %v", progs[0])
+	}
+	// Missing lists are empty arrays, not null
+	if ids, ok := progs[1]["map_ids"].([]any); !ok || len(ids) != 0 {
+		t.Errorf("progs[1].map_ids = %v, want []", progs[1]["map_ids"])
+	}
+}
+
+func TestRunProgShow(t *testing.T) {
+	out, err := run(t, "prog", "show", "1")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, want := range []string{"xdp_main", "abc123", "Map IDs:", "5", "Run Count:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := run(t, "prog", "show", "99"); err == nil {
+		t.Error("expected error for unknown program")
+	}
+}
+
+func TestRunMapShowJSON(t *testing.T) {
+	out, err := run(t, "map", "show", "--json", "5")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(out), &m); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if m["name"] != "events" || m["max_entries"] != 1024.0 {
+		t.Errorf("map = %v", m)
+	}
+}
+
+func TestRunMapDump(t *testing.T) {
+	out, err := run(t, "map", "dump", "5")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(out, "01 00 00 00") || !strings.Contains(out, "ff 00 00 00 00 00 00 00") {
+		t.Errorf("unexpected dump:\n%s", out)
+	}
+
+	// Flags may follow the ID
+	out, err = run(t, "map", "dump", "5", "--json")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	var entries []struct {
+		Key   []string `json:"key"`
+		Value []string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || entries[0].Key[0] != "0x01" || entries[0].Value[0] != "0xff" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	tests := [][]string{
+		{"prog"},
+		{"prog", "lst"},
+		{"map", "dump"},
+		{"map", "dump", "abc"},
+		{"map", "list", "extra"},
+		{"prog", "list", "--yaml"},
+	}
+	for _, args := range tests {
+		_, err := run(t, args...)
+		if !errors.Is(err, ErrUsage) {
+			t.Errorf("Run(%q) error = %v, want ErrUsage", args, err)
+		}
+	}
+}
+
+func TestRunServiceError(t *testing.T) {
+	progSvc := &mockProgService{err: &tui.PermissionError{}}
+	err := Run([]string{"prog", "list"}, progSvc, &mockMapsService{}, &bytes.Buffer{})
+	if !tui.IsPermissionError(err) {
+		t.Errorf("Run() error = %v, want permission error", err)
+	}
+}
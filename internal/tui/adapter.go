@@ -1,6 +1,14 @@
 package tui
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/cilium/ebpf"
 	"github.com/viveksb007/gobpftool/pkg/maps"
 	"github.com/viveksb007/gobpftool/pkg/prog"
 )
@@ -8,6 +16,9 @@ import (
 // ProgServiceAdapter adapts gobpftool's prog.Service to our ProgService interface.
 type ProgServiceAdapter struct {
 	svc prog.Service
+
+	mu    sync.Mutex
+	stats io.Closer // Stats fd keeping run statistics enabled, if EnableStats was called
 }
 
 // NewProgServiceAdapter creates a new adapter for the prog service.
@@ -39,6 +50,7 @@ func (a *ProgServiceAdapter) List() ([]ProgramInfo, error) {
 			Pinned:      len(p.PinnedPaths) > 0,
 			PinnedPaths: p.PinnedPaths,
 		}
+		addRunStats(&result[i])
 	}
 	return result, nil
 }
@@ -50,7 +62,7 @@ func (a *ProgServiceAdapter) Get(id uint32) (*ProgramInfo, error) {
 		return nil, err
 	}
 
-	return &ProgramInfo{
+	info := &ProgramInfo{
 		ID:          p.ID,
 		Type:        p.Type,
 		Name:        p.Name,
@@ -64,7 +76,9 @@ func (a *ProgServiceAdapter) Get(id uint32) (*ProgramInfo, error) {
 		MapIDs:      p.MapIDs,
 		Pinned:      len(p.PinnedPaths) > 0,
 		PinnedPaths: p.PinnedPaths,
-	}, nil
+	}
+	addRunStats(info)
+	return info, nil
 }
 
 // MapsServiceAdapter adapts gobpftool's maps.Service to our MapsService interface.
@@ -127,8 +141,20 @@ func (a *MapsServiceAdapter) Get(id uint32) (*MapInfo, error) {
 	}, nil
 }
 
-// Dump returns all entries in the map.
+// Dump returns all entries in the map. Per-CPU values are the values of all
+// possible CPUs, back to back.
 func (a *MapsServiceAdapter) Dump(id uint32) ([]MapEntry, error) {
+	// gobpftool reads values into a single value-sized buffer, which
+	// per-CPU maps can't be read into
+	if m, err := ebpf.NewMapFromID(ebpf.MapID(id)); err == nil {
+		if hasPerCPUValue(m.Type()) {
+			iter := &mapIterator{m: m}
+			defer iter.Close()
+			return readAll(iter)
+		}
+		m.Close()
+	}
+
 	entries, err := a.svc.Dump(id)
 	if err != nil {
 		return nil, err
@@ -143,3 +169,212 @@ func (a *MapsServiceAdapter) Dump(id uint32) ([]MapEntry, error) {
 	}
 	return result, nil
 }
+
+// Lookup returns the value stored under key in the map. Per-CPU values are
+// the values of all possible CPUs, back to back.
+func (a *MapsServiceAdapter) Lookup(id uint32, key []byte) ([]byte, error) {
+	// As for Dump, per-CPU values don't fit gobpftool's buffer
+	if m, err := ebpf.NewMapFromID(ebpf.MapID(id)); err == nil {
+		defer m.Close()
+		if hasPerCPUValue(m.Type()) {
+			var values [][]byte
+			if err := m.Lookup(key, &values); err != nil {
+				return nil, mapKeyError(err)
+			}
+			return bytes.Join(values, nil), nil
+		}
+	}
+
+	value, err := a.svc.Lookup(id, key)
+	if err != nil {
+		return nil, mapKeyError(err)
+	}
+	return value, nil
+}
+
+// Update creates or replaces the entry for key in the map. Per-CPU values
+// are given as the values of each CPU, back to back; CPUs left out are set
+// to zero.
+// gobpftool is read-only, so writes go through cilium/ebpf directly.
+func (a *MapsServiceAdapter) Update(id uint32, key, value []byte) error {
+	return a.write(id, key, value, ebpf.UpdateAny)
+}
+
+// Insert creates the entry for key in the map, or returns ErrKeyExists if
+// the map already has one. Values are given as for Update.
+func (a *MapsServiceAdapter) Insert(id uint32, key, value []byte) error {
+	return a.write(id, key, value, ebpf.UpdateNoExist)
+}
+
+// write stores value under key with the given update flags.
+func (a *MapsServiceAdapter) write(id uint32, key, value []byte, flags ebpf.MapUpdateFlags) error {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(id))
+	if err != nil {
+		return fmt.Errorf("failed to get map by ID %d: %w", id, err)
+	}
+	defer m.Close()
+
+	var v any = value
+	if hasPerCPUValue(m.Type()) {
+		values := splitPerCPU(value, m.ValueSize())
+		if values == nil {
+			return fmt.Errorf("per-CPU value must be a multiple of %d bytes", m.ValueSize())
+		}
+		v = values
+	}
+	if err := m.Update(key, v, flags); err != nil {
+		if errors.Is(err, ebpf.ErrKeyExist) {
+			return ErrKeyExists
+		}
+		return fmt.Errorf("failed to update entry: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the entry for key from the map.
+func (a *MapsServiceAdapter) Delete(id uint32, key []byte) error {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(id))
+	if err != nil {
+		return fmt.Errorf("failed to get map by ID %d: %w", id, err)
+	}
+	defer m.Close()
+
+	if err := m.Delete(key); err != nil {
+		return mapKeyError(fmt.Errorf("failed to delete entry: %w", err))
+	}
+	return nil
+}
+
+// Iterate starts reading the map's entries. Entries are read with batch
+// lookups where the kernel and map type support them, and one key at a time
+// otherwise.
+func (a *MapsServiceAdapter) Iterate(id uint32) (MapIterator, error) {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map by ID %d: %w", id, err)
+	}
+	// Batch lookups of per-CPU values need a buffer per CPU; iterate instead
+	return &mapIterator{m: m, batch: !hasPerCPUValue(m.Type())}, nil
+}
+
+// mapIterator reads a map's entries with batch lookups, falling back to a
+// key-by-key iteration if the first batch lookup fails.
+type mapIterator struct {
+	m       *ebpf.Map
+	batch   bool // Batch lookups may be supported
+	started bool // Some entries have been read
+	done    bool
+	cursor  ebpf.MapBatchCursor
+	iter    *ebpf.MapIterator
+}
+
+// Next returns up to n further entries. Per-CPU values are the values of
+// all possible CPUs, back to back.
+func (it *mapIterator) Next(n int) ([]MapEntry, error) {
+	if it.done || n <= 0 {
+		return nil, nil
+	}
+	if it.batch {
+		entries, err := it.nextBatch(n)
+		if err == nil || it.started {
+			it.started = true
+			return entries, err
+		}
+		// The kernel or map type doesn't support batch lookups, or the
+		// batch is smaller than a hash bucket
+		it.batch = false
+	}
+	it.started = true
+	return it.nextKeys(n)
+}
+
+// nextBatch reads up to n entries with a single batch lookup.
+func (it *mapIterator) nextBatch(n int) ([]MapEntry, error) {
+	keys := byteRows(n, it.m.KeySize())
+	values := byteRows(n, it.m.ValueSize())
+	count, err := it.m.BatchLookup(&it.cursor, keys.Interface(), values.Interface(), nil)
+	if errors.Is(err, ebpf.ErrKeyNotExist) {
+		// The lookup reached the end of the map
+		it.done = true
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]MapEntry, count)
+	for i := range entries {
+		entries[i] = MapEntry{
+			Key:   bytes.Clone(keys.Index(i).Bytes()),
+			Value: bytes.Clone(values.Index(i).Bytes()),
+		}
+	}
+	return entries, nil
+}
+
+// nextKeys reads up to n entries one key at a time.
+func (it *mapIterator) nextKeys(n int) ([]MapEntry, error) {
+	if it.iter == nil {
+		it.iter = it.m.Iterate()
+	}
+	perCPU := hasPerCPUValue(it.m.Type())
+
+	var entries []MapEntry
+	for len(entries) < n {
+		// Keys and values are unmarshaled into fresh slices
+		var key, value []byte
+		var values [][]byte
+		var ok bool
+		if perCPU {
+			ok = it.iter.Next(&key, &values)
+			value = bytes.Join(values, nil)
+		} else {
+			ok = it.iter.Next(&key, &value)
+		}
+		if !ok {
+			it.done = true
+			if err := it.iter.Err(); err != nil {
+				return entries, fmt.Errorf("failed to iterate map entries: %w", err)
+			}
+			break
+		}
+		entries = append(entries, MapEntry{Key: key, Value: value})
+	}
+	return entries, nil
+}
+
+// Close releases the map.
+func (it *mapIterator) Close() error {
+	it.done = true
+	if it.m == nil {
+		return nil
+	}
+	err := it.m.Close()
+	it.m = nil
+	return err
+}
+
+// byteRows allocates a slice of n fixed-size byte arrays, which batch lookups
+// fill in place.
+func byteRows(n int, size uint32) reflect.Value {
+	row := reflect.ArrayOf(int(size), reflect.TypeFor[byte]())
+	return reflect.MakeSlice(reflect.SliceOf(row), n, n)
+}
+
+// hasPerCPUValue returns true if maps of type t store a value per CPU.
+func hasPerCPUValue(t ebpf.MapType) bool {
+	switch t {
+	case ebpf.PerCPUHash, ebpf.PerCPUArray, ebpf.LRUCPUHash, ebpf.PerCPUCGroupStorage:
+		return true
+	default:
+		return false
+	}
+}
+
+// mapKeyError translates a missing-key error into ErrKeyNotFound.
+func mapKeyError(err error) error {
+	if errors.Is(err, ebpf.ErrKeyNotExist) {
+		return ErrKeyNotFound
+	}
+	return err
+}
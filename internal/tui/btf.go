@@ -0,0 +1,447 @@
+package tui
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// btfIndent is the indentation used for each nesting level of decoded values.
+const btfIndent = "    "
+
+// formatBTF decodes data according to the BTF type t, producing output in the
+// same JSON-like shape as `bpftool map dump` on BTF-annotated maps.
+// Scalars and arrays of scalars are rendered on a single line; structs and
+// unions span multiple lines. Falls back to hex if data is too short for t.
+func formatBTF(t *BTFType, data []byte) string {
+	if t == nil || uint32(len(data)) < t.Size {
+		return FormatHex(data)
+	}
+	var b strings.Builder
+	writeBTFValue(&b, t, data, 0)
+	return b.String()
+}
+
+// writeBTFValue writes the decoded value of data as type t at the given nesting depth.
+func writeBTFValue(b *strings.Builder, t *BTFType, data []byte, depth int) {
+	if uint32(len(data)) < t.Size {
+		b.WriteString(FormatHex(data))
+		return
+	}
+
+	switch t.Kind {
+	case BTFKindInt:
+		b.WriteString(formatBTFInt(t, readUint(data, t.Size)))
+
+	case BTFKindFloat:
+		switch t.Size {
+		case 4:
+			b.WriteString(fmt.Sprintf("%g", math.Float32frombits(uint32(readUint(data, 4)))))
+		case 8:
+			b.WriteString(fmt.Sprintf("%g", math.Float64frombits(readUint(data, 8))))
+		default:
+			b.WriteString(FormatHex(data[:t.Size]))
+		}
+
+	case BTFKindPointer:
+		b.WriteString(fmt.Sprintf("0x%x", readUint(data, t.Size)))
+
+	case BTFKindEnum:
+		b.WriteString(formatBTFEnum(t, readUint(data, t.Size)))
+
+	case BTFKindArray:
+		writeBTFArray(b, t, data, depth)
+
+	case BTFKindStruct, BTFKindUnion:
+		writeBTFStruct(b, t, data, depth)
+
+	default:
+		b.WriteString(FormatHex(data[:t.Size]))
+	}
+}
+
+// writeBTFArray writes an array. Character arrays are rendered as strings.
+func writeBTFArray(b *strings.Builder, t *BTFType, data []byte, depth int) {
+	elem := t.Elem
+	if elem == nil {
+		b.WriteString(FormatHex(data[:t.Size]))
+		return
+	}
+
+	if elem.Kind == BTFKindInt && elem.Size == 1 && elem.Char {
+		str := data[:t.Len]
+		if i := strings.IndexByte(string(str), 0); i >= 0 {
+			str = str[:i]
+		}
+		b.WriteString(fmt.Sprintf("%q", str))
+		return
+	}
+
+	compound := elem.Kind == BTFKindStruct || elem.Kind == BTFKindUnion || elem.Kind == BTFKindArray
+	b.WriteString("[")
+	for i := uint32(0); i < t.Len; i++ {
+		off := i * elem.Size
+		if compound {
+			b.WriteString("\n")
+			b.WriteString(strings.Repeat(btfIndent, depth+1))
+		} else if i > 0 {
+			b.WriteString(" ")
+		}
+		writeBTFValue(b, elem, data[off:], depth+1)
+		if i < t.Len-1 {
+			b.WriteString(",")
+		}
+	}
+	if compound && t.Len > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(btfIndent, depth))
+	}
+	b.WriteString("]")
+}
+
+// writeBTFStruct writes a struct or union with one member per line.
+func writeBTFStruct(b *strings.Builder, t *BTFType, data []byte, depth int) {
+	b.WriteString("{")
+	for _, mem := range t.Members {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(btfIndent, depth+1))
+		name := mem.Name
+		if name == "" {
+			name = "(anon)"
+		}
+		b.WriteString(fmt.Sprintf("%q: ", name))
+
+		switch {
+		case mem.Type == nil:
+			b.WriteString("?")
+		case mem.BitfieldSize > 0:
+			v := readBits(data, mem.Offset, mem.BitfieldSize)
+			if mem.Type.Kind == BTFKindEnum {
+				b.WriteString(formatBTFEnum(mem.Type, v))
+			} else {
+				if mem.Type.Signed {
+					v = signExtend(v, mem.BitfieldSize)
+				}
+				b.WriteString(formatBTFInt(mem.Type, v))
+			}
+		default:
+			off := mem.Offset / 8
+			if off > uint32(len(data)) {
+				b.WriteString("?")
+			} else {
+				writeBTFValue(b, mem.Type, data[off:], depth+1)
+			}
+		}
+		b.WriteString(",")
+	}
+	if len(t.Members) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(btfIndent, depth))
+	}
+	b.WriteString("}")
+}
+
+// formatBTFInt formats an integer value according to its encoding.
+func formatBTFInt(t *BTFType, v uint64) string {
+	switch {
+	case t.Bool:
+		return fmt.Sprintf("%t", v != 0)
+	case t.Signed:
+		return fmt.Sprintf("%d", int64(signExtend(v, t.Size*8)))
+	default:
+		return fmt.Sprintf("%d", v)
+	}
+}
+
+// formatBTFEnum formats an enum value by name, falling back to its number.
+func formatBTFEnum(t *BTFType, v uint64) string {
+	if t.Signed {
+		v = signExtend(v, t.Size*8)
+	}
+	for _, ev := range t.Values {
+		if ev.Value == v {
+			return ev.Name
+		}
+	}
+	if t.Signed {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+// readUint reads a native-endian unsigned integer of the given size in bytes.
+// Sizes above 8 bytes (e.g. __int128) are truncated to their low 64 bits.
+func readUint(data []byte, size uint32) uint64 {
+	switch size {
+	case 1:
+		return uint64(data[0])
+	case 2:
+		return uint64(binary.NativeEndian.Uint16(data))
+	case 4:
+		return uint64(binary.NativeEndian.Uint32(data))
+	case 8:
+		return binary.NativeEndian.Uint64(data)
+	case 16:
+		return binary.NativeEndian.Uint64(data)
+	default:
+		return 0
+	}
+}
+
+// readBits extracts a bitfield of size bits starting at bit offset off.
+// Bit numbering follows the little-endian layout used by BTF on x86-64 and arm64.
+func readBits(data []byte, off, size uint32) uint64 {
+	var v uint64
+	for i := uint32(0); i < size && i < 64; i++ {
+		bit := off + i
+		if bit/8 >= uint32(len(data)) {
+			break
+		}
+		if data[bit/8]&(1<<(bit%8)) != 0 {
+			v |= 1 << i
+		}
+	}
+	return v
+}
+
+// signExtend interprets the low bits of v as a two's complement number.
+func signExtend(v uint64, bits uint32) uint64 {
+	if bits == 0 || bits >= 64 {
+		return v
+	}
+	shift := 64 - bits
+	return uint64(int64(v<<shift) >> shift)
+}
+
+// encodeBTF encodes a JSON value as type t, the inverse of formatBTF.
+// Structs are objects keyed by member name (omitted members are zero), enums
+// are given by name or number and char arrays as strings.
+func encodeBTF(t *BTFType, input string) ([]byte, error) {
+	dec := json.NewDecoder(strings.NewReader(input))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	buf := make([]byte, t.Size)
+	if err := encodeBTFValue(buf, t, v); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// encodeBTFValue writes v as type t at the start of buf.
+func encodeBTFValue(buf []byte, t *BTFType, v any) error {
+	if uint32(len(buf)) < t.Size {
+		return fmt.Errorf("value for %s exceeds its container", typeLabel(t))
+	}
+
+	switch t.Kind {
+	case BTFKindInt, BTFKindPointer:
+		if b, ok := v.(bool); ok && t.Bool {
+			if b {
+				writeUint(buf, t.Size, 1)
+			}
+			return nil
+		}
+		n, err := jsonInt(v, t.Size, t.Signed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", typeLabel(t), err)
+		}
+		writeUint(buf, t.Size, n)
+
+	case BTFKindFloat:
+		num, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: expected a number", typeLabel(t))
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: %w", typeLabel(t), err)
+		}
+		switch t.Size {
+		case 4:
+			writeUint(buf, 4, uint64(math.Float32bits(float32(f))))
+		case 8:
+			writeUint(buf, 8, math.Float64bits(f))
+		default:
+			return fmt.Errorf("%s: unsupported float size %d", typeLabel(t), t.Size)
+		}
+
+	case BTFKindEnum:
+		n, err := enumValue(t, v)
+		if err != nil {
+			return err
+		}
+		writeUint(buf, t.Size, n)
+
+	case BTFKindArray:
+		return encodeBTFArray(buf, t, v)
+
+	case BTFKindStruct, BTFKindUnion:
+		return encodeBTFStruct(buf, t, v)
+
+	default:
+		return fmt.Errorf("%s: unsupported type", typeLabel(t))
+	}
+	return nil
+}
+
+// encodeBTFArray writes a JSON array, or a string for char arrays.
+// Shorter inputs leave the remaining elements zeroed.
+func encodeBTFArray(buf []byte, t *BTFType, v any) error {
+	elem := t.Elem
+	if elem == nil {
+		return fmt.Errorf("%s: unsupported array", typeLabel(t))
+	}
+
+	if s, ok := v.(string); ok && elem.Kind == BTFKindInt && elem.Size == 1 && elem.Char {
+		if uint32(len(s)) > t.Len {
+			return fmt.Errorf("string %q longer than %d bytes", s, t.Len)
+		}
+		copy(buf, s)
+		return nil
+	}
+
+	items, ok := v.([]any)
+	if !ok {
+		return fmt.Errorf("%s: expected an array", typeLabel(t))
+	}
+	if uint32(len(items)) > t.Len {
+		return fmt.Errorf("%s: expected at most %d elements, got %d", typeLabel(t), t.Len, len(items))
+	}
+	for i, item := range items {
+		if err := encodeBTFValue(buf[uint32(i)*elem.Size:], elem, item); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// encodeBTFStruct writes a JSON object member by member.
+func encodeBTFStruct(buf []byte, t *BTFType, v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: expected an object", typeLabel(t))
+	}
+
+	for name, val := range obj {
+		mem := findMember(t, name)
+		if mem == nil || mem.Type == nil {
+			return fmt.Errorf("%s has no member %q", typeLabel(t), name)
+		}
+
+		if mem.BitfieldSize > 0 {
+			var n uint64
+			var err error
+			if mem.Type.Kind == BTFKindEnum {
+				n, err = enumValue(mem.Type, val)
+			} else {
+				n, err = jsonInt(val, (mem.BitfieldSize+7)/8, mem.Type.Signed)
+			}
+			if err != nil {
+				return fmt.Errorf("%q: %w", name, err)
+			}
+			writeBits(buf, mem.Offset, mem.BitfieldSize, n)
+			continue
+		}
+
+		if err := encodeBTFValue(buf[mem.Offset/8:], mem.Type, val); err != nil {
+			return fmt.Errorf("%q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// findMember returns the member of a struct or union with the given name.
+func findMember(t *BTFType, name string) *BTFMember {
+	for i := range t.Members {
+		if t.Members[i].Name == name {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// enumValue resolves an enumerator name or number.
+func enumValue(t *BTFType, v any) (uint64, error) {
+	if name, ok := v.(string); ok {
+		for _, ev := range t.Values {
+			if ev.Name == name {
+				return ev.Value, nil
+			}
+		}
+		return 0, fmt.Errorf("%s has no value %q", typeLabel(t), name)
+	}
+	return jsonInt(v, t.Size, t.Signed)
+}
+
+// jsonInt converts a JSON number to an integer of the given size in bytes.
+func jsonInt(v any, size uint32, signed bool) (uint64, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("expected an integer")
+	}
+	return parseInt(num.String(), size, signed)
+}
+
+// parseInt parses a decimal integer, checking that it fits in size bytes.
+// Negative numbers are accepted for signed types only.
+func parseInt(s string, size uint32, signed bool) (uint64, error) {
+	bits := int(min(size*8, 64))
+	if signed {
+		n, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %d-byte integer %q", size, s)
+		}
+		return uint64(n), nil
+	}
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %d-byte unsigned integer %q", size, s)
+	}
+	return n, nil
+}
+
+// typeLabel names t for error messages.
+func typeLabel(t *BTFType) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return "(anon)"
+}
+
+// writeUint writes a native-endian unsigned integer of the given size in bytes.
+// It is the inverse of readUint.
+func writeUint(buf []byte, size uint32, v uint64) {
+	switch size {
+	case 1:
+		buf[0] = uint8(v)
+	case 2:
+		binary.NativeEndian.PutUint16(buf, uint16(v))
+	case 4:
+		binary.NativeEndian.PutUint32(buf, uint32(v))
+	case 8, 16:
+		binary.NativeEndian.PutUint64(buf, v)
+	}
+}
+
+// writeBits stores the low size bits of v at bit offset off. It is the inverse of readBits.
+func writeBits(buf []byte, off, size uint32, v uint64) {
+	for i := uint32(0); i < size && i < 64; i++ {
+		bit := off + i
+		if bit/8 >= uint32(len(buf)) {
+			break
+		}
+		if v&(1<<i) != 0 {
+			buf[bit/8] |= 1 << (bit % 8)
+		} else {
+			buf[bit/8] &^= 1 << (bit % 8)
+		}
+	}
+}
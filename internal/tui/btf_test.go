@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var (
+	btfU32  = &BTFType{Kind: BTFKindInt, Name: "u32", Size: 4}
+	btfS16  = &BTFType{Kind: BTFKindInt, Name: "s16", Size: 2, Signed: true}
+	btfChar = &BTFType{Kind: BTFKindInt, Name: "char", Size: 1, Signed: true, Char: true}
+	btfBool = &BTFType{Kind: BTFKindInt, Name: "_Bool", Size: 1, Bool: true}
+	btfU64  = &BTFType{Kind: BTFKindInt, Name: "u64", Size: 8}
+)
+
+func TestFormatBTFScalars(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  *BTFType
+		data []byte
+		want string
+	}{
+		{"unsigned int", btfU32, []byte{0x2a, 0x00, 0x00, 0x00}, "42"},
+		{"signed negative", btfS16, []byte{0xfe, 0xff}, "-2"},
+		{"bool true", btfBool, []byte{0x01}, "true"},
+		{"bool false", btfBool, []byte{0x00}, "false"},
+		{"pointer", &BTFType{Kind: BTFKindPointer, Size: 8}, []byte{0x00, 0x10, 0, 0, 0, 0, 0, 0}, "0x1000"},
+		{"float", &BTFType{Kind: BTFKindFloat, Name: "double", Size: 8}, []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}, "1.5"},
+		{"nil type falls back to hex", nil, []byte{0xab}, "ab"},
+		{"short data falls back to hex", btfU32, []byte{0x01, 0x02}, "01 02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatBTF(tt.typ, tt.data); got != tt.want {
+				t.Errorf("formatBTF() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBTFEnum(t *testing.T) {
+	enum := &BTFType{
+		Kind: BTFKindEnum,
+		Name: "state",
+		Size: 4,
+		Values: []BTFEnumValue{
+			{Name: "STATE_IDLE", Value: 0},
+			{Name: "STATE_BUSY", Value: 1},
+		},
+	}
+
+	if got := formatBTF(enum, []byte{1, 0, 0, 0}); got != "STATE_BUSY" {
+		t.Errorf("known value = %q, want STATE_BUSY", got)
+	}
+	if got := formatBTF(enum, []byte{7, 0, 0, 0}); got != "7" {
+		t.Errorf("unknown value = %q, want 7", got)
+	}
+}
+
+func TestFormatBTFArrays(t *testing.T) {
+	charArray := &BTFType{Kind: BTFKindArray, Size: 8, Elem: btfChar, Len: 8}
+	if got := formatBTF(charArray, []byte("bash\x00\x00\x00\x00")); got != `"bash"` {
+		t.Errorf("char array = %s, want \"bash\"", got)
+	}
+
+	intArray := &BTFType{Kind: BTFKindArray, Size: 12, Elem: btfU32, Len: 3}
+	data := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}
+	if got := formatBTF(intArray, data); got != "[1, 2, 3]" {
+		t.Errorf("int array = %q, want [1, 2, 3]", got)
+	}
+}
+
+func TestFormatBTFStruct(t *testing.T) {
+	inner := &BTFType{
+		Kind: BTFKindStruct,
+		Name: "inner",
+		Size: 4,
+		Members: []BTFMember{
+			{Name: "x", Offset: 0, Type: btfU32},
+		},
+	}
+	outer := &BTFType{
+		Kind: BTFKindStruct,
+		Name: "outer",
+		Size: 20,
+		Members: []BTFMember{
+			{Name: "pid", Offset: 0, Type: btfU32},
+			{Name: "comm", Offset: 32, Type: &BTFType{Kind: BTFKindArray, Size: 8, Elem: btfChar, Len: 8}},
+			{Name: "nested", Offset: 96, Type: inner},
+			{Name: "flag", Offset: 128, BitfieldSize: 1, Type: btfU32},
+			{Name: "level", Offset: 129, BitfieldSize: 3, Type: btfU32},
+		},
+	}
+
+	data := make([]byte, 20)
+	data[0] = 0xe8 // pid = 1000
+	data[1] = 0x03
+	copy(data[4:], "sshd")
+	data[12] = 7           // nested.x = 7
+	data[16] = 0x01 | 5<<1 // flag = 1, level = 5
+
+	want := strings.Join([]string{
+		`{`,
+		`    "pid": 1000,`,
+		`    "comm": "sshd",`,
+		`    "nested": {`,
+		`        "x": 7,`,
+		`    },`,
+		`    "flag": 1,`,
+		`    "level": 5,`,
+		`}`,
+	}, "\n")
+
+	if got := formatBTF(outer, data); got != want {
+		t.Errorf("formatBTF() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncodeBTFStruct(t *testing.T) {
+	state := &BTFType{Kind: BTFKindEnum, Name: "state", Size: 4, Values: []BTFEnumValue{
+		{Name: "STATE_BUSY", Value: 1},
+	}}
+	typ := &BTFType{
+		Kind: BTFKindStruct,
+		Name: "key",
+		Size: 20,
+		Members: []BTFMember{
+			{Name: "pid", Offset: 0, Type: btfU32},
+			{Name: "comm", Offset: 32, Type: &BTFType{Kind: BTFKindArray, Size: 8, Elem: btfChar, Len: 8}},
+			{Name: "state", Offset: 96, Type: state},
+			{Name: "flag", Offset: 128, BitfieldSize: 1, Type: btfU32},
+			{Name: "level", Offset: 129, BitfieldSize: 3, Type: btfU32},
+		},
+	}
+
+	got, err := encodeBTF(typ, `{"pid": 1000, "comm": "sshd", "state": "STATE_BUSY", "flag": 1, "level": 5}`)
+	if err != nil {
+		t.Fatalf("encodeBTF() error = %v", err)
+	}
+
+	want := make([]byte, 20)
+	want[0], want[1] = 0xe8, 0x03
+	copy(want[4:], "sshd")
+	want[12] = 1
+	want[16] = 0x01 | 5<<1
+	if !bytes.Equal(got, want) {
+		t.Errorf("encodeBTF() = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeBTFScalarsAndArrays(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   *BTFType
+		input string
+		want  []byte
+	}{
+		{"unsigned int", btfU32, `42`, []byte{42, 0, 0, 0}},
+		{"signed negative", btfS16, `-2`, []byte{0xfe, 0xff}},
+		{"bool", btfBool, `true`, []byte{1}},
+		{"int array", &BTFType{Kind: BTFKindArray, Size: 8, Elem: btfU32, Len: 2}, `[1, 2]`, []byte{1, 0, 0, 0, 2, 0, 0, 0}},
+		{"short array zero-fills", &BTFType{Kind: BTFKindArray, Size: 8, Elem: btfU32, Len: 2}, `[1]`, []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeBTF(tt.typ, tt.input)
+			if err != nil {
+				t.Fatalf("encodeBTF(%s) error = %v", tt.input, err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("encodeBTF(%s) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeBTFErrors(t *testing.T) {
+	typ := &BTFType{Kind: BTFKindStruct, Name: "key", Size: 4, Members: []BTFMember{
+		{Name: "pid", Offset: 0, Type: btfU32},
+	}}
+
+	tests := []struct {
+		name  string
+		typ   *BTFType
+		input string
+		want  string
+	}{
+		{"invalid JSON", typ, `{"pid":`, "invalid JSON"},
+		{"unknown member", typ, `{"tid": 1}`, `no member "tid"`},
+		{"wrong kind", typ, `[1]`, "expected an object"},
+		{"out of range", btfS16, `40000`, "invalid 2-byte integer"},
+		{"negative unsigned", btfU32, `-1`, "unsigned"},
+		{"unknown enumerator", &BTFType{Kind: BTFKindEnum, Name: "e", Size: 4}, `"NOPE"`, `no value "NOPE"`},
+		{"string too long", &BTFType{Kind: BTFKindArray, Size: 2, Elem: btfChar, Len: 2}, `"abc"`, "longer than 2 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeBTF(tt.typ, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("encodeBTF(%s) error = %v, want error containing %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBTFArrayOfStructs(t *testing.T) {
+	point := &BTFType{
+		Kind: BTFKindStruct,
+		Size: 8,
+		Members: []BTFMember{
+			{Name: "", Offset: 0, Type: btfU64},
+		},
+	}
+	arr := &BTFType{Kind: BTFKindArray, Size: 16, Elem: point, Len: 2}
+
+	data := make([]byte, 16)
+	data[0] = 1
+	data[8] = 2
+
+	got := formatBTF(arr, data)
+	if !strings.Contains(got, `"(anon)": 1`) || !strings.Contains(got, `"(anon)": 2`) {
+		t.Errorf("array of structs should render each element, got:\n%s", got)
+	}
+	if !strings.HasPrefix(got, "[\n") || !strings.HasSuffix(got, "\n]") {
+		t.Errorf("array of structs should span multiple lines, got:\n%s", got)
+	}
+}
+
+func TestSignExtend(t *testing.T) {
+	tests := []struct {
+		v    uint64
+		bits uint32
+		want int64
+	}{
+		{0x7, 3, -1},
+		{0x3, 3, 3},
+		{0xff, 8, -1},
+		{0x80000000, 32, -2147483648},
+		{42, 64, 42},
+	}
+
+	for _, tt := range tests {
+		if got := int64(signExtend(tt.v, tt.bits)); got != tt.want {
+			t.Errorf("signExtend(%#x, %d) = %d, want %d", tt.v, tt.bits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+	"golang.org/x/sys/unix"
+)
+
+// bpfMapInfo mirrors the kernel's struct bpf_map_info.
+// cilium/ebpf doesn't expose the BTF key/value type IDs, so we query them directly.
+type bpfMapInfo struct {
+	Type                  uint32
+	ID                    uint32
+	KeySize               uint32
+	ValueSize             uint32
+	MaxEntries            uint32
+	MapFlags              uint32
+	Name                  [16]byte
+	Ifindex               uint32
+	BTFVmlinuxValueTypeID uint32
+	NetnsDev              uint64
+	NetnsIno              uint64
+	BTFID                 uint32
+	BTFKeyTypeID          uint32
+	BTFValueTypeID        uint32
+	BTFVmlinuxID          uint32
+	MapExtra              uint64
+}
+
+// objGetInfoByFD fills info (a pointer to a kernel info struct) for the BPF object fd.
+// The info pointer is kept as an unsafe.Pointer so the GC tracks it; like
+// cilium/ebpf's sys.Pointer, this assumes a 64-bit architecture.
+func objGetInfoByFD(fd int, info unsafe.Pointer, size uintptr) error {
+	attr := struct {
+		fd      uint32
+		infoLen uint32
+		info    unsafe.Pointer
+	}{
+		fd:      uint32(fd),
+		infoLen: uint32(size),
+		info:    info,
+	}
+	_, _, errno := unix.Syscall(unix.SYS_BPF, unix.BPF_OBJ_GET_INFO_BY_FD,
+		uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// MapBTF returns the BTF key and value types of a map, or nil if the map has no BTF.
+func (a *MapsServiceAdapter) MapBTF(id uint32) (*MapBTF, error) {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map by ID %d: %w", id, err)
+	}
+	defer m.Close()
+
+	var info bpfMapInfo
+	if err := objGetInfoByFD(m.FD(), unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return nil, fmt.Errorf("failed to get map info: %w", err)
+	}
+	if info.BTFID == 0 || (info.BTFKeyTypeID == 0 && info.BTFValueTypeID == 0) {
+		return nil, nil
+	}
+
+	spec, err := loadBTFSpec(btf.ID(info.BTFID))
+	if err != nil {
+		return nil, err
+	}
+
+	conv := newBTFConverter()
+	result := &MapBTF{}
+	if info.BTFKeyTypeID != 0 {
+		t, err := spec.TypeByID(btf.TypeID(info.BTFKeyTypeID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve key type: %w", err)
+		}
+		result.Key = conv.convert(t)
+	}
+	if info.BTFValueTypeID != 0 {
+		t, err := spec.TypeByID(btf.TypeID(info.BTFValueTypeID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve value type: %w", err)
+		}
+		result.Value = conv.convert(t)
+	}
+	return result, nil
+}
+
+// loadBTFSpec loads the BTF object with the given ID.
+// Split BTF (e.g. kernel module BTF) is loaded on top of the vmlinux BTF.
+func loadBTFSpec(id btf.ID) (*btf.Spec, error) {
+	h, err := btf.NewHandleFromID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BTF by ID %d: %w", id, err)
+	}
+	defer h.Close()
+
+	spec, err := h.Spec(nil)
+	if err == nil {
+		return spec, nil
+	}
+
+	base, baseErr := btf.LoadKernelSpec()
+	if baseErr != nil {
+		return nil, fmt.Errorf("failed to load BTF %d: %w", id, errors.Join(err, baseErr))
+	}
+	spec, err = h.Spec(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BTF %d: %w", id, err)
+	}
+	return spec, nil
+}
+
+// btfConverter converts cilium/ebpf BTF types to BTFType, sharing the
+// result for types referenced more than once.
+type btfConverter struct {
+	seen map[btf.Type]*BTFType
+}
+
+// newBTFConverter creates a new converter.
+func newBTFConverter() *btfConverter {
+	return &btfConverter{seen: make(map[btf.Type]*BTFType)}
+}
+
+// convert converts t, resolving typedefs and qualifiers.
+func (c *btfConverter) convert(t btf.Type) *BTFType {
+	t = btf.UnderlyingType(t)
+	if bt, ok := c.seen[t]; ok {
+		return bt
+	}
+
+	bt := &BTFType{Name: t.TypeName()}
+	c.seen[t] = bt
+	if size, err := btf.Sizeof(t); err == nil {
+		bt.Size = uint32(size)
+	}
+
+	switch t := t.(type) {
+	case *btf.Int:
+		bt.Kind = BTFKindInt
+		bt.Signed = t.Encoding&btf.Signed != 0
+		bt.Char = t.Encoding&btf.Char != 0
+		bt.Bool = t.Encoding&btf.Bool != 0
+
+	case *btf.Float:
+		bt.Kind = BTFKindFloat
+
+	case *btf.Pointer:
+		// Pointer targets aren't followed: they may be recursive and their
+		// memory isn't part of the map value anyway
+		bt.Kind = BTFKindPointer
+
+	case *btf.Array:
+		bt.Kind = BTFKindArray
+		bt.Elem = c.convert(t.Type)
+		bt.Len = t.Nelems
+
+	case *btf.Struct:
+		bt.Kind = BTFKindStruct
+		bt.Members = c.convertMembers(t.Members)
+
+	case *btf.Union:
+		bt.Kind = BTFKindUnion
+		bt.Members = c.convertMembers(t.Members)
+
+	case *btf.Enum:
+		bt.Kind = BTFKindEnum
+		bt.Signed = t.Signed
+		bt.Values = make([]BTFEnumValue, len(t.Values))
+		for i, v := range t.Values {
+			bt.Values[i] = BTFEnumValue{Name: v.Name, Value: v.Value}
+		}
+
+	default:
+		bt.Kind = BTFKindUnknown
+	}
+	return bt
+}
+
+// convertMembers converts struct or union members.
+func (c *btfConverter) convertMembers(members []btf.Member) []BTFMember {
+	result := make([]BTFMember, len(members))
+	for i, mem := range members {
+		result[i] = BTFMember{
+			Name:         mem.Name,
+			Offset:       uint32(mem.Offset),
+			BitfieldSize: uint32(mem.BitfieldSize),
+			Type:         c.convert(mem.Type),
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+func TestBTFConverter(t *testing.T) {
+	u32 := &btf.Int{Name: "u32", Size: 4, Encoding: btf.Unsigned}
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed | btf.Char}
+	typedef := &btf.Typedef{Name: "pid_t", Type: u32}
+	s := &btf.Struct{
+		Name: "event",
+		Size: 24,
+		Members: []btf.Member{
+			{Name: "pid", Type: typedef, Offset: 0},
+			{Name: "comm", Type: &btf.Array{Type: char, Nelems: 16}, Offset: 32},
+			{Name: "self", Type: &btf.Pointer{Target: u32}, Offset: 128},
+		},
+	}
+
+	got := newBTFConverter().convert(&btf.Const{Type: s})
+
+	if got.Kind != BTFKindStruct || got.Name != "event" || got.Size != 24 {
+		t.Fatalf("struct = %+v, want Kind=Struct Name=event Size=24", got)
+	}
+	if len(got.Members) != 3 {
+		t.Fatalf("members = %d, want 3", len(got.Members))
+	}
+
+	pid := got.Members[0]
+	if pid.Type.Kind != BTFKindInt || pid.Type.Size != 4 || pid.Type.Signed {
+		t.Errorf("pid typedef should resolve to unsigned 4-byte int, got %+v", pid.Type)
+	}
+
+	comm := got.Members[1]
+	if comm.Offset != 32 || comm.Type.Kind != BTFKindArray || comm.Type.Len != 16 || comm.Type.Size != 16 {
+		t.Errorf("comm = %+v, want 16-byte array at offset 32", comm)
+	}
+	if !comm.Type.Elem.Char {
+		t.Error("comm element should be a char")
+	}
+
+	if got.Members[2].Type.Kind != BTFKindPointer || got.Members[2].Type.Size != 8 {
+		t.Errorf("self = %+v, want 8-byte pointer", got.Members[2].Type)
+	}
+}
+
+func TestBTFConverterSharesTypes(t *testing.T) {
+	u32 := &btf.Int{Name: "u32", Size: 4}
+	s := &btf.Struct{
+		Size: 8,
+		Members: []btf.Member{
+			{Name: "a", Type: u32, Offset: 0},
+			{Name: "b", Type: u32, Offset: 32},
+		},
+	}
+
+	got := newBTFConverter().convert(s)
+	if got.Members[0].Type != got.Members[1].Type {
+		t.Error("identical member types should be converted once")
+	}
+}
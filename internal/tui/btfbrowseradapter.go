@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+)
+
+// BTFServiceAdapter implements BTFService on top of the kernel's BTF objects.
+// The most recently loaded spec is cached: parsing vmlinux BTF takes a
+// noticeable fraction of a second, and definitions are fetched one at a time.
+type BTFServiceAdapter struct {
+	mu     sync.Mutex
+	specID uint32
+	spec   *btf.Spec
+}
+
+// NewBTFServiceAdapter creates a new BTF service adapter.
+func NewBTFServiceAdapter() *BTFServiceAdapter {
+	return &BTFServiceAdapter{}
+}
+
+// List returns all loaded BTF objects. Objects that disappear while being
+// listed are skipped.
+func (a *BTFServiceAdapter) List() ([]BTFObjectInfo, error) {
+	owners := btfOwners()
+
+	it := new(btf.HandleIterator)
+	var objects []BTFObjectInfo
+	for it.Next() {
+		info, err := it.Handle.Info()
+		if err != nil {
+			continue
+		}
+
+		obj := BTFObjectInfo{ID: uint32(info.ID), Name: info.Name}
+		switch {
+		case info.IsVmlinux():
+			obj.Kind = "vmlinux"
+		case info.IsModule():
+			obj.Kind = "module"
+		default:
+			obj.Kind = "program"
+			obj.Owners = owners[obj.ID]
+		}
+		objects = append(objects, obj)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list BTF objects: %w", err)
+	}
+	return objects, nil
+}
+
+// btfOwners maps BTF object IDs to the programs and maps that use them.
+// This is best effort: objects that can't be opened are left out.
+func btfOwners() map[uint32][]string {
+	owners := make(map[uint32][]string)
+
+	for id := ebpf.ProgramID(0); ; {
+		next, err := ebpf.ProgramGetNextID(id)
+		if err != nil {
+			break
+		}
+		id = next
+		prog, err := ebpf.NewProgramFromID(id)
+		if err != nil {
+			continue
+		}
+		if info, err := prog.Info(); err == nil {
+			if btfID, ok := info.BTFID(); ok {
+				owners[uint32(btfID)] = append(owners[uint32(btfID)], "prog "+cmp.Or(info.Name, fmt.Sprint(id)))
+			}
+		}
+		prog.Close()
+	}
+
+	for id := ebpf.MapID(0); ; {
+		next, err := ebpf.MapGetNextID(id)
+		if err != nil {
+			break
+		}
+		id = next
+		m, err := ebpf.NewMapFromID(id)
+		if err != nil {
+			continue
+		}
+		if info, err := m.Info(); err == nil {
+			if btfID, ok := info.BTFID(); ok {
+				owners[uint32(btfID)] = append(owners[uint32(btfID)], "map "+cmp.Or(info.Name, fmt.Sprint(id)))
+			}
+		}
+		m.Close()
+	}
+
+	return owners
+}
+
+// Types returns the named types of a BTF object, sorted by name.
+// For module BTF, only the module's own types are returned, not vmlinux's.
+func (a *BTFServiceAdapter) Types(id uint32) ([]BTFTypeInfo, error) {
+	spec, err := a.loadSpec(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []BTFTypeInfo
+	for t, err := range spec.All() {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read BTF %d: %w", id, err)
+		}
+		if t.TypeName() == "" {
+			continue
+		}
+		info, err := btfTypeInfo(spec, id, t, "")
+		if err != nil {
+			continue
+		}
+		types = append(types, info)
+	}
+
+	slices.SortFunc(types, func(a, b BTFTypeInfo) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.ID, b.ID))
+	})
+	return types, nil
+}
+
+// Definition returns a C-like definition of a type.
+func (a *BTFServiceAdapter) Definition(id, typeID uint32) (string, error) {
+	spec, err := a.loadSpec(id)
+	if err != nil {
+		return "", err
+	}
+
+	t, err := spec.TypeByID(btf.TypeID(typeID))
+	if err != nil {
+		return "", fmt.Errorf("failed to find type %d in BTF %d: %w", typeID, id, err)
+	}
+	return formatCDecl(t), nil
+}
+
+// MapTypes returns a map's key and value types, or nil if the map has no BTF.
+func (a *BTFServiceAdapter) MapTypes(mapID uint32) ([]BTFTypeInfo, error) {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(mapID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map by ID %d: %w", mapID, err)
+	}
+	defer m.Close()
+
+	var info bpfMapInfo
+	if err := objGetInfoByFD(m.FD(), unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return nil, fmt.Errorf("failed to get map info: %w", err)
+	}
+	if info.BTFID == 0 {
+		return nil, nil
+	}
+
+	spec, err := a.loadSpec(info.BTFID)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []BTFTypeInfo
+	for _, ref := range []struct {
+		label  string
+		typeID uint32
+	}{
+		{"key", info.BTFKeyTypeID},
+		{"value", info.BTFValueTypeID},
+	} {
+		if ref.typeID == 0 {
+			continue
+		}
+		t, err := spec.TypeByID(btf.TypeID(ref.typeID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s type: %w", ref.label, err)
+		}
+		types = append(types, BTFTypeInfo{
+			ObjID: info.BTFID,
+			ID:    ref.typeID,
+			Kind:  btfKindName(t),
+			Name:  cmp.Or(t.TypeName(), cDecl(t, "", 0)),
+			Label: ref.label,
+		})
+	}
+	return types, nil
+}
+
+// bpfProgInfo mirrors the kernel's struct bpf_prog_info up to the func info
+// fields. cilium/ebpf resolves func info against a spec of its own without
+// the type IDs, so we query them directly.
+type bpfProgInfo struct {
+	Type            uint32
+	ID              uint32
+	Tag             [8]byte
+	JitedProgLen    uint32
+	XlatedProgLen   uint32
+	JitedProgInsns  uint64
+	XlatedProgInsns uint64
+	LoadTime        uint64
+	CreatedByUID    uint32
+	NrMapIDs        uint32
+	MapIDs          uint64
+	Name            [16]byte
+	Ifindex         uint32
+	GPLCompatible   uint32
+	NetnsDev        uint64
+	NetnsIno        uint64
+	NrJitedKsyms    uint32
+	NrJitedFuncLens uint32
+	JitedKsyms      uint64
+	JitedFuncLens   uint64
+	BTFID           uint32
+	FuncInfoRecSize uint32
+	FuncInfo        unsafe.Pointer
+	NrFuncInfo      uint32
+	NrLineInfo      uint32
+}
+
+// bpfFuncInfo mirrors the kernel's struct bpf_func_info.
+type bpfFuncInfo struct {
+	InsnOff uint32
+	TypeID  uint32
+}
+
+// ProgFuncs returns a program's functions from its BTF func info, in the
+// order they appear in the program, or nil if the program has no BTF.
+func (a *BTFServiceAdapter) ProgFuncs(progID uint32) ([]BTFTypeInfo, error) {
+	prog, err := ebpf.NewProgramFromID(ebpf.ProgramID(progID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program by ID %d: %w", progID, err)
+	}
+	defer prog.Close()
+
+	var info bpfProgInfo
+	if err := objGetInfoByFD(prog.FD(), unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return nil, fmt.Errorf("failed to get program info: %w", err)
+	}
+	if info.BTFID == 0 || info.NrFuncInfo == 0 {
+		return nil, nil
+	}
+
+	// A second query fills in the records, now that their number is known
+	funcs := make([]bpfFuncInfo, info.NrFuncInfo)
+	info = bpfProgInfo{
+		FuncInfoRecSize: uint32(unsafe.Sizeof(funcs[0])),
+		FuncInfo:        unsafe.Pointer(&funcs[0]),
+		NrFuncInfo:      uint32(len(funcs)),
+	}
+	if err := objGetInfoByFD(prog.FD(), unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return nil, fmt.Errorf("failed to get func info: %w", err)
+	}
+
+	spec, err := a.loadSpec(info.BTFID)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []BTFTypeInfo
+	for _, fn := range funcs[:min(info.NrFuncInfo, uint32(len(funcs)))] {
+		t, err := spec.TypeByID(btf.TypeID(fn.TypeID))
+		if err != nil {
+			continue
+		}
+		label := "subprog"
+		if fn.InsnOff == 0 {
+			label = "entry"
+		}
+		types = append(types, BTFTypeInfo{
+			ObjID: info.BTFID,
+			ID:    fn.TypeID,
+			Kind:  btfKindName(t),
+			Name:  t.TypeName(),
+			Label: label,
+		})
+	}
+	return types, nil
+}
+
+// btfTypeInfo describes t, a type of the spec loaded from BTF object objID.
+func btfTypeInfo(spec *btf.Spec, objID uint32, t btf.Type, label string) (BTFTypeInfo, error) {
+	typeID, err := spec.TypeID(t)
+	if err != nil {
+		return BTFTypeInfo{}, err
+	}
+	return BTFTypeInfo{
+		ObjID: objID,
+		ID:    uint32(typeID),
+		Kind:  btfKindName(t),
+		Name:  t.TypeName(),
+		Label: label,
+	}, nil
+}
+
+// loadSpec returns the spec of a BTF object, reusing the cached one if it
+// is the same object.
+func (a *BTFServiceAdapter) loadSpec(id uint32) (*btf.Spec, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.spec != nil && a.specID == id {
+		return a.spec, nil
+	}
+	spec, err := loadBTFSpec(btf.ID(id))
+	if err != nil {
+		return nil, err
+	}
+	a.specID, a.spec = id, spec
+	return spec, nil
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// btfItem represents a BTF object in the list.
+type btfItem struct {
+	info BTFObjectInfo
+}
+
+// FilterValue implements list.Item interface for fuzzy filtering.
+// Objects are matched on name, kind and the programs and maps using them.
+func (i btfItem) FilterValue() string {
+	return strings.Join(append([]string{i.info.Name, i.info.Kind}, i.info.Owners...), " ")
+}
+
+// Title returns the BTF object title for display (ID and Name).
+func (i btfItem) Title() string {
+	return fmt.Sprintf("[%d] %s", i.info.ID, i.displayName())
+}
+
+// displayName returns the BTF object's name. Userspace BTF has no name,
+// so it's named after its first user.
+func (i btfItem) displayName() string {
+	name := i.info.Name
+	if name == "" && len(i.info.Owners) > 0 {
+		name = i.info.Owners[0]
+	}
+	return cmp.Or(name, "(unnamed)")
+}
+
+// Description returns the BTF object description for display (Kind and users).
+func (i btfItem) Description() string {
+	desc := "Kind: " + i.info.Kind
+	if len(i.info.Owners) > 0 {
+		desc += " | Used by: " + strings.Join(i.info.Owners, ", ")
+	}
+	return desc
+}
+
+// btfListModel manages the BTF objects list state.
+type btfListModel struct {
+	list    list.Model
+	objects []BTFObjectInfo
+	err     error
+	loading bool
+	spinner spinner.Model
+}
+
+// newBTFListModel creates a new BTF objects list model.
+func newBTFListModel(width, height int) btfListModel {
+	// Create delegate for custom item rendering
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = selectedStyle
+	delegate.Styles.SelectedDesc = selectedStyle.Foreground(dimStyle.GetForeground())
+
+	// Calculate list dimensions (leave room for title and help bar)
+	listHeight := height - 6
+	if listHeight < 3 {
+		listHeight = 3
+	}
+
+	l := list.New([]list.Item{}, delegate, width, listHeight)
+	l.Title = "BTF Objects"
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+	l.Styles.Title = titleStyle
+
+	return btfListModel{
+		list:    l,
+		objects: []BTFObjectInfo{},
+		spinner: newSpinner(),
+	}
+}
+
+// Init implements tea.Model for btfListModel.
+func (m btfListModel) Init() tea.Cmd {
+	return nil
+}
+
+// SetObjects updates the list with new BTF object data.
+func (m *btfListModel) SetObjects(objects []BTFObjectInfo) {
+	m.objects = objects
+	m.loading = false
+	m.err = nil
+	items := make([]list.Item, len(objects))
+	for i, obj := range objects {
+		items[i] = btfItem{info: obj}
+	}
+	m.list.Title = "BTF Objects"
+	m.list.SetItems(items)
+}
+
+// Update handles messages for the BTF objects list.
+// Returns the updated model, an optional command, and the selected BTF object if Enter was pressed.
+func (m btfListModel) Update(msg tea.Msg) (btfListModel, tea.Cmd, *BTFObjectInfo) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
+	case tea.KeyMsg:
+		// Don't handle enter if we're filtering
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "enter":
+			// Get selected item and return its BTF object info
+			if item, ok := m.list.SelectedItem().(btfItem); ok {
+				return m, nil, &item.info
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd, nil
+}
+
+// View renders the BTF objects list.
+func (m btfListModel) View() string {
+	if m.loading {
+		return titleStyle.Render("BTF Objects") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading BTF objects...")
+	}
+
+	if len(m.objects) == 0 && m.err == nil {
+		return titleStyle.Render("BTF Objects") + "\n\n" +
+			dimStyle.Render("No BTF objects loaded")
+	}
+
+	if m.err != nil {
+		return titleStyle.Render("BTF Objects") + "\n\n" +
+			errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	return m.list.View()
+}
+
+// SetSize updates the list dimensions.
+func (m *btfListModel) SetSize(width, height int) {
+	listHeight := height - 6
+	if listHeight < 3 {
+		listHeight = 3
+	}
+	m.list.SetSize(width, listHeight)
+}
+
+// SetError sets an error state for the list.
+func (m *btfListModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *btfListModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.err = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// IsLoading returns true if BTF objects are being loaded.
+func (m btfListModel) IsLoading() bool {
+	return m.loading
+}
+
+// SelectedItem returns the currently selected BTF object, if any.
+func (m btfListModel) SelectedItem() *BTFObjectInfo {
+	if item, ok := m.list.SelectedItem().(btfItem); ok {
+		return &item.info
+	}
+	return nil
+}
+
+// IsFiltering returns true if the list is currently in filtering mode.
+func (m btfListModel) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
+// ResetFilter clears any active filter and restores the full list.
+func (m *btfListModel) ResetFilter() {
+	m.list.ResetFilter()
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewBTFListModel(t *testing.T) {
+	m := newBTFListModel(80, 24)
+
+	if m.list.Title != "BTF Objects" {
+		t.Errorf("expected title 'BTF Objects', got '%s'", m.list.Title)
+	}
+	if len(m.objects) != 0 {
+		t.Errorf("expected empty objects slice, got %d items", len(m.objects))
+	}
+}
+
+func TestBTFItemInterface(t *testing.T) {
+	item := btfItem{info: BTFObjectInfo{ID: 1, Name: "vmlinux", Kind: "vmlinux"}}
+	if got, want := item.Title(), "[1] vmlinux"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := item.Description(), "Kind: vmlinux"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+
+	// Program BTF has no name and is named after its users
+	item = btfItem{info: BTFObjectInfo{ID: 40, Kind: "program", Owners: []string{"prog xdp_main", "map events"}}}
+	if got, want := item.Title(), "[40] prog xdp_main"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := item.Description(), "Kind: program | Used by: prog xdp_main, map events"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+	if got, want := item.FilterValue(), " program prog xdp_main map events"; got != want {
+		t.Errorf("FilterValue() = %q, want %q", got, want)
+	}
+
+	item = btfItem{info: BTFObjectInfo{ID: 41, Kind: "program"}}
+	if got, want := item.Title(), "[41] (unnamed)"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+}
+
+func TestBTFListUpdateEnterKey(t *testing.T) {
+	m := newBTFListModel(80, 24)
+	m.SetObjects([]BTFObjectInfo{
+		{ID: 1, Name: "vmlinux", Kind: "vmlinux"},
+		{ID: 2, Name: "nf_tables", Kind: "module"},
+	})
+
+	_, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if selected == nil {
+		t.Fatal("expected selected object, got nil")
+	}
+	if selected.ID != 1 {
+		t.Errorf("expected object ID 1, got %d", selected.ID)
+	}
+}
+
+func TestBTFListView(t *testing.T) {
+	m := newBTFListModel(80, 24)
+	if view := m.View(); !strings.Contains(view, "No BTF objects loaded") {
+		t.Errorf("empty view should say no objects are loaded, got %q", view)
+	}
+
+	m.SetObjects([]BTFObjectInfo{{ID: 1, Name: "vmlinux", Kind: "vmlinux"}})
+	if view := m.View(); !strings.Contains(view, "[1] vmlinux") {
+		t.Errorf("view should list the object, got %q", view)
+	}
+
+	m.SetError(errors.New("boom"))
+	if view := m.View(); !strings.Contains(view, "Error: boom") {
+		t.Errorf("view should show the error, got %q", view)
+	}
+}
+
+func TestBTFListLoading(t *testing.T) {
+	m := newBTFListModel(80, 24)
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading BTF objects...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetObjects(nil)
+	if m.IsLoading() {
+		t.Error("setting objects should clear the loading state")
+	}
+}
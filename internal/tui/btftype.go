@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// btfTypeModel manages the BTF type definition view state.
+type btfTypeModel struct {
+	info     BTFTypeInfo
+	def      string // C-like definition
+	viewport viewport.Model
+	width    int
+	height   int
+	ready    bool
+	loading  bool
+	spinner  spinner.Model
+	err      error
+}
+
+// newBTFTypeModel creates a new type definition model.
+func newBTFTypeModel(width, height int) btfTypeModel {
+	return btfTypeModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+	}
+}
+
+// StartLoading prepares the view for the definition of the given type,
+// clearing any previous definition or error.
+func (m *btfTypeModel) StartLoading(info BTFTypeInfo) tea.Cmd {
+	m.info = info
+	m.def = ""
+	m.err = nil
+	return m.SetLoading(true)
+}
+
+// SetDefinition displays a type definition.
+func (m *btfTypeModel) SetDefinition(def string) {
+	m.def = def
+	m.loading = false
+	m.err = nil
+	m.updateViewport()
+	m.viewport.GotoTop()
+}
+
+// SetError sets an error state for the definition view.
+func (m *btfTypeModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.updateViewport()
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *btfTypeModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	m.updateViewport()
+	if loading {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the viewport dimensions.
+func (m *btfTypeModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if m.ready {
+		m.viewport.Width = width
+		m.viewport.Height = height - 4 // Leave room for title and help bar
+	}
+	m.updateViewport()
+}
+
+// updateViewport refreshes the viewport content.
+func (m *btfTypeModel) updateViewport() {
+	content := m.renderContent()
+
+	if !m.ready {
+		m.viewport = viewport.New(m.width, m.height-4)
+		m.viewport.SetContent(content)
+		m.ready = true
+	} else {
+		m.viewport.SetContent(content)
+	}
+}
+
+// renderContent generates the definition content.
+func (m *btfTypeModel) renderContent() string {
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	if m.loading {
+		return m.spinner.View() + dimStyle.Render(" Loading definition...")
+	}
+
+	if m.def == "" {
+		return dimStyle.Render("No definition available")
+	}
+
+	return valueStyle.Render(m.def)
+}
+
+// Init implements tea.Model for btfTypeModel.
+func (m btfTypeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the definition view.
+func (m btfTypeModel) Update(msg tea.Msg) (btfTypeModel, tea.Cmd) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		m.updateViewport()
+		return m, cmd
+	}
+
+	// Handle viewport scrolling for other keys
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View renders the definition view.
+func (m btfTypeModel) View() string {
+	title := titleStyle.Render(fmt.Sprintf("%s %s (BTF %d, type %d)", m.info.Kind, m.info.Name, m.info.ObjID, m.info.ID))
+
+	if !m.ready {
+		return title + "\n\nLoading..."
+	}
+
+	return title + "\n\n" + m.viewport.View()
+}
+
+// GetType returns the type being shown.
+func (m btfTypeModel) GetType() BTFTypeInfo {
+	return m.info
+}
+
+// HasError returns true if there's an error state.
+func (m btfTypeModel) HasError() bool {
+	return m.err != nil
+}
+
+// IsLoading returns true if the definition is loading.
+func (m btfTypeModel) IsLoading() bool {
+	return m.loading
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBTFTypeModel(t *testing.T) {
+	m := newBTFTypeModel(80, 24)
+	info := BTFTypeInfo{ObjID: 1, ID: 42, Kind: "struct", Name: "event"}
+
+	if cmd := m.StartLoading(info); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading definition...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetDefinition("struct event {\n    u32 pid;\n};")
+	view := m.View()
+	for _, want := range []string{"struct event (BTF 1, type 42)", "u32 pid;"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got %q", want, view)
+		}
+	}
+	if m.GetType() != info {
+		t.Errorf("GetType() = %+v, want %+v", m.GetType(), info)
+	}
+
+	m.SetDefinition("")
+	if !strings.Contains(m.View(), "No definition available") {
+		t.Error("view should say there's no definition")
+	}
+
+	m.SetError(errors.New("boom"))
+	if !m.HasError() || !strings.Contains(m.View(), "Error: boom") {
+		t.Error("view should show the error")
+	}
+}
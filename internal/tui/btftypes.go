@@ -0,0 +1,309 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// btfTypesModel manages the BTF type browser: a searchable list of the types
+// of a BTF object, or of the types a map or program refers to.
+// vmlinux has over 100,000 named types, so rather than the fuzzy-filtered
+// list component, it renders only the visible rows and searches by substring.
+type btfTypesModel struct {
+	title     string
+	types     []BTFTypeInfo
+	matches   []int // Indexes into types matching the search, best first
+	cursor    int   // Index into matches
+	offset    int   // First visible match
+	search    textinput.Model
+	searching bool // Typing a search
+	width     int
+	height    int
+	loading   bool
+	spinner   spinner.Model
+	err       error
+}
+
+// newBTFTypesModel creates a new type browser model.
+func newBTFTypesModel(width, height int) btfTypesModel {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "type name"
+	search.Width = width - 4
+
+	return btfTypesModel{
+		width:   width,
+		height:  height,
+		search:  search,
+		spinner: newSpinner(),
+	}
+}
+
+// StartLoading prepares the browser for a new set of types, clearing any
+// previous types, search or error.
+func (m *btfTypesModel) StartLoading(title string) tea.Cmd {
+	m.title = title
+	m.types = nil
+	m.matches = nil
+	m.err = nil
+	m.searching = false
+	m.search.Blur()
+	m.search.SetValue("")
+	return m.SetLoading(true)
+}
+
+// SetTypes sets the types to browse.
+func (m *btfTypesModel) SetTypes(types []BTFTypeInfo) {
+	m.types = types
+	m.loading = false
+	m.err = nil
+	m.applySearch()
+}
+
+// SetError sets an error state for the browser.
+func (m *btfTypesModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *btfTypesModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the browser dimensions.
+func (m *btfTypesModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.search.Width = width - 4
+	m.scrollToCursor()
+}
+
+// applySearch recomputes the matches for the current search: exact name
+// matches first, then prefix matches, then names containing the search,
+// each in the order of the types. Matching ignores case.
+func (m *btfTypesModel) applySearch() {
+	m.cursor = 0
+	m.offset = 0
+	m.matches = m.matches[:0]
+
+	query := strings.ToLower(strings.TrimSpace(m.search.Value()))
+	if query == "" {
+		for i := range m.types {
+			m.matches = append(m.matches, i)
+		}
+		return
+	}
+
+	var prefix, contains []int
+	for i, t := range m.types {
+		name := strings.ToLower(t.Name)
+		switch {
+		case name == query:
+			m.matches = append(m.matches, i)
+		case strings.HasPrefix(name, query):
+			prefix = append(prefix, i)
+		case strings.Contains(name, query):
+			contains = append(contains, i)
+		}
+	}
+	m.matches = append(append(m.matches, prefix...), contains...)
+}
+
+// visibleRows returns how many types fit on screen.
+func (m btfTypesModel) visibleRows() int {
+	// Title, search line, status line, help bar and spacing
+	return max(m.height-8, 1)
+}
+
+// moveCursor moves the cursor by delta matches, clamped to the list.
+func (m *btfTypesModel) moveCursor(delta int) {
+	m.cursor = max(min(m.cursor+delta, len(m.matches)-1), 0)
+	m.scrollToCursor()
+}
+
+// scrollToCursor adjusts the offset so that the cursor is visible.
+func (m *btfTypesModel) scrollToCursor() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// Init implements tea.Model for btfTypesModel.
+func (m btfTypesModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the type browser.
+// Returns the updated model, an optional command, and the selected type if Enter was pressed.
+func (m btfTypesModel) Update(msg tea.Msg) (btfTypesModel, tea.Cmd, *BTFTypeInfo) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+	}
+
+	if m.searching {
+		return m.updateSearch(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil, nil
+	}
+
+	switch keyMsg.String() {
+	case "/":
+		m.searching = true
+		return m, m.search.Focus(), nil
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.visibleRows())
+	case "pgdown":
+		m.moveCursor(m.visibleRows())
+	case "home", "g":
+		m.moveCursor(-len(m.matches))
+	case "end", "G":
+		m.moveCursor(len(m.matches))
+	case "enter":
+		if selected := m.SelectedType(); selected != nil {
+			return m, nil, selected
+		}
+	}
+	return m, nil, nil
+}
+
+// updateSearch handles messages while a search is being typed.
+// Matches update as the search is typed; Enter keeps them and Esc clears them.
+func (m btfTypesModel) updateSearch(msg tea.Msg) (btfTypesModel, tea.Cmd, *BTFTypeInfo) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.searching = false
+			m.search.Blur()
+			m.search.SetValue("")
+			m.applySearch()
+			return m, nil, nil
+
+		case "enter":
+			m.searching = false
+			m.search.Blur()
+			return m, nil, nil
+
+		case "up", "down":
+			// Allow moving through the matches without leaving the search
+			if msg.String() == "up" {
+				m.moveCursor(-1)
+			} else {
+				m.moveCursor(1)
+			}
+			return m, nil, nil
+		}
+	}
+
+	prev := m.search.Value()
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	if m.search.Value() != prev {
+		m.applySearch()
+	}
+	return m, cmd, nil
+}
+
+// View renders the type browser.
+func (m btfTypesModel) View() string {
+	title := titleStyle.Render(m.title)
+
+	if m.loading {
+		return title + "\n\n" + m.spinner.View() + dimStyle.Render(" Loading BTF types...")
+	}
+
+	if m.err != nil {
+		return title + "\n\n" + errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	if len(m.types) == 0 {
+		return title + "\n\n" + dimStyle.Render("No BTF types")
+	}
+
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	switch {
+	case m.searching:
+		b.WriteString(m.search.View())
+	case m.search.Value() != "":
+		b.WriteString(dimStyle.Render("/" + m.search.Value()))
+	default:
+		b.WriteString(dimStyle.Render("Press / to search type names"))
+	}
+	b.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(dimStyle.Render("No matching types"))
+		b.WriteString("\n")
+	}
+
+	end := min(m.offset+m.visibleRows(), len(m.matches))
+	for i := m.offset; i < end; i++ {
+		t := m.types[m.matches[i]]
+		row := fmt.Sprintf("%-10s %s", t.Kind, t.Name)
+		if t.Label != "" {
+			row = fmt.Sprintf("%-8s %s", t.Label+":", row)
+		}
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("▶ " + row))
+		} else {
+			b.WriteString(normalStyle.Render("  " + row))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("%d of %d types", len(m.matches), len(m.types))))
+	return b.String()
+}
+
+// SelectedType returns the type under the cursor, if any.
+func (m btfTypesModel) SelectedType() *BTFTypeInfo {
+	if m.cursor >= len(m.matches) {
+		return nil
+	}
+	t := m.types[m.matches[m.cursor]]
+	return &t
+}
+
+// IsSearching returns true while a search is being typed.
+func (m btfTypesModel) IsSearching() bool {
+	return m.searching
+}
+
+// IsLoading returns true if the types are loading.
+func (m btfTypesModel) IsLoading() bool {
+	return m.loading
+}
+
+// HasError returns true if there's an error state.
+func (m btfTypesModel) HasError() bool {
+	return m.err != nil
+}
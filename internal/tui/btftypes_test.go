@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var testBTFTypes = []BTFTypeInfo{
+	{ObjID: 1, ID: 1, Kind: "struct", Name: "bpf_map"},
+	{ObjID: 1, ID: 2, Kind: "struct", Name: "map"},
+	{ObjID: 1, ID: 3, Kind: "struct", Name: "mapping"},
+	{ObjID: 1, ID: 4, Kind: "typedef", Name: "u32"},
+}
+
+// btfTypesSearch types query into the browser's search and confirms it.
+func btfTypesSearch(m btfTypesModel, query string) btfTypesModel {
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return m
+}
+
+func TestBTFTypesSearchRanking(t *testing.T) {
+	m := newBTFTypesModel(80, 24)
+	m.SetTypes(testBTFTypes)
+
+	m = btfTypesSearch(m, "MAP")
+	if m.IsSearching() {
+		t.Error("enter should finish the search")
+	}
+
+	var got []string
+	for _, i := range m.matches {
+		got = append(got, m.types[i].Name)
+	}
+	want := []string{"map", "mapping", "bpf_map"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("matches = %v, want %v (exact, prefix, then substring)", got, want)
+	}
+	if !strings.Contains(m.View(), "3 of 4 types") {
+		t.Error("view should count the matches")
+	}
+}
+
+func TestBTFTypesSearchEscClears(t *testing.T) {
+	m := newBTFTypesModel(80, 24)
+	m.SetTypes(testBTFTypes)
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u32")})
+	if len(m.matches) != 1 {
+		t.Fatalf("expected 1 match while typing, got %d", len(m.matches))
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsSearching() || len(m.matches) != len(testBTFTypes) {
+		t.Errorf("esc should clear the search, got %d matches", len(m.matches))
+	}
+}
+
+func TestBTFTypesNavigationAndSelect(t *testing.T) {
+	m := newBTFTypesModel(80, 24)
+	m.SetTypes(testBTFTypes)
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if selected == nil || selected.Name != "map" {
+		t.Fatalf("expected map to be selected, got %+v", selected)
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if got := m.SelectedType(); got == nil || got.Name != "u32" {
+		t.Errorf("G should move to the last type, got %+v", got)
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.SelectedType(); got == nil || got.Name != "u32" {
+		t.Errorf("cursor should stop at the last type, got %+v", got)
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if got := m.SelectedType(); got == nil || got.Name != "bpf_map" {
+		t.Errorf("g should move to the first type, got %+v", got)
+	}
+
+	// Nothing to select without matches
+	m = btfTypesSearch(m, "nope")
+	if _, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); selected != nil {
+		t.Errorf("expected no selection without matches, got %+v", selected)
+	}
+	if !strings.Contains(m.View(), "No matching types") {
+		t.Error("view should say nothing matches")
+	}
+}
+
+func TestBTFTypesScrolling(t *testing.T) {
+	m := newBTFTypesModel(80, 12)
+	types := make([]BTFTypeInfo, 50)
+	for i := range types {
+		types[i] = BTFTypeInfo{ID: uint32(i), Kind: "int", Name: "t" + strings.Repeat("x", i)}
+	}
+	m.SetTypes(types)
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.cursor != m.visibleRows() {
+		t.Errorf("page down should move by a page, cursor = %d", m.cursor)
+	}
+	if m.cursor < m.offset || m.cursor >= m.offset+m.visibleRows() {
+		t.Errorf("cursor %d should be visible from offset %d", m.cursor, m.offset)
+	}
+}
+
+func TestBTFTypesView(t *testing.T) {
+	m := newBTFTypesModel(80, 24)
+	cmd := m.StartLoading("Map BTF: events (ID: 1)")
+	if cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if !strings.Contains(m.View(), "Loading BTF types...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetTypes([]BTFTypeInfo{{ID: 1, Kind: "int", Name: "u32", Label: "key"}})
+	view := m.View()
+	for _, want := range []string{"Map BTF: events (ID: 1)", "key:", "int", "u32"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got %q", want, view)
+		}
+	}
+
+	m.SetTypes(nil)
+	if !strings.Contains(m.View(), "No BTF types") {
+		t.Error("view should say there are no types")
+	}
+
+	m.SetError(errors.New("boom"))
+	if !m.HasError() || !strings.Contains(m.View(), "Error: boom") {
+		t.Error("view should show the error")
+	}
+}
@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+// cIndent is one level of indentation in C definitions. Spaces rather than
+// tabs keep the viewport's width calculations right.
+const cIndent = "    "
+
+// formatCDecl renders a C-like definition of a BTF type, in the style of
+// bpftool btf dump format c. Named types referenced by the definition are
+// shown by name; anonymous structs, unions and enums are expanded inline.
+func formatCDecl(t btf.Type) string {
+	switch t := t.(type) {
+	case *btf.Struct:
+		return fmt.Sprintf("/* size: %d bytes */\n%s;", t.Size, cAggregate("struct", t.Name, t.Members, 0, true))
+
+	case *btf.Union:
+		return fmt.Sprintf("/* size: %d bytes */\n%s;", t.Size, cAggregate("union", t.Name, t.Members, 0, true))
+
+	case *btf.Enum:
+		return cEnum(t, 0, true) + ";"
+
+	case *btf.Typedef:
+		return "typedef " + cDecl(t.Type, t.Name, 0) + ";"
+
+	case *btf.Func:
+		return funcLinkage(t.Linkage) + cDecl(t.Type, t.Name, 0) + ";"
+
+	case *btf.Var:
+		return varLinkage(t.Linkage) + cDecl(t.Type, t.Name, 0) + ";"
+
+	case *btf.Datasec:
+		var b strings.Builder
+		fmt.Fprintf(&b, "SEC(\"%s\") {\n", t.Name)
+		for _, v := range t.Vars {
+			b.WriteString(cIndent)
+			b.WriteString(formatCDecl(v.Type))
+			fmt.Fprintf(&b, " /* offset %d size %d */\n", v.Offset, v.Size)
+		}
+		b.WriteString("};")
+		return b.String()
+
+	case *btf.Fwd:
+		return fmt.Sprintf("%s %s;", t.Kind, t.Name)
+
+	case *btf.Int:
+		var enc string
+		switch {
+		case t.Encoding&btf.Bool != 0:
+			enc = "boolean"
+		case t.Encoding&btf.Char != 0:
+			enc = "character"
+		case t.Encoding&btf.Signed != 0:
+			enc = "signed integer"
+		default:
+			enc = "unsigned integer"
+		}
+		return fmt.Sprintf("%s /* %d-bit %s */", t.Name, t.Size*8, enc)
+
+	case *btf.Float:
+		return fmt.Sprintf("%s /* %d-bit float */", t.Name, t.Size*8)
+	}
+
+	return cDecl(t, "", 0)
+}
+
+// cDecl renders a declaration of decl with type t, e.g. "char *argv[4]".
+// An empty decl renders the type alone, as in a cast.
+func cDecl(t btf.Type, decl string, depth int) string {
+	switch t := t.(type) {
+	case *btf.Pointer:
+		inner := "*" + decl
+		switch t.Target.(type) {
+		case *btf.Array, *btf.FuncProto:
+			inner = "(" + inner + ")"
+		}
+		return cDecl(t.Target, inner, depth)
+
+	case *btf.Array:
+		return cDecl(t.Type, fmt.Sprintf("%s[%d]", decl, t.Nelems), depth)
+
+	case *btf.FuncProto:
+		return cDecl(t.Return, decl+"("+cParams(t.Params, depth)+")", depth)
+
+	case *btf.Const:
+		return cQualified("const", t.Type, decl, depth)
+
+	case *btf.Volatile:
+		return cQualified("volatile", t.Type, decl, depth)
+
+	case *btf.Restrict:
+		return cQualified("restrict", t.Type, decl, depth)
+
+	case *btf.TypeTag:
+		return cDecl(t.Type, decl, depth)
+	}
+
+	base := cTypeRef(t, depth)
+	if decl == "" {
+		return base
+	}
+	return base + " " + decl
+}
+
+// cQualified renders a qualified declaration. Qualified pointers carry the
+// qualifier on the declarator ("char *const p"), everything else on the
+// type ("const char *p").
+func cQualified(qualifier string, t btf.Type, decl string, depth int) string {
+	if _, ok := t.(*btf.Pointer); ok {
+		return cDecl(t, strings.TrimSpace(qualifier+" "+decl), depth)
+	}
+	return qualifier + " " + cDecl(t, decl, depth)
+}
+
+// cParams renders a function's parameter list.
+func cParams(params []btf.FuncParam, depth int) string {
+	if len(params) == 0 {
+		return "void"
+	}
+
+	parts := make([]string, len(params))
+	for i, p := range params {
+		if _, ok := p.Type.(*btf.Void); (ok || p.Type == nil) && i == len(params)-1 {
+			parts[i] = "..."
+			continue
+		}
+		parts[i] = cDecl(p.Type, p.Name, depth)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cTypeRef renders a reference to a type that has no declarator syntax.
+func cTypeRef(t btf.Type, depth int) string {
+	switch t := t.(type) {
+	case nil, *btf.Void:
+		return "void"
+	case *btf.Struct:
+		return cAggregate("struct", t.Name, t.Members, depth, false)
+	case *btf.Union:
+		return cAggregate("union", t.Name, t.Members, depth, false)
+	case *btf.Enum:
+		return cEnum(t, depth, false)
+	case *btf.Fwd:
+		return fmt.Sprintf("%s %s", t.Kind, t.Name)
+	}
+
+	if name := t.TypeName(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("/* %T */", t)
+}
+
+// cAggregate renders a struct or union. Named aggregates are only expanded
+// if expand is set, so that references to them don't recurse.
+func cAggregate(keyword, name string, members []btf.Member, depth int, expand bool) string {
+	head := keyword
+	if name != "" {
+		head += " " + name
+		if !expand {
+			return head
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(head + " {\n")
+	for _, mem := range members {
+		b.WriteString(strings.Repeat(cIndent, depth+1))
+		b.WriteString(cDecl(mem.Type, mem.Name, depth+1))
+		if mem.BitfieldSize > 0 {
+			fmt.Fprintf(&b, ": %d", mem.BitfieldSize)
+		}
+		b.WriteString(";")
+		if keyword == "struct" {
+			if mem.BitfieldSize > 0 {
+				fmt.Fprintf(&b, " /* offset %d bit %d */", mem.Offset/8, mem.Offset%8)
+			} else {
+				fmt.Fprintf(&b, " /* offset %d */", mem.Offset.Bytes())
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(cIndent, depth) + "}")
+	return b.String()
+}
+
+// cEnum renders an enum. Named enums are only expanded if expand is set.
+func cEnum(t *btf.Enum, depth int, expand bool) string {
+	head := "enum"
+	if t.Name != "" {
+		head += " " + t.Name
+		if !expand {
+			return head
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(head + " {\n")
+	for _, v := range t.Values {
+		b.WriteString(strings.Repeat(cIndent, depth+1))
+		if t.Signed {
+			fmt.Fprintf(&b, "%s = %d,\n", v.Name, int64(v.Value))
+		} else {
+			fmt.Fprintf(&b, "%s = %d,\n", v.Name, v.Value)
+		}
+	}
+	b.WriteString(strings.Repeat(cIndent, depth) + "}")
+	return b.String()
+}
+
+// funcLinkage returns the storage class prefix for a function's linkage.
+func funcLinkage(l btf.FuncLinkage) string {
+	switch l {
+	case btf.StaticFunc:
+		return "static "
+	case btf.ExternFunc:
+		return "extern "
+	}
+	return ""
+}
+
+// varLinkage returns the storage class prefix for a variable's linkage.
+func varLinkage(l btf.VarLinkage) string {
+	switch l {
+	case btf.StaticVar:
+		return "static "
+	case btf.ExternVar:
+		return "extern "
+	}
+	return ""
+}
+
+// btfKindName returns the BTF kind of a type as bpftool names it.
+func btfKindName(t btf.Type) string {
+	switch t.(type) {
+	case *btf.Int:
+		return "int"
+	case *btf.Float:
+		return "float"
+	case *btf.Pointer:
+		return "ptr"
+	case *btf.Array:
+		return "array"
+	case *btf.Struct:
+		return "struct"
+	case *btf.Union:
+		return "union"
+	case *btf.Enum:
+		return "enum"
+	case *btf.Fwd:
+		return "fwd"
+	case *btf.Typedef:
+		return "typedef"
+	case *btf.Volatile:
+		return "volatile"
+	case *btf.Const:
+		return "const"
+	case *btf.Restrict:
+		return "restrict"
+	case *btf.Func:
+		return "func"
+	case *btf.FuncProto:
+		return "func_proto"
+	case *btf.Var:
+		return "var"
+	case *btf.Datasec:
+		return "datasec"
+	case *btf.TypeTag:
+		return "type_tag"
+	}
+	return "unknown"
+}
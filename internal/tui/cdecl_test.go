@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+func TestCDecl(t *testing.T) {
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed | btf.Char}
+	u32 := &btf.Int{Name: "u32", Size: 4}
+	proto := &btf.FuncProto{
+		Return: u32,
+		Params: []btf.FuncParam{{Name: "ctx", Type: &btf.Pointer{Target: &btf.Void{}}}},
+	}
+
+	tests := []struct {
+		name string
+		typ  btf.Type
+		decl string
+		want string
+	}{
+		{"int", u32, "x", "u32 x"},
+		{"pointer", &btf.Pointer{Target: char}, "p", "char *p"},
+		{"const pointee", &btf.Pointer{Target: &btf.Const{Type: char}}, "p", "const char *p"},
+		{"const pointer", &btf.Const{Type: &btf.Pointer{Target: char}}, "p", "char *const p"},
+		{"array of pointers", &btf.Array{Type: &btf.Pointer{Target: char}, Nelems: 4}, "argv", "char *argv[4]"},
+		{"pointer to array", &btf.Pointer{Target: &btf.Array{Type: u32, Nelems: 4}}, "p", "u32 (*p)[4]"},
+		{"function pointer", &btf.Pointer{Target: proto}, "fn", "u32 (*fn)(void *ctx)"},
+		{"abstract", &btf.Pointer{Target: char}, "", "char *"},
+		{"named struct", &btf.Struct{Name: "sock", Members: []btf.Member{{Name: "a", Type: u32}}}, "sk", "struct sock sk"},
+		{"forward", &btf.Pointer{Target: &btf.Fwd{Name: "task", Kind: btf.FwdStruct}}, "t", "struct task *t"},
+		{"variadic", &btf.FuncProto{Return: &btf.Void{}, Params: []btf.FuncParam{
+			{Name: "fmt", Type: &btf.Pointer{Target: char}},
+			{Type: &btf.Void{}},
+		}}, "f", "void f(char *fmt, ...)"},
+		{"no params", &btf.FuncProto{Return: u32}, "f", "u32 f(void)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cDecl(tt.typ, tt.decl, 0); got != tt.want {
+				t.Errorf("cDecl() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCDeclStruct(t *testing.T) {
+	u32 := &btf.Int{Name: "u32", Size: 4}
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed | btf.Char}
+	s := &btf.Struct{
+		Name: "event",
+		Size: 28,
+		Members: []btf.Member{
+			{Name: "pid", Type: &btf.Typedef{Name: "pid_t", Type: u32}, Offset: 0},
+			{Name: "comm", Type: &btf.Array{Type: char, Nelems: 16}, Offset: 32},
+			{Name: "flags", Type: u32, Offset: 160, BitfieldSize: 3},
+			{Type: &btf.Union{Size: 4, Members: []btf.Member{
+				{Name: "a", Type: u32},
+				{Name: "b", Type: u32},
+			}}, Offset: 192},
+		},
+	}
+
+	want := `/* size: 28 bytes */
+struct event {
+    pid_t pid; /* offset 0 */
+    char comm[16]; /* offset 4 */
+    u32 flags: 3; /* offset 20 bit 0 */
+    union {
+        u32 a;
+        u32 b;
+    }; /* offset 24 */
+};`
+	if got := formatCDecl(s); got != want {
+		t.Errorf("formatCDecl() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatCDeclOtherKinds(t *testing.T) {
+	u32 := &btf.Int{Name: "u32", Size: 4}
+	ctx := &btf.Struct{Name: "xdp_md"}
+
+	tests := []struct {
+		name string
+		typ  btf.Type
+		want string
+	}{
+		{
+			"enum",
+			&btf.Enum{Name: "action", Size: 4, Signed: true, Values: []btf.EnumValue{
+				{Name: "DROP", Value: 1},
+				{Name: "ERR", Value: uint64(0xffffffffffffffff)},
+			}},
+			"enum action {\n    DROP = 1,\n    ERR = -1,\n};",
+		},
+		{
+			"typedef of anonymous struct",
+			&btf.Typedef{Name: "pair_t", Type: &btf.Struct{Members: []btf.Member{{Name: "x", Type: u32}}}},
+			"typedef struct {\n    u32 x; /* offset 0 */\n} pair_t;",
+		},
+		{
+			"typedef of named struct",
+			&btf.Typedef{Name: "md_t", Type: ctx},
+			"typedef struct xdp_md md_t;",
+		},
+		{
+			"func",
+			&btf.Func{Name: "xdp_main", Linkage: btf.GlobalFunc, Type: &btf.FuncProto{
+				Return: &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed},
+				Params: []btf.FuncParam{{Name: "ctx", Type: &btf.Pointer{Target: ctx}}},
+			}},
+			"int xdp_main(struct xdp_md *ctx);",
+		},
+		{
+			"static func",
+			&btf.Func{Name: "helper", Linkage: btf.StaticFunc, Type: &btf.FuncProto{Return: u32}},
+			"static u32 helper(void);",
+		},
+		{
+			"forward",
+			&btf.Fwd{Name: "sk_buff", Kind: btf.FwdUnion},
+			"union sk_buff;",
+		},
+		{
+			"int",
+			&btf.Int{Name: "long", Size: 8, Encoding: btf.Signed},
+			"long /* 64-bit signed integer */",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCDecl(tt.typ); got != tt.want {
+				t.Errorf("formatCDecl() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
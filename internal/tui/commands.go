@@ -0,0 +1,505 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Service calls run as tea.Cmds so that slow kernel queries (e.g. dumping a
+// large hash map) never block Update. Every result message carries the load
+// sequence number it was started with; the root model discards results whose
+// sequence no longer matches, which is how Esc cancels an in-flight load.
+
+// programsLoadedMsg is sent when an asynchronous ProgService.List call completes.
+type programsLoadedMsg struct {
+	seq      int
+	programs []ProgramInfo
+	at       time.Time // When the programs were polled
+	statsOff bool      // Kernel isn't collecting run statistics
+	err      error
+	refresh  bool // Periodic refresh rather than the initial load
+}
+
+// programRefreshedMsg is sent when a periodic ProgService.Get call for the
+// program detail view completes.
+type programRefreshedMsg struct {
+	seq      int
+	program  *ProgramInfo
+	at       time.Time // When the program was polled
+	statsOff bool      // Kernel isn't collecting run statistics
+	err      error
+}
+
+// statsEnabledMsg is sent when an asynchronous ProgStatsService.EnableStats call completes.
+type statsEnabledMsg struct {
+	err error
+}
+
+// programLoadedMsg is sent when an asynchronous ProgService.Get call for
+// navigating to a program by ID completes.
+type programLoadedMsg struct {
+	seq      int
+	program  *ProgramInfo
+	statsOff bool // Kernel isn't collecting run statistics
+	err      error
+}
+
+// mapsLoadedMsg is sent when an asynchronous MapsService.List call completes.
+type mapsLoadedMsg struct {
+	seq     int
+	maps    []MapInfo
+	err     error
+	refresh bool // Periodic refresh rather than the initial load
+}
+
+// mapLoadedMsg is sent when an asynchronous MapsService.Get call completes.
+type mapLoadedMsg struct {
+	seq     int
+	mapInfo *MapInfo
+	err     error
+}
+
+// mapDumpLoadedMsg is sent when the first page of a map dump has been read.
+type mapDumpLoadedMsg struct {
+	seq     int
+	mapID   uint32
+	entries []MapEntry
+	iter    MapIterator // Source of the remaining entries, or nil if all were read
+	btf     *MapBTF     // nil if the map has no BTF or the service can't provide it
+	err     error
+}
+
+// mapDumpPageMsg is sent when a further page of a map dump has been read.
+type mapDumpPageMsg struct {
+	iter    MapIterator // Iterator the page was read from
+	entries []MapEntry
+	done    bool // No entries remain
+	err     error
+}
+
+// mapTailStartedMsg is sent when a reader has been attached to a ring
+// buffer or perf event array.
+type mapTailStartedMsg struct {
+	seq    int
+	mapID  uint32
+	reader MapEventReader
+	err    error
+}
+
+// mapEventsMsg is sent when records have been read from a ring buffer or
+// perf event array.
+type mapEventsMsg struct {
+	reader MapEventReader // Reader the records were read from
+	events []MapEvent
+	err    error
+}
+
+// mapEventTypeMsg is sent when the type to decode a map's records with has
+// been looked up.
+type mapEventTypeMsg struct {
+	seq  int
+	name string
+	t    *BTFType
+	err  error
+}
+
+// mapEntryEditedMsg is sent when an asynchronous MapsService.Update, Insert
+// or Delete call completes.
+type mapEntryEditedMsg struct {
+	seq   int
+	mapID uint32
+	op    mapEditOp
+	key   []byte
+	value []byte // Value stored by the write; unused for deletes
+	err   error
+}
+
+// mapExportedMsg is sent when map entries have been written to a file.
+type mapExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// mapLookupMsg is sent when an asynchronous MapsService.Lookup call completes.
+type mapLookupMsg struct {
+	seq    int
+	result mapLookupResult
+}
+
+// linksLoadedMsg is sent when an asynchronous LinkService.List call completes.
+type linksLoadedMsg struct {
+	seq     int
+	links   []LinkInfo
+	err     error
+	refresh bool // Periodic refresh rather than the initial load
+}
+
+// linkLoadedMsg is sent when an asynchronous LinkService.Get call completes.
+type linkLoadedMsg struct {
+	seq  int
+	link *LinkInfo
+	err  error
+}
+
+// pinsLoadedMsg is sent when an asynchronous PinService.List call completes.
+type pinsLoadedMsg struct {
+	seq  int
+	pins []PinInfo
+	err  error
+}
+
+// processesLoadedMsg is sent when an asynchronous ProcessService.List call
+// completes. It isn't sequenced: each view picks out the processes holding
+// the object it currently shows.
+type processesLoadedMsg struct {
+	procs []ProcessInfo
+	err   error
+}
+
+// btfObjectsLoadedMsg is sent when an asynchronous BTFService.List call completes.
+type btfObjectsLoadedMsg struct {
+	seq     int
+	objects []BTFObjectInfo
+	err     error
+}
+
+// btfTypesLoadedMsg is sent when an asynchronous BTFService.Types, MapTypes
+// or ProgFuncs call completes.
+type btfTypesLoadedMsg struct {
+	seq   int
+	types []BTFTypeInfo
+	err   error
+}
+
+// btfDefinitionLoadedMsg is sent when an asynchronous BTFService.Definition call completes.
+type btfDefinitionLoadedMsg struct {
+	seq int
+	def string
+	err error
+}
+
+// progXlatedLoadedMsg is sent when an asynchronous ProgDisasmService.Xlated call completes.
+type progXlatedLoadedMsg struct {
+	seq   int
+	insns []Instruction
+	err   error
+}
+
+// progJITLoadedMsg is sent when an asynchronous ProgDisasmService.JITed call completes.
+type progJITLoadedMsg struct {
+	seq   int
+	image *JITImage
+	err   error
+}
+
+// listProgramsCmd returns a command that lists BPF programs in the background.
+func listProgramsCmd(svc ProgService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		programs, err := svc.List()
+		return programsLoadedMsg{seq: seq, programs: programs, at: time.Now(), statsOff: statsOff(svc), err: err}
+	}
+}
+
+// listMapsCmd returns a command that lists BPF maps in the background.
+func listMapsCmd(svc MapsService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		maps, err := svc.List()
+		return mapsLoadedMsg{seq: seq, maps: maps, err: err}
+	}
+}
+
+// refreshProgramsCmd returns a command that re-lists BPF programs for a periodic refresh.
+func refreshProgramsCmd(svc ProgService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		programs, err := svc.List()
+		return programsLoadedMsg{seq: seq, programs: programs, at: time.Now(), statsOff: statsOff(svc), err: err, refresh: true}
+	}
+}
+
+// refreshProgramCmd returns a command that re-fetches a single program for a periodic refresh.
+func refreshProgramCmd(svc ProgService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		program, err := svc.Get(id)
+		return programRefreshedMsg{seq: seq, program: program, at: time.Now(), statsOff: statsOff(svc), err: err}
+	}
+}
+
+// getProgramCmd returns a command that fetches a single program by ID in the background.
+func getProgramCmd(svc ProgService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		program, err := svc.Get(id)
+		return programLoadedMsg{seq: seq, program: program, statsOff: statsOff(svc), err: err}
+	}
+}
+
+// enableStatsCmd returns a command that turns on run statistics in the background.
+func enableStatsCmd(svc ProgStatsService) tea.Cmd {
+	return func() tea.Msg {
+		return statsEnabledMsg{err: svc.EnableStats()}
+	}
+}
+
+// statsOff reports whether svc can tell that run statistics are disabled.
+// Services that can't control statistics never prompt to enable them.
+func statsOff(svc ProgService) bool {
+	statsSvc, ok := svc.(ProgStatsService)
+	return ok && !statsSvc.StatsEnabled()
+}
+
+// refreshMapsCmd returns a command that re-lists BPF maps for a periodic refresh.
+func refreshMapsCmd(svc MapsService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		maps, err := svc.List()
+		return mapsLoadedMsg{seq: seq, maps: maps, err: err, refresh: true}
+	}
+}
+
+// listLinksCmd returns a command that lists BPF links in the background.
+func listLinksCmd(svc LinkService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		links, err := svc.List()
+		return linksLoadedMsg{seq: seq, links: links, err: err}
+	}
+}
+
+// getLinkCmd returns a command that fetches a single link by ID in the background.
+func getLinkCmd(svc LinkService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		link, err := svc.Get(id)
+		return linkLoadedMsg{seq: seq, link: link, err: err}
+	}
+}
+
+// listPinsCmd returns a command that walks the mounted bpffs instances in the background.
+func listPinsCmd(svc PinService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		pins, err := svc.List()
+		return pinsLoadedMsg{seq: seq, pins: pins, err: err}
+	}
+}
+
+// refreshLinksCmd returns a command that re-lists BPF links for a periodic refresh.
+func refreshLinksCmd(svc LinkService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		links, err := svc.List()
+		return linksLoadedMsg{seq: seq, links: links, err: err, refresh: true}
+	}
+}
+
+// listProcessesCmd returns a command that scans for BPF object owners in the background.
+func listProcessesCmd(svc ProcessService) tea.Cmd {
+	return func() tea.Msg {
+		procs, err := svc.List()
+		return processesLoadedMsg{procs: procs, err: err}
+	}
+}
+
+// listBTFCmd returns a command that lists BTF objects in the background.
+func listBTFCmd(svc BTFService, seq int) tea.Cmd {
+	return func() tea.Msg {
+		objects, err := svc.List()
+		return btfObjectsLoadedMsg{seq: seq, objects: objects, err: err}
+	}
+}
+
+// btfTypesCmd returns a command that fetches types for the type browser in
+// the background, using one of the BTFService methods returning types.
+func btfTypesCmd(seq int, id uint32, fetch func(uint32) ([]BTFTypeInfo, error)) tea.Cmd {
+	return func() tea.Msg {
+		types, err := fetch(id)
+		return btfTypesLoadedMsg{seq: seq, types: types, err: err}
+	}
+}
+
+// btfDefinitionCmd returns a command that renders a type's definition in the background.
+func btfDefinitionCmd(svc BTFService, seq int, info BTFTypeInfo) tea.Cmd {
+	return func() tea.Msg {
+		def, err := svc.Definition(info.ObjID, info.ID)
+		return btfDefinitionLoadedMsg{seq: seq, def: def, err: err}
+	}
+}
+
+// getMapCmd returns a command that fetches a single map by ID in the background.
+func getMapCmd(svc MapsService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		mapInfo, err := svc.Get(id)
+		return mapLoadedMsg{seq: seq, mapInfo: mapInfo, err: err}
+	}
+}
+
+// dumpMapCmd returns a command that reads the first n entries of a map in the
+// background, keeping the iterator open if more remain. If the service
+// provides BTF, the key and value types are fetched as well.
+func dumpMapCmd(svc MapsService, seq int, id uint32, n int) tea.Cmd {
+	return func() tea.Msg {
+		iter, err := iterateMap(svc, id)
+		if err != nil {
+			return mapDumpLoadedMsg{seq: seq, mapID: id, err: err}
+		}
+		entries, done, err := nextPage(iter, n)
+		if err != nil {
+			iter.Close()
+			return mapDumpLoadedMsg{seq: seq, mapID: id, err: err}
+		}
+		if done {
+			iter.Close()
+			iter = nil
+		}
+		if entries == nil {
+			entries = []MapEntry{}
+		}
+
+		var mapBTF *MapBTF
+		if btfSvc, ok := svc.(MapBTFService); ok {
+			// BTF is best effort; fall back to hex if it can't be loaded
+			mapBTF, _ = btfSvc.MapBTF(id)
+		}
+		return mapDumpLoadedMsg{seq: seq, mapID: id, entries: entries, iter: iter, btf: mapBTF}
+	}
+}
+
+// dumpPageCmd returns a command that reads the next n entries of a map dump
+// in the background.
+func dumpPageCmd(iter MapIterator, n int) tea.Cmd {
+	return func() tea.Msg {
+		entries, done, err := nextPage(iter, n)
+		return mapDumpPageMsg{iter: iter, entries: entries, done: done, err: err}
+	}
+}
+
+// tailMapCmd returns a command that attaches a reader to a ring buffer or
+// perf event array in the background.
+func tailMapCmd(svc MapsService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		eventSvc, ok := svc.(MapEventService)
+		if !ok {
+			return mapTailStartedMsg{seq: seq, mapID: id, err: errors.New("tailing events is not supported by this service")}
+		}
+		reader, err := eventSvc.Tail(id)
+		return mapTailStartedMsg{seq: seq, mapID: id, reader: reader, err: err}
+	}
+}
+
+// readEventsCmd returns a command that reads the next batch of records in
+// the background.
+func readEventsCmd(reader MapEventReader) tea.Cmd {
+	return func() tea.Msg {
+		events, err := reader.Read(eventReadBatch, eventReadTimeout)
+		return mapEventsMsg{reader: reader, events: events, err: err}
+	}
+}
+
+// eventTypeCmd returns a command that looks up the type to decode a map's
+// records with in the background.
+func eventTypeCmd(svc MapsService, seq int, id uint32, name string) tea.Cmd {
+	return func() tea.Msg {
+		eventSvc, ok := svc.(MapEventService)
+		if !ok {
+			return mapEventTypeMsg{seq: seq, name: name, err: errors.New("decoding records is not supported by this service")}
+		}
+		t, err := eventSvc.EventType(id, name)
+		return mapEventTypeMsg{seq: seq, name: name, t: t, err: err}
+	}
+}
+
+// editMapEntryCmd returns a command that applies an edit to a map entry in the background.
+func editMapEntryCmd(svc MapsService, seq int, id uint32, edit mapEdit) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		switch edit.op {
+		case mapEditUpdate:
+			err = svc.Update(id, edit.key, edit.value)
+		case mapEditInsert:
+			err = svc.Insert(id, edit.key, edit.value)
+		case mapEditDelete:
+			err = svc.Delete(id, edit.key)
+		}
+		value := edit.value
+		if err == nil && edit.op != mapEditDelete {
+			// The map may store the value differently, e.g. zeroing the
+			// per-CPU values left out
+			if stored, lerr := svc.Lookup(id, edit.key); lerr == nil {
+				value = stored
+			}
+		}
+		return mapEntryEditedMsg{seq: seq, mapID: id, op: edit.op, key: edit.key, value: value, err: err}
+	}
+}
+
+// exportMapCmd returns a command that writes map entries to a file in the
+// background, reading them from the iterator returned by open.
+func exportMapCmd(path string, format exportFormat, open func() (MapIterator, error), keyType, valueType *BTFType) tea.Cmd {
+	return func() tea.Msg {
+		iter, err := open()
+		if err != nil {
+			return mapExportedMsg{path: path, err: err}
+		}
+		defer iter.Close()
+		count, err := exportToFile(path, format, iter, keyType, valueType)
+		return mapExportedMsg{path: path, count: count, err: err}
+	}
+}
+
+// lookupMapCmd returns a command that parses a typed key and looks it up in the
+// background. BTF is fetched first so that structured keys can be encoded and
+// the result decoded.
+func lookupMapCmd(svc MapsService, seq int, info MapInfo, input string) tea.Cmd {
+	return func() tea.Msg {
+		var result mapLookupResult
+		if btfSvc, ok := svc.(MapBTFService); ok {
+			// BTF is best effort; fall back to hex if it can't be loaded
+			result.btf, _ = btfSvc.MapBTF(info.ID)
+		}
+
+		var keyType *BTFType
+		if result.btf != nil {
+			keyType = result.btf.Key
+		}
+		key, err := parseLookupKey(input, info.KeySize, keyType)
+		if err != nil {
+			result.err = fmt.Errorf("invalid key: %w", err)
+			return mapLookupMsg{seq: seq, result: result}
+		}
+
+		result.key = key
+		result.value, result.err = svc.Lookup(info.ID, key)
+		return mapLookupMsg{seq: seq, result: result}
+	}
+}
+
+// xlatedCmd returns a command that fetches a program's xlated instructions in the background.
+func xlatedCmd(svc ProgService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		disasmSvc, ok := svc.(ProgDisasmService)
+		if !ok {
+			return progXlatedLoadedMsg{seq: seq, err: errors.New("disassembly is not supported by this service")}
+		}
+		insns, err := disasmSvc.Xlated(id)
+		return progXlatedLoadedMsg{seq: seq, insns: insns, err: err}
+	}
+}
+
+// jitedCmd returns a command that fetches a program's JIT image in the background.
+func jitedCmd(svc ProgService, seq int, id uint32) tea.Cmd {
+	return func() tea.Msg {
+		disasmSvc, ok := svc.(ProgDisasmService)
+		if !ok {
+			return progJITLoadedMsg{seq: seq, err: errors.New("disassembly is not supported by this service")}
+		}
+		image, err := disasmSvc.JITed(id)
+		return progJITLoadedMsg{seq: seq, image: image, err: err}
+	}
+}
+
+// newSpinner creates the spinner shown by views while a load is in flight.
+func newSpinner() spinner.Model {
+	return spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(spinnerStyle),
+	)
+}
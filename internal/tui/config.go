@@ -0,0 +1,399 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Config is the user configuration, read from config.toml. The zero value
+// leaves every setting at its default.
+type Config struct {
+	// RefreshInterval is how often the program and map lists are re-queried,
+	// or nil if not set.
+	RefreshInterval *time.Duration
+
+	startView   ViewState
+	progColumns []string // nil keeps the default columns
+	mapColumns  []string
+	progTable   bool
+	mapTable    bool
+	modes       dumpModes            // Display modes of maps without one of their own
+	nameModes   map[string]dumpModes // Display modes by map name
+	theme       map[string]lipgloss.Color
+	keys        map[string][]string // Keys by action
+}
+
+// configFile mirrors config.toml.
+type configFile struct {
+	DefaultView     string                `toml:"default_view"`
+	RefreshInterval string                `toml:"refresh_interval"`
+	Programs        listConfig            `toml:"programs"`
+	Maps            listConfig            `toml:"maps"`
+	Display         displayConfig         `toml:"display"`
+	Theme           map[string]string     `toml:"theme"`
+	Keys            map[string]configKeys `toml:"keys"`
+}
+
+// listConfig configures the programs or maps list.
+type listConfig struct {
+	Columns []string `toml:"columns"`
+	Table   bool     `toml:"table"`
+}
+
+// displayConfig configures the display modes of the map dump.
+type displayConfig struct {
+	Key   string                      `toml:"key"`
+	Value string                      `toml:"value"`
+	Maps  map[string]mapDisplayConfig `toml:"maps"`
+}
+
+// mapDisplayConfig configures the display modes of the maps with a name.
+type mapDisplayConfig struct {
+	Key   string `toml:"key"`
+	Value string `toml:"value"`
+}
+
+// configKeys are the keys bound to an action: a string or an array of them.
+type configKeys []string
+
+// UnmarshalTOML implements toml.Unmarshaler.
+func (k *configKeys) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*k = configKeys{v}
+		return nil
+	case []any:
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return fmt.Errorf("keys must be strings, got %v", e)
+			}
+			*k = append(*k, s)
+		}
+		return nil
+	}
+	return fmt.Errorf("expected a key or an array of keys, got %v", v)
+}
+
+// configViews are the views bpftui can start in, by name.
+var configViews = map[string]ViewState{
+	"menu":     ViewMenu,
+	"programs": ViewProgList,
+	"maps":     ViewMapList,
+	"links":    ViewLinkList,
+	"btf":      ViewBTFList,
+	"pinned":   ViewPinTree,
+}
+
+// themeStyles are the styles the theme can color, by name.
+var themeStyles = map[string]*lipgloss.Style{
+	"title":    &titleStyle,
+	"selected": &selectedStyle,
+	"help":     &helpStyle,
+	"error":    &errorStyle,
+	"normal":   &normalStyle,
+	"dim":      &dimStyle,
+	"label":    &labelStyle,
+	"value":    &valueStyle,
+	"added":    &addedStyle,
+	"removed":  &removedStyle,
+	"changed":  &changedStyle,
+	"link":     &linkStyle,
+	"match":    &matchStyle, // The background of search matches
+	"spinner":  &spinnerStyle,
+}
+
+// configKeyBindings are the key bindings that can be changed, by action.
+var configKeyBindings = map[string]func(*keyMap) *key.Binding{
+	"back":    func(k *keyMap) *key.Binding { return &k.Back },
+	"quit":    func(k *keyMap) *key.Binding { return &k.Quit },
+	"help":    func(k *keyMap) *key.Binding { return &k.Help },
+	"disasm":  func(k *keyMap) *key.Binding { return &k.Disasm },
+	"jit":     func(k *keyMap) *key.Binding { return &k.JIT },
+	"stats":   func(k *keyMap) *key.Binding { return &k.Stats },
+	"btf":     func(k *keyMap) *key.Binding { return &k.BTF },
+	"owners":  func(k *keyMap) *key.Binding { return &k.Owners },
+	"sort":    func(k *keyMap) *key.Binding { return &k.Sort },
+	"order":   func(k *keyMap) *key.Binding { return &k.Order },
+	"table":   func(k *keyMap) *key.Binding { return &k.Table },
+	"columns": func(k *keyMap) *key.Binding { return &k.Columns },
+}
+
+// Keys the views handle themselves, which configured keys can't take over:
+// the lists' and the scrollable detail views' navigation keys.
+var (
+	listKeys   = []string{"up", "k", "down", "j", "left", "h", "right", "l", "pgup", "b", "u", "pgdown", "f", "d", "home", "g", "end", "G", "/", "enter"}
+	detailKeys = []string{"up", "k", "down", "j", "left", "h", "right", "l", "pgup", "b", "u", "ctrl+u", "pgdown", " ", "f", "d", "ctrl+d", "enter"}
+)
+
+// viewKeys are the keys each view handles itself, by view.
+var viewKeys = map[string][]string{
+	"list":           listKeys,
+	"program detail": detailKeys,
+	"disassembly":    append(slices.Clone(detailKeys), "n", "N"),
+	"JIT code":       append(slices.Clone(detailKeys), "n", "N"),
+	"map dump": {"up", "k", "down", "j", "pgup", "b", "pgdown", "f", " ", "home", "g", "end", "G",
+		"x", "m", "M", "o", "O", "a", "e", "i", "d", "s", "/", "n", "N"},
+	"tail events":    {"up", "k", "down", "j", "pgup", "b", "pgdown", "f", " ", "p", "home", "g", "end", "G", "c", "x", "t", "enter"},
+	"BTF types":      {"up", "k", "down", "j", "pgup", "pgdown", "home", "g", "end", "G", "/", "enter"},
+	"pinned objects": {"up", "k", "down", "j", "left", "h", "right", "l", "pgup", "pgdown", "home", "g", "end", "G", "enter"},
+}
+
+// actionViews are the views each action works in. Actions not listed, such
+// as quit, work in every view.
+var actionViews = map[string][]string{
+	"disasm":  {"program detail"},
+	"jit":     {"program detail"},
+	"btf":     {"program detail"},
+	"stats":   {"list", "program detail"},
+	"owners":  {"list"},
+	"sort":    {"list"},
+	"order":   {"list"},
+	"table":   {"list"},
+	"columns": {"list"},
+}
+
+// colorRE matches the colors the theme accepts: ANSI 256-color numbers and
+// #rgb or #rrggbb hex colors.
+var colorRE = regexp.MustCompile(`^(\d{1,3}|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6})$`)
+
+// DefaultConfigPath returns the path of the configuration file:
+// $XDG_CONFIG_HOME/bpftui/config.toml, or ~/.config/bpftui/config.toml
+// if XDG_CONFIG_HOME isn't set.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bpftui", "config.toml"), nil
+}
+
+// ConfigError lists the problems found in a configuration file.
+type ConfigError struct {
+	Path     string
+	Problems []string
+}
+
+// Error implements error, with a problem per line.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid config %s:\n  %s", e.Path, strings.Join(e.Problems, "\n  "))
+}
+
+// LoadConfig reads and validates the configuration file at path. Syntax
+// errors, unknown settings and invalid values are all reported in a
+// *ConfigError. A missing file returns an error wrapping os.ErrNotExist.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfig(path, string(data))
+}
+
+// parseConfig parses and validates the contents of the configuration file
+// at path.
+func parseConfig(path, data string) (*Config, error) {
+	var f configFile
+	md, err := toml.Decode(data, &f)
+	if err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			return nil, &ConfigError{Path: path, Problems: []string{
+				fmt.Sprintf("line %d: %s", perr.Position.Line, perr.Message)}}
+		}
+		return nil, &ConfigError{Path: path, Problems: []string{strings.TrimPrefix(err.Error(), "toml: ")}}
+	}
+
+	var problems []string
+	problem := func(setting, format string, args ...any) {
+		problems = append(problems, setting+": "+fmt.Sprintf(format, args...))
+	}
+	for _, k := range md.Undecoded() {
+		problems = append(problems, fmt.Sprintf("%s: unknown setting", k))
+	}
+
+	cfg := &Config{}
+	if f.DefaultView != "" {
+		view, ok := configViews[f.DefaultView]
+		if !ok {
+			problem("default_view", "unknown view %q (views: %s)", f.DefaultView,
+				strings.Join(slices.Sorted(maps.Keys(configViews)), ", "))
+		}
+		cfg.startView = view
+	}
+
+	if f.RefreshInterval != "" {
+		d, err := time.ParseDuration(f.RefreshInterval)
+		switch {
+		case err != nil:
+			problem("refresh_interval", "invalid duration %q, e.g. \"5s\" or \"0\" to disable", f.RefreshInterval)
+		case d < 0:
+			problem("refresh_interval", "can't be negative")
+		default:
+			cfg.RefreshInterval = &d
+		}
+	}
+
+	// Columns are checked against the tables they're shown in
+	checkColumns := func(setting string, columns []tableColumn, names []string) []string {
+		if names == nil {
+			return nil
+		}
+		t := newListTable(columns)
+		if err := t.SetColumns(names); err != nil {
+			var valid []string
+			for _, i := range t.choosable() {
+				valid = append(valid, columns[i].name)
+			}
+			problem(setting, "%v (columns: %s)", err, strings.Join(valid, ", "))
+			return nil
+		}
+		return names
+	}
+	cfg.progColumns = checkColumns("programs.columns", progColumns, f.Programs.Columns)
+	cfg.mapColumns = checkColumns("maps.columns", mapColumns, f.Maps.Columns)
+	cfg.progTable = f.Programs.Table
+	cfg.mapTable = f.Maps.Table
+
+	mode := func(setting, name string) displayMode {
+		if name == "" {
+			return displayMode{}
+		}
+		d, err := parseDisplayMode(name)
+		if err != nil {
+			problem(setting, "%v", err)
+		}
+		return d
+	}
+	cfg.modes = dumpModes{key: mode("display.key", f.Display.Key), value: mode("display.value", f.Display.Value)}
+	cfg.nameModes = make(map[string]dumpModes)
+	for _, name := range slices.Sorted(maps.Keys(f.Display.Maps)) {
+		d := f.Display.Maps[name]
+		setting := "display.maps." + name
+		cfg.nameModes[name] = dumpModes{key: mode(setting+".key", d.Key), value: mode(setting+".value", d.Value)}
+	}
+
+	cfg.theme = make(map[string]lipgloss.Color)
+	for _, name := range slices.Sorted(maps.Keys(f.Theme)) {
+		color := f.Theme[name]
+		if _, ok := themeStyles[name]; !ok {
+			problem("theme."+name, "unknown style (styles: %s)", strings.Join(slices.Sorted(maps.Keys(themeStyles)), ", "))
+			continue
+		}
+		if n, err := strconv.Atoi(color); !colorRE.MatchString(color) || err == nil && n > 255 {
+			problem("theme."+name, "invalid color %q, expected 0-255 or #rrggbb", color)
+			continue
+		}
+		cfg.theme[name] = lipgloss.Color(color)
+	}
+
+	cfg.keys = make(map[string][]string)
+	for _, action := range slices.Sorted(maps.Keys(f.Keys)) {
+		keys := f.Keys[action]
+		if _, ok := configKeyBindings[action]; !ok {
+			problem("keys."+action, "unknown action (actions: %s)", strings.Join(slices.Sorted(maps.Keys(configKeyBindings)), ", "))
+			continue
+		}
+		if len(keys) == 0 || slices.Contains(keys, "") {
+			problem("keys."+action, "needs at least one key")
+			continue
+		}
+		cfg.keys[action] = keys
+	}
+	// The same key can't do two things
+	bound := make(map[string]string)
+	km := cfg.keyMap()
+	for _, action := range slices.Sorted(maps.Keys(configKeyBindings)) {
+		for _, k := range configKeyBindings[action](&km).Keys() {
+			if other, ok := bound[k]; ok {
+				problem("keys", "%q is bound to both %s and %s", k, other, action)
+			}
+			bound[k] = action
+		}
+	}
+	// Nor can it shadow a key of a view the action works in. Default keys
+	// are left alone, as they're known to take precedence.
+	for _, action := range slices.Sorted(maps.Keys(cfg.keys)) {
+		views := actionViews[action]
+		if views == nil {
+			views = slices.Sorted(maps.Keys(viewKeys))
+		}
+		defaults := configKeyBindings[action](&defaultKeyMap).Keys()
+		for _, k := range cfg.keys[action] {
+			if slices.Contains(defaults, k) {
+				continue
+			}
+			for _, view := range views {
+				if slices.Contains(viewKeys[view], k) {
+					problem("keys."+action, "%q is already used in the %s view", k, view)
+					break
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &ConfigError{Path: path, Problems: problems}
+	}
+	return cfg, nil
+}
+
+// keyMap returns the default key bindings with the configured ones
+// replacing them.
+func (c *Config) keyMap() keyMap {
+	km := defaultKeyMap
+	for action, keys := range c.keys {
+		b := configKeyBindings[action](&km)
+		*b = key.NewBinding(key.WithKeys(keys...), key.WithHelp(keys[0], b.Help().Desc))
+	}
+	return km
+}
+
+// ApplyTheme colors the styles as configured. It has to be called before
+// the model is created, as some views copy styles when they're created.
+func (c *Config) ApplyTheme() {
+	for name, color := range c.theme {
+		s := themeStyles[name]
+		if name == "match" {
+			*s = s.Background(color)
+		} else {
+			*s = s.Foreground(color)
+		}
+	}
+}
+
+// apply applies the configuration to the model and returns the command
+// loading the view it starts in.
+func (c *Config) apply(m *Model) tea.Cmd {
+	m.keys = c.keyMap()
+	if c.progColumns != nil {
+		m.progList.SetColumns(c.progColumns)
+	}
+	if c.mapColumns != nil {
+		m.mapList.SetColumns(c.mapColumns)
+	}
+	if c.progTable {
+		m.progList.ToggleTable()
+	}
+	if c.mapTable {
+		m.mapList.ToggleTable()
+	}
+	m.mapDump.SetDefaultModes(c.modes, c.nameModes)
+	if c.startView != ViewMenu {
+		return m.openView(c.startView)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const testConfig = `
+default_view = "maps"
+refresh_interval = "5s"
+
+[programs]
+columns = ["id", "name", "runs"]
+
+[maps]
+columns = ["id", "name", "memlock"]
+table = true
+
+[display]
+value = "xxd"
+
+[display.maps.conntrack]
+key = "u32be"
+value = "string"
+
+[theme]
+title = "#ff8800"
+match = "33"
+
+[keys]
+sort = "o"
+quit = ["Q", "ctrl+c"]
+`
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig("config.toml", testConfig)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if cfg.RefreshInterval == nil || *cfg.RefreshInterval != 5*time.Second {
+		t.Errorf("RefreshInterval = %v, want 5s", cfg.RefreshInterval)
+	}
+	if cfg.startView != ViewMapList {
+		t.Errorf("startView = %v, want maps", cfg.startView)
+	}
+	if cfg.modes.value.String() != "xxd" || cfg.nameModes["conntrack"].key.String() != "u32be" {
+		t.Errorf("modes = %+v, %+v", cfg.modes, cfg.nameModes)
+	}
+	km := cfg.keyMap()
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}, km.Sort) {
+		t.Error("sort should be bound to o")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}, km.Quit) {
+		t.Error("q should no longer quit")
+	}
+	if km.Sort.Help().Desc != defaultKeyMap.Sort.Help().Desc {
+		t.Error("rebinding should keep the help text")
+	}
+
+	// An empty file changes nothing
+	cfg, err = parseConfig("config.toml", "")
+	if err != nil || cfg.RefreshInterval != nil || cfg.startView != ViewMenu {
+		t.Errorf("parseConfig(\"\") = %+v, %v", cfg, err)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"syntax", "[maps\n", "line 2:"},
+		{"wrong type", "refresh_interval = 5\n", "incompatible types"},
+		{"unknown setting", "colour = \"red\"\n", "colour: unknown setting"},
+		{"unknown nested setting", "[maps]\ncolums = []\n", "maps.colums: unknown setting"},
+		{"view", `default_view = "mapz"`, `default_view: unknown view "mapz"`},
+		{"duration", `refresh_interval = "5x"`, `refresh_interval: invalid duration "5x"`},
+		{"negative duration", `refresh_interval = "-1s"`, "refresh_interval: can't be negative"},
+		{"column", "[programs]\ncolumns = [\"id\", \"nam\"]\n", `programs.columns: unknown column "nam"`},
+		{"no columns", "[maps]\ncolumns = []\n", "maps.columns: no columns"},
+		{"display mode", "[display]\nkey = \"u24\"\n", `display.key: unknown display mode "u24"`},
+		{"map display mode", "[display.maps.ct]\nvalue = \"float\"\n", `display.maps.ct.value: unknown display mode`},
+		{"style", "[theme]\nborder = \"1\"\n", "theme.border: unknown style"},
+		{"color", "[theme]\ntitle = \"300\"\n", `theme.title: invalid color "300"`},
+		{"action", "[keys]\njump = \"x\"\n", "keys.jump: unknown action"},
+		{"no keys", "[keys]\nsort = []\n", "keys.sort: needs at least one key"},
+		{"conflict", "[keys]\nsort = \"r\"\n", `keys: "r" is bound to both order and sort`},
+		{"view key", "[keys]\nquit = \"x\"\n", `keys.quit: "x" is already used in the map dump view`},
+		{"list key", "[keys]\nsort = \"j\"\n", `keys.sort: "j" is already used in the list view`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig("config.toml", tt.data)
+			var cerr *ConfigError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("parseConfig() error = %v, want a ConfigError", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseConfig() error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	// Keys of views an action doesn't work in are free
+	if _, err := parseConfig("config.toml", "[keys]\ndisasm = \"n\"\nsort = \"x\"\n"); err != nil {
+		t.Errorf("parseConfig() error = %v, want keys unused in the actions' views accepted", err)
+	}
+
+	// All problems are reported at once
+	_, err := parseConfig("config.toml", "default_view = \"x\"\n[theme]\ntitle = \"y\"\n")
+	if err == nil || len(err.(*ConfigError).Problems) != 2 {
+		t.Errorf("parseConfig() error = %v, want 2 problems", err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadConfig(filepath.Join(dir, "missing.toml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadConfig() of a missing file error = %v, want ErrNotExist", err)
+	}
+
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("default_view = \"nope\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadConfig() error = %v, want it to name the file", err)
+	}
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := DefaultConfigPath()
+	if err != nil || path != "/tmp/xdg/bpftui/config.toml" {
+		t.Errorf("DefaultConfigPath() = %q, %v", path, err)
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	cfg, err := parseConfig("config.toml", testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(&mockProgService{}, &mockMapsService{maps: []MapInfo{{ID: 1, Name: "conntrack"}}})
+	m.startCmd = cfg.apply(&m)
+
+	if m.state != ViewMapList || !m.mapList.IsLoading() {
+		t.Errorf("should start loading the maps list, state %v", m.state)
+	}
+	if m.startCmd == nil || m.Init() == nil {
+		t.Error("Init should load the start view")
+	}
+	if !m.mapList.IsTable() || m.progList.IsTable() {
+		t.Error("only the maps list should start as a table")
+	}
+	if got := m.mapList.table.shownCount(); got != 3 {
+		t.Errorf("maps table shows %d columns, want 3", got)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}, m.keys.Sort) {
+		t.Error("the model should use the configured keys")
+	}
+
+	// Display modes apply to maps by name, then to all others
+	m.mapDump.StartLoading(1, "conntrack")
+	if key, value := m.mapDump.GetModes(); key.String() != "u32be" || value.String() != "string" {
+		t.Errorf("conntrack modes = %s, %s", key, value)
+	}
+	m.mapDump.StartLoading(2, "other")
+	if key, value := m.mapDump.GetModes(); key.String() != "hex" || value.String() != "xxd" {
+		t.Errorf("other modes = %s, %s", key, value)
+	}
+
+	// Back leads to the menu
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).state != ViewMenu {
+		t.Error("esc should go back to the menu")
+	}
+}
+
+func TestConfigApplyTheme(t *testing.T) {
+	saved := titleStyle
+	savedMatch := matchStyle
+	defer func() { titleStyle, matchStyle = saved, savedMatch }()
+
+	cfg, err := parseConfig("config.toml", testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ApplyTheme()
+	if titleStyle.GetForeground() != lipgloss.Color("#ff8800") {
+		t.Errorf("title color = %v", titleStyle.GetForeground())
+	}
+	if !titleStyle.GetBold() {
+		t.Error("the theme should only change colors")
+	}
+	if matchStyle.GetBackground() != lipgloss.Color("33") {
+		t.Errorf("match background = %v", matchStyle.GetBackground())
+	}
+}
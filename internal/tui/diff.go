@@ -0,0 +1,243 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// baselineDiff is the comparison of programs or maps against a baseline,
+// such as an earlier snapshot.
+type baselineDiff[T any] struct {
+	status  map[uint32]itemStatus // Status of each current object, by ID
+	changes map[uint32][]string   // Fields that changed, by current ID
+	match   map[uint32]T          // Baseline counterpart, by current ID
+	removed []T                   // Baseline objects with no counterpart
+	added   int
+	changed int
+}
+
+// diffBaseline pairs each current object with its baseline counterpart and
+// compares them. An object matches the baseline object with the same ID if
+// both have the same type and name, and otherwise the first unmatched
+// baseline object with the same type and name, so reloaded programs and
+// recreated maps show as changed rather than removed and added.
+func diffBaseline[T any](baseline, current []T, id func(T) uint32, key func(T) string, changes func(old, curr T) []string) baselineDiff[T] {
+	d := baselineDiff[T]{
+		status:  make(map[uint32]itemStatus, len(current)),
+		changes: make(map[uint32][]string),
+		match:   make(map[uint32]T, len(current)),
+	}
+
+	byID := make(map[uint32]int, len(baseline))
+	for i, b := range baseline {
+		byID[id(b)] = i
+	}
+	used := make([]bool, len(baseline))
+	pair := func(c T, j int) {
+		used[j] = true
+		d.match[id(c)] = baseline[j]
+	}
+
+	// The same object first, then another object in the same role
+	for _, c := range current {
+		if j, ok := byID[id(c)]; ok && key(baseline[j]) == key(c) {
+			pair(c, j)
+		}
+	}
+	for _, c := range current {
+		if _, ok := d.match[id(c)]; ok {
+			continue
+		}
+		for j, b := range baseline {
+			if !used[j] && key(b) == key(c) {
+				pair(c, j)
+				break
+			}
+		}
+	}
+
+	for _, c := range current {
+		old, ok := d.match[id(c)]
+		if !ok {
+			d.status[id(c)] = itemAdded
+			d.added++
+			continue
+		}
+		if ch := changes(old, c); len(ch) > 0 {
+			d.status[id(c)] = itemChanged
+			d.changes[id(c)] = ch
+			d.changed++
+		}
+	}
+	for j, b := range baseline {
+		if !used[j] {
+			d.removed = append(d.removed, b)
+		}
+	}
+	return d
+}
+
+// diffCounts summarizes the number of objects added, removed and changed
+// since the baseline.
+func diffCounts(added, removed, changed int) string {
+	return fmt.Sprintf("vs baseline: +%d -%d ~%d", added, removed, changed)
+}
+
+// diffTitle annotates a list title with diffCounts.
+func diffTitle(base string, added, removed, changed int) string {
+	return fmt.Sprintf("%s (%s)", base, diffCounts(added, removed, changed))
+}
+
+// diffPrograms compares programs against baseline programs.
+func diffPrograms(baseline, current []ProgramInfo) baselineDiff[ProgramInfo] {
+	return diffBaseline(baseline, current,
+		func(p ProgramInfo) uint32 { return p.ID },
+		func(p ProgramInfo) string { return p.Type + "/" + p.Name },
+		progChanges)
+}
+
+// diffMaps compares maps against baseline maps.
+func diffMaps(baseline, current []MapInfo) baselineDiff[MapInfo] {
+	return diffBaseline(baseline, current,
+		func(m MapInfo) uint32 { return m.ID },
+		func(m MapInfo) string { return m.Type + "/" + m.Name },
+		mapChanges)
+}
+
+// progChanges describes how a program differs from its baseline version.
+func progChanges(old, curr ProgramInfo) []string {
+	var ch []string
+	if old.Tag != curr.Tag {
+		ch = append(ch, fmt.Sprintf("tag %s→%s", old.Tag, curr.Tag))
+	}
+	if old.BytesXlated != curr.BytesXlated {
+		ch = append(ch, fmt.Sprintf("xlated %d→%d", old.BytesXlated, curr.BytesXlated))
+	}
+	if old.MemLock != curr.MemLock {
+		ch = append(ch, fmt.Sprintf("memlock %d→%d", old.MemLock, curr.MemLock))
+	}
+	if !slices.Equal(old.MapIDs, curr.MapIDs) {
+		ch = append(ch, fmt.Sprintf("maps %s→%s", formatIDs(old.MapIDs), formatIDs(curr.MapIDs)))
+	}
+	return ch
+}
+
+// mapChanges describes how a map differs from its baseline version.
+func mapChanges(old, curr MapInfo) []string {
+	var ch []string
+	if old.KeySize != curr.KeySize {
+		ch = append(ch, fmt.Sprintf("key %d→%d", old.KeySize, curr.KeySize))
+	}
+	if old.ValueSize != curr.ValueSize {
+		ch = append(ch, fmt.Sprintf("value %d→%d", old.ValueSize, curr.ValueSize))
+	}
+	if old.MaxEntries != curr.MaxEntries {
+		ch = append(ch, fmt.Sprintf("max entries %d→%d", old.MaxEntries, curr.MaxEntries))
+	}
+	if old.Flags != curr.Flags {
+		ch = append(ch, fmt.Sprintf("flags 0x%x→0x%x", old.Flags, curr.Flags))
+	}
+	if old.MemLock != curr.MemLock {
+		ch = append(ch, fmt.Sprintf("memlock %d→%d", old.MemLock, curr.MemLock))
+	}
+	return ch
+}
+
+// formatIDs formats IDs as a bracketed list, e.g. "[1 2 3]".
+func formatIDs(ids []uint32) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// entryDiff is the comparison of a map's entries against its baseline entries.
+// Entries can be added a page at a time; removed entries are only known once
+// all of them have been added.
+type entryDiff struct {
+	prev    map[string][]byte // Baseline values by key
+	status  []itemStatus      // Status of each current entry
+	old     [][]byte          // Baseline value of each changed entry
+	removed []MapEntry        // Baseline entries whose key is gone
+	added   int
+	changed int
+}
+
+// newEntryDiff starts a comparison against the baseline entries.
+func newEntryDiff(baseline []MapEntry) entryDiff {
+	prev := make(map[string][]byte, len(baseline))
+	for _, e := range baseline {
+		prev[string(e.Key)] = e.Value
+	}
+	return entryDiff{prev: prev}
+}
+
+// reset forgets the current entries, keeping the baseline.
+func (d *entryDiff) reset() {
+	*d = entryDiff{prev: d.prev}
+}
+
+// add compares further current entries against the baseline.
+func (d *entryDiff) add(current []MapEntry) {
+	for _, e := range current {
+		old, ok := d.prev[string(e.Key)]
+		switch {
+		case !ok:
+			d.status = append(d.status, itemAdded)
+			d.old = append(d.old, nil)
+			d.added++
+		case !slices.Equal(old, e.Value):
+			d.status = append(d.status, itemChanged)
+			d.old = append(d.old, old)
+			d.changed++
+		default:
+			d.status = append(d.status, itemUnchanged)
+			d.old = append(d.old, nil)
+		}
+	}
+}
+
+// finish finds the baseline entries missing from current, which holds all
+// of the map's entries.
+func (d *entryDiff) finish(baseline, current []MapEntry) {
+	seen := make(map[string]bool, len(current))
+	for _, e := range current {
+		seen[string(e.Key)] = true
+	}
+	d.removed = nil
+	for _, e := range baseline {
+		if !seen[string(e.Key)] {
+			d.removed = append(d.removed, e)
+		}
+	}
+}
+
+// diffEntries compares entries against baseline entries by key.
+func diffEntries(baseline, current []MapEntry) entryDiff {
+	d := newEntryDiff(baseline)
+	d.add(current)
+	d.finish(baseline, current)
+	return d
+}
+
+// baselineEntries returns the entries the snapshot recorded for the
+// counterpart of the map info among the current maps. It returns an empty
+// slice if the map is new and nil if the counterpart couldn't be dumped.
+func baselineEntries(snap *Snapshot, current []MapInfo, info MapInfo) []MapEntry {
+	if !slices.ContainsFunc(current, func(m MapInfo) bool { return m.ID == info.ID }) {
+		current = []MapInfo{info}
+	}
+	old, ok := diffMaps(snap.Maps, current).match[info.ID]
+	if !ok {
+		return []MapEntry{}
+	}
+	if _, failed := snap.DumpErrors[old.ID]; failed {
+		return nil
+	}
+	if entries := snap.Dumps[old.ID]; entries != nil {
+		return entries
+	}
+	return []MapEntry{}
+}
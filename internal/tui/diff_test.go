@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDiffPrograms(t *testing.T) {
+	baseline := []ProgramInfo{
+		{ID: 1, Name: "xdp_main", Type: "xdp", Tag: "aaaa", MapIDs: []uint32{10}},
+		{ID: 2, Name: "tc_egress", Type: "sched_cls", Tag: "bbbb"},
+		{ID: 3, Name: "kprobe_old", Type: "kprobe", Tag: "cccc"},
+	}
+	current := []ProgramInfo{
+		{ID: 1, Name: "xdp_main", Type: "xdp", Tag: "aaaa", MapIDs: []uint32{10}},
+		// Reloaded with a new ID and new code
+		{ID: 7, Name: "tc_egress", Type: "sched_cls", Tag: "dddd"},
+		{ID: 8, Name: "tracepoint_new", Type: "tracepoint"},
+	}
+
+	d := diffPrograms(baseline, current)
+	if d.status[1] != itemUnchanged || d.status[7] != itemChanged || d.status[8] != itemAdded {
+		t.Errorf("status = %v", d.status)
+	}
+	if !slices.Equal(d.changes[7], []string{"tag bbbb→dddd"}) {
+		t.Errorf("changes = %v", d.changes[7])
+	}
+	if d.match[7].ID != 2 {
+		t.Errorf("reloaded program should match the baseline program in the same role, got %d", d.match[7].ID)
+	}
+	if len(d.removed) != 1 || d.removed[0].ID != 3 {
+		t.Errorf("removed = %v, want program 3", d.removed)
+	}
+	if got := diffTitle("BPF Programs", d.added, len(d.removed), d.changed); got != "BPF Programs (vs baseline: +1 -1 ~1)" {
+		t.Errorf("diffTitle() = %q", got)
+	}
+}
+
+func TestDiffMapsReusedID(t *testing.T) {
+	// An ID reused by a different map doesn't match
+	baseline := []MapInfo{{ID: 5, Name: "counts", Type: "hash", MaxEntries: 16}}
+	current := []MapInfo{{ID: 5, Name: "events", Type: "ringbuf"}}
+
+	d := diffMaps(baseline, current)
+	if d.status[5] != itemAdded || len(d.removed) != 1 {
+		t.Errorf("status = %v, removed = %v", d.status, d.removed)
+	}
+}
+
+func TestMapChanges(t *testing.T) {
+	old := MapInfo{KeySize: 4, ValueSize: 8, MaxEntries: 16, MemLock: 4096}
+	curr := MapInfo{KeySize: 4, ValueSize: 8, MaxEntries: 1024, MemLock: 8192}
+	want := []string{"max entries 16→1024", "memlock 4096→8192"}
+	if got := mapChanges(old, curr); !slices.Equal(got, want) {
+		t.Errorf("mapChanges() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffEntries(t *testing.T) {
+	baseline := []MapEntry{
+		{Key: []byte{1}, Value: []byte{10}},
+		{Key: []byte{2}, Value: []byte{20}},
+		{Key: []byte{3}, Value: []byte{30}},
+	}
+	current := []MapEntry{
+		{Key: []byte{1}, Value: []byte{10}},
+		{Key: []byte{2}, Value: []byte{21}},
+		{Key: []byte{4}, Value: []byte{40}},
+	}
+
+	d := diffEntries(baseline, current)
+	if !slices.Equal(d.status, []itemStatus{itemUnchanged, itemChanged, itemAdded}) {
+		t.Errorf("status = %v", d.status)
+	}
+	if !slices.Equal(d.old[1], []byte{20}) {
+		t.Errorf("old value = %v, want [20]", d.old[1])
+	}
+	if len(d.removed) != 1 || d.removed[0].Key[0] != 3 {
+		t.Errorf("removed = %v, want key 3", d.removed)
+	}
+}
+
+func TestBaselineEntries(t *testing.T) {
+	snap := newTestSnapshot(t)
+
+	// Map 10 was recreated as map 12
+	current := []MapInfo{{ID: 12, Name: "counts", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 16}}
+	if got := baselineEntries(snap, current, current[0]); len(got) != 1 {
+		t.Errorf("baselineEntries() = %v, want map 10's entries", got)
+	}
+
+	if got := baselineEntries(snap, nil, MapInfo{ID: 20, Name: "new", Type: "array"}); got == nil || len(got) != 0 {
+		t.Errorf("a new map should have an empty baseline, got %v", got)
+	}
+	if got := baselineEntries(snap, nil, MapInfo{ID: 11, Name: "events", Type: "ringbuf"}); got != nil {
+		t.Errorf("a map that couldn't be dumped should have no baseline, got %v", got)
+	}
+}
+
+func TestProgListModel_Baseline(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetBaseline([]ProgramInfo{
+		{ID: 1, Name: "prog1", Type: "xdp", Tag: "aaaa"},
+		{ID: 2, Name: "prog2", Type: "xdp"},
+	})
+	m.SetPrograms([]ProgramInfo{{ID: 1, Name: "prog1", Type: "xdp", Tag: "bbbb"}})
+
+	if m.list.Title != "BPF Programs (vs baseline: +0 -1 ~1)" {
+		t.Errorf("title = %q", m.list.Title)
+	}
+	items := m.list.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected the program and the removed baseline program, got %d items", len(items))
+	}
+	if item := items[0].(progItem); item.status != itemChanged || !containsString(item.Description(), "Changed: tag aaaa→bbbb") {
+		t.Errorf("changed program = %+v, %q", item, item.Description())
+	}
+	if item := items[1].(progItem); item.status != itemRemoved || item.info.ID != 2 {
+		t.Errorf("removed program = %+v", item)
+	}
+
+	// Refreshes keep comparing against the baseline, not the previous poll
+	m.RefreshPrograms([]ProgramInfo{{ID: 1, Name: "prog1", Type: "xdp", Tag: "bbbb"}}, m.samples[1].at)
+	if len(m.list.Items()) != 2 || m.list.Title != "BPF Programs (vs baseline: +0 -1 ~1)" {
+		t.Errorf("after refresh: %d items, title %q", len(m.list.Items()), m.list.Title)
+	}
+}
+
+func TestMapListModel_Baseline(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetBaseline([]MapInfo{})
+	m.SetMaps([]MapInfo{{ID: 1, Name: "map1", Type: "hash"}})
+
+	if item := m.list.Items()[0].(mapItem); item.status != itemAdded {
+		t.Errorf("a map missing from the baseline should be added, got %v", item.status)
+	}
+	if m.list.Title != "BPF Maps (vs baseline: +1 -0 ~0)" {
+		t.Errorf("title = %q", m.list.Title)
+	}
+
+	m.SetBaseline(nil)
+	m.SetMaps([]MapInfo{{ID: 1, Name: "map1", Type: "hash"}})
+	if item := m.list.Items()[0].(mapItem); item.status != itemUnchanged || m.list.Title != "BPF Maps" {
+		t.Error("without a baseline, maps should not be compared")
+	}
+}
+
+func TestMapDumpModel_Baseline(t *testing.T) {
+	m := newMapDumpModel(80, 40)
+	m.SetMapDump(1, "test_map", []MapEntry{
+		{Key: []byte{1}, Value: []byte{0x0a}},
+		{Key: []byte{2}, Value: []byte{0x0c}},
+	})
+	m.SetBaseline([]MapEntry{
+		{Key: []byte{2}, Value: []byte{0x0b}},
+		{Key: []byte{3}, Value: []byte{0x0d}},
+	})
+
+	view := m.View()
+	for _, want := range []string{"vs baseline: +1 -1 ~1", "Was:", "0b", "03", "0d"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Removed entries are shown but can't be selected
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if e := m.SelectedEntry(); e == nil || e.Key[0] != 2 {
+		t.Errorf("selection should stop at the last current entry, got %v", e)
+	}
+
+	// A new dump clears the comparison
+	m.StartLoading(2, "other")
+	if m.IsComparing() {
+		t.Error("loading another map should clear the baseline")
+	}
+}
+
+// TestIntegrationDiffSnapshots tests browsing one snapshot compared against
+// another, through the maps list into a dump.
+func TestIntegrationDiffSnapshots(t *testing.T) {
+	baseline := newTestSnapshot(t)
+	current := newTestSnapshot(t)
+	current.Dumps[10] = []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{43, 0, 0, 0}}}
+
+	m := NewModel(NewSnapshotProgService(current), NewSnapshotMapsService(current))
+	m.SetBaseline(baseline)
+
+	m = openMapDump(m)
+	if m.state != ViewMapDump || !m.mapDump.IsComparing() {
+		t.Fatalf("expected a compared dump, got state %v", m.state)
+	}
+	view := m.View()
+	if !containsString(view, "vs baseline: +0 -0 ~1") || !containsString(view, "Was:") {
+		t.Errorf("dump should show the changed value:\n%s", view)
+	}
+}
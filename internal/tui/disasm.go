@@ -0,0 +1,258 @@
+package tui
+
+import "fmt"
+
+// eBPF instruction classes, the low three bits of an opcode.
+const (
+	bpfClassLD    = 0x00
+	bpfClassLDX   = 0x01
+	bpfClassST    = 0x02
+	bpfClassSTX   = 0x03
+	bpfClassALU   = 0x04
+	bpfClassJMP   = 0x05
+	bpfClassJMP32 = 0x06
+	bpfClassALU64 = 0x07
+)
+
+// Opcode fields and values used by the disassembler.
+const (
+	bpfSrcX = 0x08 // Operand is the source register rather than the immediate
+
+	bpfModeIMM    = 0x00
+	bpfModeABS    = 0x20
+	bpfModeIND    = 0x40
+	bpfModeMEM    = 0x60
+	bpfModeMEMSX  = 0x80
+	bpfModeATOMIC = 0xc0
+
+	bpfSizeDW = 0x18
+
+	bpfOpDIV  = 0x30
+	bpfOpNEG  = 0x80
+	bpfOpMOD  = 0x90
+	bpfOpMOV  = 0xb0
+	bpfOpEND  = 0xd0
+	bpfOpJA   = 0x00
+	bpfOpCALL = 0x80
+	bpfOpEXIT = 0x90
+	bpfOpCOND = 0xe0
+
+	bpfFetch         = 0x01
+	bpfAtomicXchg    = 0xe0 | bpfFetch
+	bpfAtomicCmpXchg = 0xf0 | bpfFetch
+	bpfLoadAcquire   = 0x100
+	bpfStoreRelease  = 0x110
+
+	bpfPseudoMapFD    = 1
+	bpfPseudoMapValue = 2
+	bpfPseudoCall     = 1
+)
+
+// bpfALUOps are the ALU operators indexed by op >> 4.
+var bpfALUOps = [16]string{"+=", "-=", "*=", "/=", "|=", "&=", "<<=", ">>=", "neg", "%=", "^=", "=", "s>>=", "endian"}
+
+// bpfJumpOps are the conditional jump operators indexed by op >> 4.
+var bpfJumpOps = [16]string{"jmp", "==", ">", ">=", "&", "!=", "s>", "s>=", "call", "exit", "<", "<=", "s<", "s<=", "cond"}
+
+// bpfAtomicOps names the atomic read-modify-write operations.
+var bpfAtomicOps = map[int64]struct{ op, name string }{
+	0x00: {"+=", "add"},
+	0x40: {"|=", "or"},
+	0x50: {"&=", "and"},
+	0xa0: {"^=", "xor"},
+}
+
+// bpfSizes are the memory access sizes indexed by size >> 3.
+var bpfSizes = [4]string{"u32", "u16", "u8", "u64"}
+
+// bpfSignedSizes are the sign-extending load sizes indexed by size >> 3.
+var bpfSignedSizes = [4]string{"s32", "s16", "s8", "s64"}
+
+// formatInstruction renders ins as eBPF assembly in the notation used by the
+// kernel verifier log and `bpftool prog dump xlated`, e.g. "(b7) r0 = 0".
+func formatInstruction(ins Instruction) string {
+	code := ins.OpCode
+	prefix := fmt.Sprintf("(%02x) ", code)
+
+	switch code & 0x07 {
+	case bpfClassALU, bpfClassALU64:
+		return prefix + formatALU(ins)
+
+	case bpfClassLDX:
+		return prefix + formatLDX(ins)
+
+	case bpfClassST:
+		if code&0xe0 == bpfModeMEM {
+			return prefix + fmt.Sprintf("*(%s *)(r%d %+d) = %d", bpfSizes[code&0x18>>3], ins.Dst, ins.Off, int32(ins.Imm))
+		}
+
+	case bpfClassSTX:
+		return prefix + formatSTX(ins)
+
+	case bpfClassLD:
+		return prefix + formatLD(ins)
+
+	case bpfClassJMP, bpfClassJMP32:
+		return prefix + formatJump(ins)
+	}
+
+	return prefix + "unknown opcode"
+}
+
+// formatALU renders an arithmetic instruction. 32-bit operations use w registers.
+func formatALU(ins Instruction) string {
+	code := ins.OpCode
+	reg := "w"
+	if code&0x07 == bpfClassALU64 {
+		reg = "r"
+	}
+	op := code & 0xf0
+
+	switch {
+	case op == bpfOpEND:
+		if code&0x07 == bpfClassALU64 {
+			return fmt.Sprintf("r%d = bswap%d r%d", ins.Dst, ins.Imm, ins.Dst)
+		}
+		order := "le"
+		if code&bpfSrcX != 0 {
+			order = "be"
+		}
+		return fmt.Sprintf("r%d = %s%d r%d", ins.Dst, order, ins.Imm, ins.Dst)
+
+	case op == bpfOpNEG:
+		return fmt.Sprintf("%s%d = -%s%d", reg, ins.Dst, reg, ins.Dst)
+	}
+
+	sym := bpfALUOps[op>>4]
+	if (op == bpfOpDIV || op == bpfOpMOD) && ins.Off == 1 {
+		sym = "s" + sym
+	}
+
+	if code&bpfSrcX == 0 {
+		return fmt.Sprintf("%s%d %s %d", reg, ins.Dst, sym, int32(ins.Imm))
+	}
+
+	cast := ""
+	if op == bpfOpMOV && ins.Off != 0 {
+		// Sign-extending move
+		cast = fmt.Sprintf("(s%d)", ins.Off)
+	}
+	return fmt.Sprintf("%s%d %s %s%s%d", reg, ins.Dst, sym, cast, reg, ins.Src)
+}
+
+// formatLDX renders a load from memory.
+func formatLDX(ins Instruction) string {
+	size := ins.OpCode & 0x18 >> 3
+	switch ins.OpCode & 0xe0 {
+	case bpfModeMEM:
+		return fmt.Sprintf("r%d = *(%s *)(r%d %+d)", ins.Dst, bpfSizes[size], ins.Src, ins.Off)
+	case bpfModeMEMSX:
+		return fmt.Sprintf("r%d = *(%s *)(r%d %+d)", ins.Dst, bpfSignedSizes[size], ins.Src, ins.Off)
+	}
+	return "unknown opcode"
+}
+
+// formatSTX renders a store from a register, including atomic operations.
+func formatSTX(ins Instruction) string {
+	code := ins.OpCode
+	size := bpfSizes[code&0x18>>3]
+
+	switch code & 0xe0 {
+	case bpfModeMEM:
+		return fmt.Sprintf("*(%s *)(r%d %+d) = r%d", size, ins.Dst, ins.Off, ins.Src)
+
+	case bpfModeATOMIC:
+		width := ""
+		if code&0x18 == bpfSizeDW {
+			width = "64"
+		}
+
+		switch imm := ins.Imm; {
+		case imm == bpfAtomicXchg:
+			return fmt.Sprintf("r%d = atomic%s_xchg((%s *)(r%d %+d), r%d)", ins.Src, width, size, ins.Dst, ins.Off, ins.Src)
+		case imm == bpfAtomicCmpXchg:
+			return fmt.Sprintf("r0 = atomic%s_cmpxchg((%s *)(r%d %+d), r0, r%d)", width, size, ins.Dst, ins.Off, ins.Src)
+		case imm == bpfLoadAcquire:
+			return fmt.Sprintf("r%d = load_acquire((%s *)(r%d %+d))", ins.Dst, size, ins.Src, ins.Off)
+		case imm == bpfStoreRelease:
+			return fmt.Sprintf("store_release((%s *)(r%d %+d), r%d)", size, ins.Dst, ins.Off, ins.Src)
+		}
+
+		atomic, ok := bpfAtomicOps[ins.Imm&^bpfFetch]
+		if !ok {
+			break
+		}
+		if ins.Imm&bpfFetch != 0 {
+			return fmt.Sprintf("r%d = atomic%s_fetch_%s((%s *)(r%d %+d), r%d)", ins.Src, width, atomic.name, size, ins.Dst, ins.Off, ins.Src)
+		}
+		return fmt.Sprintf("lock *(%s *)(r%d %+d) %s r%d", size, ins.Dst, ins.Off, atomic.op, ins.Src)
+	}
+	return "unknown opcode"
+}
+
+// formatLD renders a wide immediate load or a legacy packet access.
+func formatLD(ins Instruction) string {
+	code := ins.OpCode
+	size := bpfSizes[code&0x18>>3]
+
+	switch code & 0xe0 {
+	case bpfModeABS:
+		return fmt.Sprintf("r0 = *(%s *)skb[%d]", size, int32(ins.Imm))
+
+	case bpfModeIND:
+		return fmt.Sprintf("r0 = *(%s *)skb[r%d + %d]", size, ins.Src, int32(ins.Imm))
+
+	case bpfModeIMM:
+		if code&0x18 != bpfSizeDW {
+			break
+		}
+		switch ins.Src {
+		case bpfPseudoMapFD:
+			return fmt.Sprintf("r%d = map[id:%d]", ins.Dst, uint32(ins.Imm))
+		case bpfPseudoMapValue:
+			return fmt.Sprintf("r%d = map[id:%d][0]+%d", ins.Dst, uint32(ins.Imm), uint32(uint64(ins.Imm)>>32))
+		}
+		return fmt.Sprintf("r%d = 0x%x", ins.Dst, uint64(ins.Imm))
+	}
+	return "unknown opcode"
+}
+
+// formatJump renders jumps, calls and exits.
+func formatJump(ins Instruction) string {
+	code := ins.OpCode
+	reg := "r"
+	if code&0x07 == bpfClassJMP32 {
+		reg = "w"
+	}
+	op := code & 0xf0
+
+	switch op {
+	case bpfOpCALL:
+		if ins.Src == bpfPseudoCall {
+			return fmt.Sprintf("call pc%+d", int32(ins.Imm))
+		}
+		name := ins.Call
+		if name == "" {
+			name = "unknown"
+		}
+		return fmt.Sprintf("call %s#%d", name, int32(ins.Imm))
+
+	case bpfOpEXIT:
+		return "exit"
+
+	case bpfOpJA:
+		if code&0x07 == bpfClassJMP32 {
+			return fmt.Sprintf("gotol pc%+d", int32(ins.Imm))
+		}
+		return fmt.Sprintf("goto pc%+d", ins.Off)
+
+	case bpfOpCOND:
+		return fmt.Sprintf("may_goto pc%+d", ins.Off)
+	}
+
+	sym := bpfJumpOps[op>>4]
+	if code&bpfSrcX != 0 {
+		return fmt.Sprintf("if %s%d %s %s%d goto pc%+d", reg, ins.Dst, sym, reg, ins.Src, ins.Off)
+	}
+	return fmt.Sprintf("if %s%d %s 0x%x goto pc%+d", reg, ins.Dst, sym, uint32(ins.Imm), ins.Off)
+}
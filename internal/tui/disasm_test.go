@@ -0,0 +1,51 @@
+package tui
+
+import "testing"
+
+func TestFormatInstruction(t *testing.T) {
+	tests := []struct {
+		name string
+		ins  Instruction
+		want string
+	}{
+		{"mov imm", Instruction{OpCode: 0xb7, Dst: 0, Imm: 0}, "(b7) r0 = 0"},
+		{"mov reg", Instruction{OpCode: 0xbf, Dst: 6, Src: 1}, "(bf) r6 = r1"},
+		{"movsx", Instruction{OpCode: 0xbf, Dst: 1, Src: 2, Off: 8}, "(bf) r1 = (s8)r2"},
+		{"alu32 add", Instruction{OpCode: 0x04, Dst: 1, Imm: -4}, "(04) w1 += -4"},
+		{"alu64 add reg", Instruction{OpCode: 0x0f, Dst: 2, Src: 3}, "(0f) r2 += r3"},
+		{"sdiv", Instruction{OpCode: 0x3f, Dst: 1, Src: 2, Off: 1}, "(3f) r1 s/= r2"},
+		{"neg", Instruction{OpCode: 0x87, Dst: 1}, "(87) r1 = -r1"},
+		{"be16", Instruction{OpCode: 0xdc, Dst: 1, Imm: 16}, "(dc) r1 = be16 r1"},
+		{"bswap", Instruction{OpCode: 0xd7, Dst: 1, Imm: 32}, "(d7) r1 = bswap32 r1"},
+		{"ldx", Instruction{OpCode: 0x61, Dst: 2, Src: 1, Off: 4}, "(61) r2 = *(u32 *)(r1 +4)"},
+		{"ldxsx", Instruction{OpCode: 0x91, Dst: 2, Src: 1, Off: -1}, "(91) r2 = *(s8 *)(r1 -1)"},
+		{"st", Instruction{OpCode: 0x62, Dst: 10, Off: -4, Imm: 0}, "(62) *(u32 *)(r10 -4) = 0"},
+		{"stx", Instruction{OpCode: 0x7b, Dst: 10, Src: 1, Off: -8}, "(7b) *(u64 *)(r10 -8) = r1"},
+		{"atomic add", Instruction{OpCode: 0xdb, Dst: 0, Src: 1, Imm: 0x00}, "(db) lock *(u64 *)(r0 +0) += r1"},
+		{"atomic fetch add", Instruction{OpCode: 0xc3, Dst: 0, Src: 1, Imm: 0x01}, "(c3) r1 = atomic_fetch_add((u32 *)(r0 +0), r1)"},
+		{"cmpxchg", Instruction{OpCode: 0xdb, Dst: 2, Src: 3, Imm: 0xf1}, "(db) r0 = atomic64_cmpxchg((u64 *)(r2 +0), r0, r3)"},
+		{"map load", Instruction{OpCode: 0x18, Dst: 1, Src: bpfPseudoMapFD, Imm: 12}, "(18) r1 = map[id:12]"},
+		{"map value", Instruction{OpCode: 0x18, Dst: 1, Src: bpfPseudoMapValue, Imm: 8<<32 | 12}, "(18) r1 = map[id:12][0]+8"},
+		{"wide imm", Instruction{OpCode: 0x18, Dst: 1, Imm: 0x1234}, "(18) r1 = 0x1234"},
+		{"ld abs", Instruction{OpCode: 0x30, Imm: 23}, "(30) r0 = *(u8 *)skb[23]"},
+		{"helper call", Instruction{OpCode: 0x85, Imm: 1, Call: "bpf_map_lookup_elem"}, "(85) call bpf_map_lookup_elem#1"},
+		{"unresolved call", Instruction{OpCode: 0x85, Imm: 9999}, "(85) call unknown#9999"},
+		{"bpf2bpf call", Instruction{OpCode: 0x85, Src: bpfPseudoCall, Imm: 5}, "(85) call pc+5"},
+		{"exit", Instruction{OpCode: 0x95}, "(95) exit"},
+		{"goto", Instruction{OpCode: 0x05, Off: -3}, "(05) goto pc-3"},
+		{"gotol", Instruction{OpCode: 0x06, Imm: 70000}, "(06) gotol pc+70000"},
+		{"jeq imm", Instruction{OpCode: 0x15, Dst: 0, Imm: 0, Off: 2}, "(15) if r0 == 0x0 goto pc+2"},
+		{"jgt reg", Instruction{OpCode: 0x2d, Dst: 1, Src: 2, Off: 1}, "(2d) if r1 > r2 goto pc+1"},
+		{"jmp32 jslt", Instruction{OpCode: 0xc6, Dst: 1, Imm: -1, Off: 4}, "(c6) if w1 s< 0xffffffff goto pc+4"},
+		{"may_goto", Instruction{OpCode: 0xe5, Off: 3}, "(e5) may_goto pc+3"},
+		{"unknown", Instruction{OpCode: 0x02 | 0xe0}, "(e2) unknown opcode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatInstruction(tt.ins); got != tt.want {
+				t.Errorf("formatInstruction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/btf"
+)
+
+// Xlated returns the program's instructions as translated by the verifier.
+// Helper and kfunc calls are resolved through /proc/kallsyms when readable.
+func (a *ProgServiceAdapter) Xlated(id uint32) ([]Instruction, error) {
+	p, err := ebpf.NewProgramFromID(ebpf.ProgramID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program by ID %d: %w", id, err)
+	}
+	defer p.Close()
+
+	info, err := p.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program info: %w", err)
+	}
+	insns, err := info.Instructions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instructions: %w", err)
+	}
+
+	// Symbols are best effort; calls fall back to helper IDs without them
+	syms, _ := loadKallsyms()
+	return convertInstructions(insns, syms), nil
+}
+
+// JITed returns the program's JIT-compiled native code, split into functions
+// using the kernel's per-function lengths. Function names come from BTF func
+// info, falling back to the kernel's symbol for the function.
+func (a *ProgServiceAdapter) JITed(id uint32) (*JITImage, error) {
+	p, err := ebpf.NewProgramFromID(ebpf.ProgramID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program by ID %d: %w", id, err)
+	}
+	defer p.Close()
+
+	info, err := p.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program info: %w", err)
+	}
+	code, ok := info.JitedInsns()
+	if !ok {
+		return nil, fmt.Errorf("no JIT image available (program not JIT-compiled, or raw dumps not permitted)")
+	}
+
+	addrs, _ := info.JitedKsymAddrs()
+	lens, _ := info.JitedFuncLens()
+	var names []string
+	if funcs, err := info.FuncInfos(); err == nil {
+		for _, fo := range funcs {
+			names = append(names, fo.Func.Name)
+		}
+	}
+
+	// Symbols are best effort; functions and call targets stay unnamed without them
+	syms, _ := loadKallsyms()
+	return &JITImage{
+		Arch:   runtime.GOARCH,
+		Code:   code,
+		Funcs:  jitFuncs(len(code), addrs, lens, names, syms),
+		Symbol: syms.symbol,
+	}, nil
+}
+
+// jitFuncs describes the functions of a JIT image. Kernels without
+// per-function lengths report a single function spanning the whole image.
+func jitFuncs(size int, addrs []uintptr, lens []uint32, names []string, syms *kallsyms) []JITFunc {
+	if len(lens) == 0 {
+		lens = []uint32{uint32(size)}
+	}
+
+	funcs := make([]JITFunc, len(lens))
+	for i, n := range lens {
+		funcs[i].Len = n
+		if i < len(addrs) {
+			funcs[i].Addr = uint64(addrs[i])
+		}
+		switch {
+		case i < len(names) && names[i] != "":
+			funcs[i].Name = names[i]
+		case syms.symbol(funcs[i].Addr) != "":
+			funcs[i].Name = syms.symbol(funcs[i].Addr)
+		default:
+			funcs[i].Name = fmt.Sprintf("func#%d", i)
+		}
+	}
+	return funcs
+}
+
+// convertInstructions converts cilium/ebpf instructions, resolving call
+// targets with syms.
+func convertInstructions(insns asm.Instructions, syms *kallsyms) []Instruction {
+	result := make([]Instruction, 0, len(insns))
+	offset := 0
+	for _, ins := range insns {
+		ri := Instruction{
+			Offset: offset,
+			OpCode: uint8(ins.OpCode),
+			Dst:    uint8(ins.Dst),
+			Src:    uint8(ins.Src),
+			Off:    ins.Offset,
+			Imm:    ins.Constant,
+			Func:   ins.Symbol(),
+		}
+		if fn := btf.FuncMetadata(&ins); fn != nil {
+			ri.Func = fn.Name
+		}
+		if src := ins.Source(); src != nil {
+			ri.Source = strings.TrimSpace(src.String())
+		}
+		if ins.IsLoadFromMap() {
+			// The kernel reports map references by ID in xlated programs
+			ri.MapID = uint32(ins.Constant)
+		}
+		if ins.OpCode.JumpOp() == asm.Call && !ins.IsFunctionCall() {
+			ri.Call = syms.callName(ins.Constant)
+		}
+
+		result = append(result, ri)
+		offset += int(ins.Size() / asm.InstructionSize)
+	}
+	return result
+}
+
+// kallsyms maps kernel symbol addresses to names.
+type kallsyms struct {
+	names    map[uint64]string
+	callBase uint64 // Address of __bpf_call_base, which call immediates are relative to
+}
+
+// loadKallsyms reads the kernel symbol table.
+func loadKallsyms() (*kallsyms, error) {
+	f, err := os.Open("/proc/kallsyms")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseKallsyms(f)
+}
+
+// parseKallsyms parses /proc/kallsyms content. Zeroed addresses, as shown
+// when kptr_restrict hides them, are skipped.
+func parseKallsyms(r io.Reader) (*kallsyms, error) {
+	syms := &kallsyms{names: make(map[uint64]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		addr, err := strconv.ParseUint(fields[0], 16, 64)
+		if err != nil || addr == 0 {
+			continue
+		}
+		name := fields[2]
+		if name == "__bpf_call_base" {
+			syms.callBase = addr
+		}
+		if _, ok := syms.names[addr]; !ok {
+			syms.names[addr] = name
+		}
+	}
+	return syms, scanner.Err()
+}
+
+// symbol returns the name of the symbol at addr, or "" if unknown.
+func (s *kallsyms) symbol(addr uint64) string {
+	if s == nil || addr == 0 {
+		return ""
+	}
+	return s.names[addr]
+}
+
+// callName resolves the target of a helper or kfunc call. After verification
+// the immediate is the target's offset from __bpf_call_base; calls the kernel
+// leaves unpatched (e.g. tail calls) still carry the helper ID.
+func (s *kallsyms) callName(imm int64) string {
+	if s != nil && s.callBase != 0 {
+		if name, ok := s.names[s.callBase+uint64(imm)]; ok {
+			return name
+		}
+	}
+	return builtinHelperName(asm.BuiltinFunc(imm).String())
+}
+
+// builtinHelperName converts a cilium/ebpf helper constant name such as
+// "FnMapLookupElem" to the kernel's "bpf_map_lookup_elem".
+// Returns "" for unknown helpers.
+func builtinHelperName(s string) string {
+	s, ok := strings.CutPrefix(s, "Fn")
+	if !ok || s == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("bpf")
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
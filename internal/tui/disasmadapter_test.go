@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cilium/ebpf/asm"
+)
+
+func TestParseKallsyms(t *testing.T) {
+	input := strings.Join([]string{
+		"ffffffff81000000 T _stext",
+		"ffffffff81200000 T __bpf_call_base",
+		"ffffffff81200040 T bpf_map_lookup_elem",
+		"ffffffffc0000000 t helper_in_module [my_mod]",
+		"0000000000000000 T hidden_by_kptr_restrict",
+		"garbage",
+	}, "\n")
+
+	syms, err := parseKallsyms(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseKallsyms() error = %v", err)
+	}
+	if syms.callBase != 0xffffffff81200000 {
+		t.Errorf("callBase = %x, want ffffffff81200000", syms.callBase)
+	}
+	if got := syms.names[0xffffffffc0000000]; got != "helper_in_module" {
+		t.Errorf("module symbol = %q, want helper_in_module", got)
+	}
+	if len(syms.names) != 4 {
+		t.Errorf("expected 4 symbols, got %d", len(syms.names))
+	}
+}
+
+func TestKallsymsCallName(t *testing.T) {
+	syms := &kallsyms{
+		names:    map[uint64]string{0x1040: "htab_map_lookup_elem"},
+		callBase: 0x1000,
+	}
+
+	if got := syms.callName(0x40); got != "htab_map_lookup_elem" {
+		t.Errorf("callName(0x40) = %q, want htab_map_lookup_elem", got)
+	}
+	// Unpatched calls fall back to the helper ID
+	if got := syms.callName(int64(asm.FnTailCall)); got != "bpf_tail_call" {
+		t.Errorf("callName(tail call) = %q, want bpf_tail_call", got)
+	}
+	// No symbol table at all
+	var none *kallsyms
+	if got := none.callName(int64(asm.FnMapLookupElem)); got != "bpf_map_lookup_elem" {
+		t.Errorf("nil callName = %q, want bpf_map_lookup_elem", got)
+	}
+	if got := none.callName(-12345); got != "" {
+		t.Errorf("unknown call = %q, want empty", got)
+	}
+}
+
+func TestJITFuncs(t *testing.T) {
+	syms := &kallsyms{names: map[uint64]string{0x2000: "bpf_prog_abc_subprog"}}
+
+	funcs := jitFuncs(30, []uintptr{0x1000, 0x2000, 0x3000}, []uint32{10, 15, 5}, []string{"main"}, syms)
+	want := []JITFunc{
+		{Name: "main", Addr: 0x1000, Len: 10},
+		{Name: "bpf_prog_abc_subprog", Addr: 0x2000, Len: 15},
+		{Name: "func#2", Addr: 0x3000, Len: 5},
+	}
+	if len(funcs) != len(want) {
+		t.Fatalf("expected %d funcs, got %+v", len(want), funcs)
+	}
+	for i := range want {
+		if funcs[i] != want[i] {
+			t.Errorf("func %d = %+v, want %+v", i, funcs[i], want[i])
+		}
+	}
+
+	// Without per-function lengths the whole image is one function
+	funcs = jitFuncs(30, nil, nil, nil, nil)
+	if len(funcs) != 1 || funcs[0].Len != 30 || funcs[0].Name != "func#0" {
+		t.Errorf("single function = %+v", funcs)
+	}
+}
+
+func TestBuiltinHelperName(t *testing.T) {
+	tests := map[string]string{
+		"FnMapLookupElem":     "bpf_map_lookup_elem",
+		"FnGetCurrentPidTgid": "bpf_get_current_pid_tgid",
+		"FnGetPrandomU32":     "bpf_get_prandom_u32",
+		"BuiltinFunc(99999)":  "",
+	}
+	for in, want := range tests {
+		if got := builtinHelperName(in); got != want {
+			t.Errorf("builtinHelperName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestConvertInstructions(t *testing.T) {
+	insns := asm.Instructions{
+		asm.LoadMapPtr(asm.R1, 0).WithSymbol("prog"),
+		asm.FnMapLookupElem.Call(),
+		asm.Return(),
+	}
+	// The kernel reports map references by ID
+	insns[0].Constant = 7
+
+	got := convertInstructions(insns, nil)
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 instructions, got %d", len(got))
+	}
+	if got[0].Func != "prog" || got[0].MapID != 7 || got[0].Offset != 0 {
+		t.Errorf("map load = %+v, want Func=prog MapID=7 Offset=0", got[0])
+	}
+	// The wide map load takes two slots
+	if got[1].Offset != 2 || got[1].Call != "bpf_map_lookup_elem" {
+		t.Errorf("call = %+v, want Offset=2 Call=bpf_map_lookup_elem", got[1])
+	}
+	if got[2].Offset != 3 || formatInstruction(got[2]) != "(95) exit" {
+		t.Errorf("exit = %+v", got[2])
+	}
+}
@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// displayKind is a way of rendering keys and values that aren't decoded with
+// BTF.
+type displayKind int
+
+const (
+	displayHex      displayKind = iota // Space-separated hex bytes
+	displayHexdump                     // xxd-style lines with offsets and an ASCII column
+	displayUnsigned                    // Unsigned integers
+	displaySigned                      // Signed integers
+	displayString                      // Printable strings, with other bytes as hex
+)
+
+// displayMode is how the dump view renders the bytes of keys or values.
+type displayMode struct {
+	kind      displayKind
+	size      int  // Integer width in bytes
+	bigEndian bool // Integer byte order; little-endian otherwise
+}
+
+// displayModes are the modes cycled through, in order. Integer modes are
+// shown little-endian until the byte order is switched.
+var displayModes = []displayMode{
+	{kind: displayHex},
+	{kind: displayHexdump},
+	{kind: displayUnsigned, size: 1},
+	{kind: displayUnsigned, size: 2},
+	{kind: displayUnsigned, size: 4},
+	{kind: displayUnsigned, size: 8},
+	{kind: displaySigned, size: 1},
+	{kind: displaySigned, size: 2},
+	{kind: displaySigned, size: 4},
+	{kind: displaySigned, size: 8},
+	{kind: displayString},
+}
+
+// isInt returns true for the integer modes.
+func (d displayMode) isInt() bool {
+	return d.kind == displayUnsigned || d.kind == displaySigned
+}
+
+// next returns the mode after d, keeping its byte order.
+func (d displayMode) next() displayMode {
+	i := 0
+	for k, mode := range displayModes {
+		if mode.kind == d.kind && mode.size == d.size {
+			i = k
+		}
+	}
+	n := displayModes[(i+1)%len(displayModes)]
+	if n.isInt() {
+		n.bigEndian = d.bigEndian
+	}
+	return n
+}
+
+// swapped returns the mode with the other byte order. Only integer modes
+// have one.
+func (d displayMode) swapped() displayMode {
+	if d.isInt() {
+		d.bigEndian = !d.bigEndian
+	}
+	return d
+}
+
+// String returns the mode's name, as accepted by parseDisplayMode, e.g.
+// "hex", "xxd", "u32le", "s16be" or "string".
+func (d displayMode) String() string {
+	switch d.kind {
+	case displayHexdump:
+		return "xxd"
+	case displayString:
+		return "string"
+	case displayUnsigned, displaySigned:
+		sign := "u"
+		if d.kind == displaySigned {
+			sign = "s"
+		}
+		order := "le"
+		if d.bigEndian {
+			order = "be"
+		}
+		return fmt.Sprintf("%s%d%s", sign, d.size*8, order)
+	default:
+		return "hex"
+	}
+}
+
+// displayModeRE matches the names of integer modes, capturing the sign, the
+// width in bits and the byte order.
+var displayModeRE = regexp.MustCompile(`^([us])(8|16|32|64)(le|be)?$`)
+
+// parseDisplayMode parses a mode name: hex, xxd, string, or u or s followed
+// by 8, 16, 32 or 64 and optionally le or be, e.g. u32 or s16be.
+func parseDisplayMode(s string) (displayMode, error) {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case "hex":
+		return displayMode{kind: displayHex}, nil
+	case "xxd", "hexdump":
+		return displayMode{kind: displayHexdump}, nil
+	case "string", "str":
+		return displayMode{kind: displayString}, nil
+	}
+	sm := displayModeRE.FindStringSubmatch(s)
+	if sm == nil {
+		return displayMode{}, fmt.Errorf("unknown display mode %q (modes: hex, xxd, string, u8-u64, s8-s64 with le or be)", s)
+	}
+	bits, _ := strconv.Atoi(sm[2])
+	d := displayMode{kind: displayUnsigned, size: bits / 8, bigEndian: sm[3] == "be"}
+	if sm[1] == "s" {
+		d.kind = displaySigned
+	}
+	return d, nil
+}
+
+// format renders data in the mode.
+func (d displayMode) format(data []byte) string {
+	if len(data) == 0 {
+		return "(empty)"
+	}
+	switch d.kind {
+	case displayHexdump:
+		return formatHexdump(data)
+	case displayUnsigned, displaySigned:
+		return d.formatInts(data)
+	case displayString:
+		return formatStrings(data)
+	default:
+		return FormatHex(data)
+	}
+}
+
+// formatInts renders data as space-separated integers of the mode's width.
+// Bytes left over at the end are shown as hex after a +.
+func (d displayMode) formatInts(data []byte) string {
+	var order binary.ByteOrder = binary.LittleEndian
+	if d.bigEndian {
+		order = binary.BigEndian
+	}
+
+	var parts []string
+	for len(data) >= d.size {
+		chunk := data[:d.size]
+		data = data[d.size:]
+
+		var v uint64
+		switch d.size {
+		case 1:
+			v = uint64(chunk[0])
+		case 2:
+			v = uint64(order.Uint16(chunk))
+		case 4:
+			v = uint64(order.Uint32(chunk))
+		default:
+			v = order.Uint64(chunk)
+		}
+		if d.kind == displaySigned {
+			// Sign-extend from the mode's width
+			shift := 64 - d.size*8
+			parts = append(parts, strconv.FormatInt(int64(v<<shift)>>shift, 10))
+		} else {
+			parts = append(parts, strconv.FormatUint(v, 10))
+		}
+	}
+	if len(data) > 0 {
+		parts = append(parts, "+ "+FormatHex(data))
+	}
+	return strings.Join(parts, " ")
+}
+
+// hexdumpWidth is the number of bytes per line of hexdumps.
+const hexdumpWidth = 16
+
+// formatHexdump renders data like xxd: lines of 16 bytes with their offset,
+// the bytes in hex in pairs, and the printable ones as ASCII.
+func formatHexdump(data []byte) string {
+	var lines []string
+	for off := 0; off < len(data); off += hexdumpWidth {
+		row := data[off:min(off+hexdumpWidth, len(data))]
+
+		var hexPart, ascii strings.Builder
+		for i, c := range row {
+			if i > 0 && i%2 == 0 {
+				hexPart.WriteByte(' ')
+			}
+			fmt.Fprintf(&hexPart, "%02x", c)
+			if isPrintableByte(c) {
+				ascii.WriteByte(c)
+			} else {
+				ascii.WriteByte('.')
+			}
+		}
+
+		// Pad short last lines so the ASCII column lines up
+		full := hexdumpWidth*2 + hexdumpWidth/2 - 1
+		if len(data) < hexdumpWidth {
+			full = len(data)*2 + (len(data)-1)/2
+		}
+		lines = append(lines, fmt.Sprintf("%08x: %-*s  %s", off, full, hexPart.String(), ascii.String()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Shortest runs of printable bytes shown as strings: anywhere, and for
+// NUL-terminated strings at the start.
+const (
+	minStringLen  = 4
+	minCStringLen = 2
+)
+
+// formatStrings renders data with runs of printable bytes as quoted strings
+// and other bytes as hex, like strings(1) does. NUL padding after the last
+// string is left out, as in fixed-size char arrays.
+func formatStrings(data []byte) string {
+	var parts []string
+	for i := 0; i < len(data); {
+		j := i
+		for j < len(data) && isPrintableByte(data[j]) {
+			j++
+		}
+		cString := i == 0 && j >= minCStringLen && (j == len(data) || data[j] == 0)
+		if j-i < minStringLen && !cString {
+			parts = append(parts, fmt.Sprintf("%02x", data[i]))
+			i++
+			continue
+		}
+
+		parts = append(parts, strconv.Quote(string(data[i:j])))
+		i = j
+		pad := i
+		for pad < len(data) && data[pad] == 0 {
+			pad++
+		}
+		if pad == len(data) {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// isPrintableByte returns true for printable ASCII characters.
+func isPrintableByte(c byte) bool {
+	return c >= 0x20 && c < 0x7f
+}
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseDisplayMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    displayMode
+		wantErr bool
+	}{
+		{"hex", displayMode{kind: displayHex}, false},
+		{"xxd", displayMode{kind: displayHexdump}, false},
+		{"String", displayMode{kind: displayString}, false},
+		{"u32", displayMode{kind: displayUnsigned, size: 4}, false},
+		{"s16be", displayMode{kind: displaySigned, size: 2, bigEndian: true}, false},
+		{"u64le", displayMode{kind: displayUnsigned, size: 8}, false},
+		{"u24", displayMode{}, true},
+		{"float", displayMode{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDisplayMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseDisplayMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseDisplayMode(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	// Names round-trip
+	for _, d := range displayModes {
+		for _, d := range []displayMode{d, d.swapped()} {
+			if got, err := parseDisplayMode(d.String()); err != nil || got != d {
+				t.Errorf("parseDisplayMode(%q) = %+v, %v; want %+v", d.String(), got, err, d)
+			}
+		}
+	}
+}
+
+func TestDisplayModeNext(t *testing.T) {
+	d := displayMode{}
+	var names []string
+	for range displayModes {
+		names = append(names, d.String())
+		d = d.next()
+	}
+	want := "hex xxd u8le u16le u32le u64le s8le s16le s32le s64le string"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("modes cycle as %q, want %q", got, want)
+	}
+	if d != (displayMode{}) {
+		t.Errorf("cycle should wrap around to hex, got %s", d)
+	}
+
+	// Byte order is kept while cycling
+	d = displayMode{kind: displayUnsigned, size: 2}.swapped().next()
+	if d.String() != "u32be" {
+		t.Errorf("next of u16be = %s, want u32be", d)
+	}
+	if got := (displayMode{kind: displayString}).swapped(); got.String() != "string" {
+		t.Errorf("swapping a non-integer mode should do nothing, got %s", got)
+	}
+}
+
+func TestDisplayModeFormat(t *testing.T) {
+	tests := []struct {
+		mode string
+		data []byte
+		want string
+	}{
+		{"hex", []byte{0x0a, 0x0b}, "0a 0b"},
+		{"u8", []byte{1, 255}, "1 255"},
+		{"s8", []byte{1, 255}, "1 -1"},
+		{"u16le", []byte{0x50, 0x00, 0x01, 0x00}, "80 1"},
+		{"u16be", []byte{0x00, 0x50}, "80"},
+		{"s32", []byte{0xfe, 0xff, 0xff, 0xff}, "-2"},
+		{"u32be", []byte{0x0a, 0, 0, 1}, "167772161"},
+		{"u64", []byte{1, 0, 0, 0, 0, 0, 0, 0}, "1"},
+		{"u32", []byte{1, 0, 0, 0, 0xaa, 0xbb}, "1 + aa bb"},
+		{"string", []byte("eth0\x00\x00\x00\x00"), `"eth0"`},
+		{"string", []byte("lo\x00\x00\x00\x00\x00\x00"), `"lo"`},
+		{"string", []byte{0x2a, 0, 0, 0, 's', 's', 'h', 'd', 0, 0}, `2a 00 00 00 "sshd"`},
+		{"string", []byte("ab\x00\x01cd"), `"ab" 00 01 63 64`},
+		{"string", []byte{0, 0}, "00 00"},
+		{"u32", nil, "(empty)"},
+	}
+	for _, tt := range tests {
+		d, err := parseDisplayMode(tt.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.format(tt.data); got != tt.want {
+			t.Errorf("%s format(% x) = %q, want %q", tt.mode, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHexdump(t *testing.T) {
+	data := []byte("0123456789abcdef\x00\x01xy")
+	want := "00000000: 3031 3233 3435 3637 3839 6162 6364 6566  0123456789abcdef\n" +
+		"00000010: 0001 7879                                ..xy"
+	if got := formatHexdump(data); got != want {
+		t.Errorf("formatHexdump() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Short data isn't padded
+	if got := formatHexdump([]byte{1, 0, 0}); got != "00000000: 0100 00  ..." {
+		t.Errorf("formatHexdump() = %q", got)
+	}
+}
+
+func TestMapDumpModel_DisplayModes(t *testing.T) {
+	m := newMapDumpModel(80, 40)
+	m.StartLoading(1, "names")
+	m.SetMapDump(1, "names", []MapEntry{
+		{Key: []byte{0x2a, 0, 0, 0}, Value: []byte("eth0\x00\x00\x00\x00")},
+	})
+
+	// M cycles the key mode, m the value mode
+	for range 4 {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	}
+	for range len(displayModes) - 1 {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	}
+	key, value := m.GetModes()
+	if key.String() != "u32le" || value.String() != "string" {
+		t.Fatalf("modes = %s, %s; want u32le, string", key, value)
+	}
+	content := m.renderContent()
+	if !strings.Contains(content, " 42\n") || !strings.Contains(content, ` "eth0"`) {
+		t.Errorf("entries should be shown in the chosen modes, got:\n%s", content)
+	}
+	if !strings.Contains(m.View(), "key u32le, value string") {
+		t.Errorf("title should show the modes, got:\n%s", m.View())
+	}
+
+	// O switches the key's byte order
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if !strings.Contains(m.renderContent(), " 704643072\n") {
+		t.Errorf("key should be big-endian, got:\n%s", m.renderContent())
+	}
+
+	// Modes are remembered per map
+	m.StartLoading(2, "other")
+	if key, value := m.GetModes(); key != (displayMode{}) || value != (displayMode{}) {
+		t.Errorf("another map should start in hex, got %s, %s", key, value)
+	}
+	m.StartLoading(1, "names")
+	if key, value := m.GetModes(); key.String() != "u32be" || value.String() != "string" {
+		t.Errorf("modes should be restored for the map, got %s, %s", key, value)
+	}
+}
+
+func TestMapDumpModel_DisplayModeSwitchesToRaw(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	m.SetMapDump(1, "test_map", []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{2, 0, 0, 0}}})
+	m.SetBTF(&MapBTF{Key: &BTFType{Kind: BTFKindInt, Size: 4}, Value: &BTFType{Kind: BTFKindInt, Size: 4}})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if !m.IsShowingRaw() {
+		t.Error("choosing a display mode should show raw output")
+	}
+	if !strings.Contains(m.renderContent(), "00000000: 0200 0000  ....") {
+		t.Errorf("value should be shown as a hexdump, got:\n%s", m.renderContent())
+	}
+}
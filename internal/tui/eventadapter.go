@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
+)
+
+// perfPagesPerCPU is the size of the buffer each CPU writes perf event
+// array records to, in pages.
+const perfPagesPerCPU = 16
+
+// Tail starts reading the records written to a ring buffer or perf event
+// array. Ring buffer records are consumed from the buffer shared with the
+// map's other readers. For perf event arrays, the map's entries are pointed
+// at bpftui's own buffers, replacing the owner's; closing the reader removes
+// them but doesn't put the owner's back.
+func (a *MapsServiceAdapter) Tail(id uint32) (MapEventReader, error) {
+	m, err := ebpf.NewMapFromID(ebpf.MapID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map by ID %d: %w", id, err)
+	}
+
+	r := &eventReader{m: m}
+	switch m.Type() {
+	case ebpf.RingBuf:
+		r.ring, err = ringbuf.NewReader(m)
+	case ebpf.PerfEventArray:
+		r.perf, err = perf.NewReader(m, perfPagesPerCPU*os.Getpagesize())
+	default:
+		err = fmt.Errorf("%s maps have no records to tail", m.Type())
+	}
+	if err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to read map %d: %w", id, err)
+	}
+	return r, nil
+}
+
+// eventReader reads a ring buffer or perf event array.
+type eventReader struct {
+	m    *ebpf.Map
+	ring *ringbuf.Reader // Set for ring buffers
+	perf *perf.Reader    // Set for perf event arrays
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Read waits up to timeout for records and returns at most n.
+func (r *eventReader) Read(n int, timeout time.Duration) ([]MapEvent, error) {
+	deadline := time.Now().Add(timeout)
+	if r.ring != nil {
+		r.ring.SetDeadline(deadline)
+	} else {
+		r.perf.SetDeadline(deadline)
+	}
+
+	var events []MapEvent
+	for len(events) < n {
+		event, err := r.next()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// next reads a single record.
+func (r *eventReader) next() (MapEvent, error) {
+	if r.ring != nil {
+		rec, err := r.ring.Read()
+		if err != nil {
+			return MapEvent{}, err
+		}
+		return MapEvent{Time: time.Now(), CPU: -1, Data: rec.RawSample}, nil
+	}
+
+	rec, err := r.perf.Read()
+	if err != nil {
+		return MapEvent{}, err
+	}
+	return MapEvent{Time: time.Now(), CPU: rec.CPU, Data: rec.RawSample, Lost: rec.LostSamples}, nil
+}
+
+// Close stops reading and releases the map. For perf event arrays, this
+// removes bpftui's buffers from the map.
+func (r *eventReader) Close() error {
+	r.closeOnce.Do(func() {
+		if r.ring != nil {
+			r.closeErr = r.ring.Close()
+		} else {
+			r.closeErr = r.perf.Close()
+		}
+		r.closeErr = errors.Join(r.closeErr, r.m.Close())
+	})
+	return r.closeErr
+}
+
+// EventType looks up the type named name to decode a map's records with.
+// Ring buffers and perf event arrays have no value type, so the type is
+// looked up in the BTF of the map, if any, and of the programs using it.
+func (a *MapsServiceAdapter) EventType(id uint32, name string) (*BTFType, error) {
+	for _, btfID := range eventBTFIDs(ebpf.MapID(id)) {
+		spec, err := loadBTFSpec(btfID)
+		if err != nil {
+			continue
+		}
+		types, err := spec.AnyTypesByName(name)
+		if err != nil {
+			continue
+		}
+		for _, t := range types {
+			// Skip functions, variables and forward declarations
+			if size, err := btf.Sizeof(t); err == nil && size > 0 {
+				return newBTFConverter().convert(t), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no type named %q in the BTF of map %d or the programs using it", name, id)
+}
+
+// eventBTFIDs returns the BTF objects of a map and of the programs using it.
+// This is best effort: objects that can't be opened are left out.
+func eventBTFIDs(id ebpf.MapID) []btf.ID {
+	var ids []btf.ID
+	add := func(btfID btf.ID) {
+		if !slices.Contains(ids, btfID) {
+			ids = append(ids, btfID)
+		}
+	}
+
+	if m, err := ebpf.NewMapFromID(id); err == nil {
+		if info, err := m.Info(); err == nil {
+			if btfID, ok := info.BTFID(); ok {
+				add(btfID)
+			}
+		}
+		m.Close()
+	}
+
+	for progID := ebpf.ProgramID(0); ; {
+		next, err := ebpf.ProgramGetNextID(progID)
+		if err != nil {
+			break
+		}
+		progID = next
+		prog, err := ebpf.NewProgramFromID(progID)
+		if err != nil {
+			continue
+		}
+		if info, err := prog.Info(); err == nil {
+			mapIDs, _ := info.MapIDs()
+			if btfID, ok := info.BTFID(); ok && slices.Contains(mapIDs, id) {
+				add(btfID)
+			}
+		}
+		prog.Close()
+	}
+	return ids
+}
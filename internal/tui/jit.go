@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/arch/arm64/arm64asm"
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// jitLine is one line of a JIT disassembly listing: either a function
+// header or a native instruction.
+type jitLine struct {
+	funcName string // Set on function headers only
+	offset   uint32 // Offset of the instruction from the start of its function
+	text     string // Disassembled instruction
+}
+
+// disassembleJIT decodes a JIT image into a listing with a header at the
+// start of each function. Undecodable bytes are emitted as data directives
+// so that decoding can resume with the next instruction.
+func disassembleJIT(img *JITImage) ([]jitLine, error) {
+	var decode func(code []byte, pc uint64, symbol func(uint64) string) (string, int)
+	switch img.Arch {
+	case "amd64":
+		decode = decodeX86
+	case "arm64":
+		decode = decodeARM64
+	default:
+		return nil, fmt.Errorf("JIT disassembly is not supported on %s", img.Arch)
+	}
+
+	var lines []jitLine
+	start := 0
+	for _, fn := range img.Funcs {
+		end := min(start+int(fn.Len), len(img.Code))
+		lines = append(lines, jitLine{funcName: fn.Name})
+
+		code := img.Code[start:end]
+		for off := 0; off < len(code); {
+			text, n := decode(code[off:], fn.Addr+uint64(off), img.Symbol)
+			lines = append(lines, jitLine{offset: uint32(off), text: text})
+			off += n
+		}
+		start = end
+	}
+	return lines, nil
+}
+
+// decodeX86 decodes one x86-64 instruction in AT&T syntax, as objdump and
+// bpftool print it. Returns the text and the instruction's length.
+func decodeX86(code []byte, pc uint64, symbol func(uint64) string) (string, int) {
+	inst, err := x86asm.Decode(code, 64)
+	if err != nil {
+		return fmt.Sprintf(".byte 0x%02x", code[0]), 1
+	}
+
+	var lookup x86asm.SymLookup
+	if symbol != nil && pc != 0 {
+		lookup = func(addr uint64) (string, uint64) {
+			if name := symbol(addr); name != "" {
+				return name, addr
+			}
+			return "", 0
+		}
+	}
+	return x86asm.GNUSyntax(inst, pc, lookup), inst.Len
+}
+
+// decodeARM64 decodes one fixed-width arm64 instruction in GNU syntax.
+// Returns the text and the instruction's length.
+func decodeARM64(code []byte, _ uint64, _ func(uint64) string) (string, int) {
+	if len(code) < 4 {
+		return fmt.Sprintf(".byte 0x%02x", code[0]), 1
+	}
+	inst, err := arm64asm.Decode(code[:4])
+	if err != nil {
+		return fmt.Sprintf(".inst 0x%08x", binary.LittleEndian.Uint32(code)), 4
+	}
+	return arm64asm.GNUSyntax(inst), 4
+}
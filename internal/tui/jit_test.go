@@ -0,0 +1,109 @@
+package tui
+
+import "testing"
+
+func TestDisassembleJIT_X86(t *testing.T) {
+	img := &JITImage{
+		Arch: "amd64",
+		Code: []byte{
+			0x0f, 0x1f, 0x44, 0x00, 0x00, // nopl
+			0x55,             // push %rbp
+			0x48, 0x89, 0xe5, // mov %rsp,%rbp
+			0xe8, 0x00, 0x01, 0x00, 0x00, // call rel32
+			0xc3, // ret
+			0xc3, // ret (second function)
+		},
+		Funcs: []JITFunc{
+			{Name: "xdp_prog", Addr: 0x1000, Len: 15},
+			{Name: "subprog", Addr: 0x2000, Len: 1},
+		},
+		Symbol: func(addr uint64) string {
+			if addr == 0x110e {
+				return "bpf_map_lookup_elem"
+			}
+			return ""
+		},
+	}
+
+	lines, err := disassembleJIT(img)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []jitLine{
+		{funcName: "xdp_prog"},
+		{offset: 0x0, text: "nopl (%rax,%rax)"},
+		{offset: 0x5, text: "push %rbp"},
+		{offset: 0x6, text: "mov %rsp,%rbp"},
+		{offset: 0x9, text: "callq bpf_map_lookup_elem"},
+		{offset: 0xe, text: "retq"},
+		{funcName: "subprog"},
+		{offset: 0x0, text: "retq"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %+v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestDisassembleJIT_ARM64(t *testing.T) {
+	img := &JITImage{
+		Arch: "arm64",
+		Code: []byte{
+			0xfd, 0x7b, 0xbf, 0xa9, // stp x29, x30, [sp,#-16]!
+			0xc0, 0x03, 0x5f, 0xd6, // ret
+			0x00, 0x00, 0x00, 0x00, // undefined
+			0x01, // truncated
+		},
+		Funcs: []JITFunc{{Name: "tc_prog", Len: 13}},
+	}
+
+	lines, err := disassembleJIT(img)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []jitLine{
+		{funcName: "tc_prog"},
+		{offset: 0x0, text: "stp x29, x30, [sp,#-16]!"},
+		{offset: 0x4, text: "ret"},
+		{offset: 0x8, text: ".inst 0x00000000"},
+		{offset: 0xc, text: ".byte 0x01"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %+v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestDisassembleJIT_FuncLensClamped(t *testing.T) {
+	img := &JITImage{
+		Arch:  "amd64",
+		Code:  []byte{0xc3},
+		Funcs: []JITFunc{{Name: "f", Len: 100}, {Name: "g", Len: 4}},
+	}
+
+	lines, err := disassembleJIT(img)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Lengths beyond the image must not panic; g is left empty
+	if len(lines) != 3 || lines[2].funcName != "g" {
+		t.Errorf("unexpected lines: %+v", lines)
+	}
+}
+
+func TestDisassembleJIT_UnsupportedArch(t *testing.T) {
+	_, err := disassembleJIT(&JITImage{Arch: "riscv64", Code: []byte{0x13}})
+	if err == nil || !containsString(err.Error(), "riscv64") {
+		t.Errorf("expected unsupported arch error, got %v", err)
+	}
+}
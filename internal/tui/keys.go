@@ -4,13 +4,22 @@ import "github.com/charmbracelet/bubbles/key"
 
 // keyMap defines all keyboard shortcuts for the TUI.
 type keyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Enter  key.Binding
-	Back   key.Binding
-	Quit   key.Binding
-	Search key.Binding
-	Help   key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Enter   key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+	Search  key.Binding
+	Help    key.Binding
+	Disasm  key.Binding
+	JIT     key.Binding
+	Stats   key.Binding
+	BTF     key.Binding
+	Owners  key.Binding
+	Sort    key.Binding
+	Order   key.Binding
+	Table   key.Binding
+	Columns key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
@@ -57,4 +66,40 @@ var defaultKeyMap = keyMap{
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
 	),
+	Disasm: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "disassemble"),
+	),
+	JIT: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "JIT code"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "enable run stats"),
+	),
+	BTF: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "BTF functions"),
+	),
+	Owners: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "group by process"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by next column"),
+	),
+	Order: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reverse sort order"),
+	),
+	Table: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "table layout"),
+	),
+	Columns: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "choose columns"),
+	),
 }
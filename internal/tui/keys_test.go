@@ -55,6 +55,48 @@ func TestDefaultKeyMapBindings(t *testing.T) {
 			wantKeys: []string{"?"},
 			wantHelp: "help",
 		},
+		{
+			name:     "Owners binding",
+			binding:  defaultKeyMap.Owners,
+			wantKeys: []string{"P"},
+			wantHelp: "group by process",
+		},
+		{
+			name:     "Sort binding",
+			binding:  defaultKeyMap.Sort,
+			wantKeys: []string{"s"},
+			wantHelp: "sort by next column",
+		},
+		{
+			name:     "Order binding",
+			binding:  defaultKeyMap.Order,
+			wantKeys: []string{"r"},
+			wantHelp: "reverse sort order",
+		},
+		{
+			name:     "Table binding",
+			binding:  defaultKeyMap.Table,
+			wantKeys: []string{"t"},
+			wantHelp: "table layout",
+		},
+		{
+			name:     "Columns binding",
+			binding:  defaultKeyMap.Columns,
+			wantKeys: []string{"c"},
+			wantHelp: "choose columns",
+		},
+		{
+			name:     "BTF binding",
+			binding:  defaultKeyMap.BTF,
+			wantKeys: []string{"B"},
+			wantHelp: "BTF functions",
+		},
+		{
+			name:     "Stats binding",
+			binding:  defaultKeyMap.Stats,
+			wantKeys: []string{"S"},
+			wantHelp: "enable run stats",
+		},
 	}
 
 	for _, tt := range tests {
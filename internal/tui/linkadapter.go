@@ -0,0 +1,365 @@
+package tui
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"net"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/ebpf/link"
+	"golang.org/x/sys/unix"
+)
+
+// LinkServiceAdapter implements LinkService on top of the kernel's link APIs.
+// Type-specific attach info is read from bpf_link_info directly, since
+// cilium/ebpf doesn't expose the names of probed functions and files.
+type LinkServiceAdapter struct{}
+
+// NewLinkServiceAdapter creates a new link service adapter.
+func NewLinkServiceAdapter() *LinkServiceAdapter {
+	return &LinkServiceAdapter{}
+}
+
+// List returns all BPF links. Links that disappear while being listed are skipped.
+func (a *LinkServiceAdapter) List() ([]LinkInfo, error) {
+	it := new(link.Iterator)
+	defer it.Close()
+
+	targets := newLinkTargets()
+	var links []LinkInfo
+	for it.Next() {
+		info, err := readLinkInfo(it.Link, targets)
+		if err != nil {
+			continue
+		}
+		links = append(links, info)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	return links, nil
+}
+
+// Get returns link info by ID.
+func (a *LinkServiceAdapter) Get(id uint32) (*LinkInfo, error) {
+	l, err := link.NewFromID(link.ID(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link by ID %d: %w", id, err)
+	}
+	defer l.Close()
+
+	info, err := readLinkInfo(l, newLinkTargets())
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Layout of struct bpf_link_info. The type-specific union starts at
+// linkInfoUnion; perf_event links nest a second union at perfEventUnion.
+const (
+	linkInfoSize   = 96
+	linkInfoUnion  = 16
+	perfEventUnion = linkInfoUnion + 8
+	linkNameSize   = 4096 // PATH_MAX, for names and paths copied out by the kernel
+)
+
+// Link types (enum bpf_link_type), in the names bpftool uses.
+var linkTypeNames = []string{
+	"unspec", "raw_tracepoint", "tracing", "cgroup", "iter", "netns", "xdp",
+	"perf_event", "kprobe_multi", "struct_ops", "netfilter", "tcx",
+	"uprobe_multi", "netkit", "sockmap",
+}
+
+// Attach types (enum bpf_attach_type), in the names bpftool uses.
+var attachTypeNames = []string{
+	"cgroup_inet_ingress", "cgroup_inet_egress", "cgroup_inet_sock_create",
+	"cgroup_sock_ops", "sk_skb_stream_parser", "sk_skb_stream_verdict",
+	"cgroup_device", "sk_msg_verdict", "cgroup_inet4_bind", "cgroup_inet6_bind",
+	"cgroup_inet4_connect", "cgroup_inet6_connect", "cgroup_inet4_post_bind",
+	"cgroup_inet6_post_bind", "cgroup_udp4_sendmsg", "cgroup_udp6_sendmsg",
+	"lirc_mode2", "flow_dissector", "cgroup_sysctl", "cgroup_udp4_recvmsg",
+	"cgroup_udp6_recvmsg", "cgroup_getsockopt", "cgroup_setsockopt",
+	"trace_raw_tp", "trace_fentry", "trace_fexit", "modify_return", "lsm_mac",
+	"trace_iter", "cgroup_inet4_getpeername", "cgroup_inet6_getpeername",
+	"cgroup_inet4_getsockname", "cgroup_inet6_getsockname", "xdp_devmap",
+	"cgroup_inet_sock_release", "xdp_cpumap", "sk_lookup", "xdp",
+	"sk_skb_verdict", "sk_reuseport_select", "sk_reuseport_select_or_migrate",
+	"perf_event", "trace_kprobe_multi", "lsm_cgroup", "struct_ops", "netfilter",
+	"tcx_ingress", "tcx_egress", "trace_uprobe_multi", "cgroup_unix_connect",
+	"cgroup_unix_sendmsg", "cgroup_unix_recvmsg", "cgroup_unix_getpeername",
+	"cgroup_unix_getsockname", "netkit_primary", "netkit_peer",
+	"trace_kprobe_session", "trace_uprobe_session",
+}
+
+// Perf event types (enum bpf_perf_event_type).
+var perfEventTypeNames = []string{
+	"unspec", "uprobe", "uretprobe", "kprobe", "kretprobe", "tracepoint", "event",
+}
+
+// Netfilter protocol families and hooks, as used by netfilter links.
+var (
+	nfProtoNames = map[uint32]string{1: "inet", 2: "ipv4", 3: "arp", 5: "netdev", 7: "bridge", 10: "ipv6"}
+	nfHookNames  = []string{"prerouting", "input", "forward", "output", "postrouting"}
+)
+
+// linkTypeName returns the name of a link type, or its number if unknown.
+func linkTypeName(t uint32) string {
+	if int(t) < len(linkTypeNames) {
+		return linkTypeNames[t]
+	}
+	return fmt.Sprintf("type %d", t)
+}
+
+// attachTypeName returns the name of an attach type, or its number if unknown.
+func attachTypeName(t uint32) string {
+	if int(t) < len(attachTypeNames) {
+		return attachTypeNames[t]
+	}
+	return fmt.Sprintf("attach type %d", t)
+}
+
+// enumName returns names[i], or i as a number if it's out of range.
+func enumName(names []string, i uint32) string {
+	if int(i) < len(names) {
+		return names[i]
+	}
+	return fmt.Sprintf("%d", i)
+}
+
+// linkTargets resolves kernel identifiers in link info to readable names.
+// Any resolver may return "" if the identifier can't be resolved.
+type linkTargets struct {
+	netdev  func(ifindex uint32) string
+	cgroup  func(id uint64) string
+	btfFunc func(objID, typeID uint32) string
+	symbol  func(addr uint64) string
+}
+
+// newLinkTargets returns resolvers backed by the running system. The cgroup
+// tree and kernel symbols are only read if a link needs them.
+func newLinkTargets() linkTargets {
+	var cgroups map[uint64]string
+	var syms *kallsyms
+	var symsLoaded bool
+
+	return linkTargets{
+		netdev: func(ifindex uint32) string {
+			iface, err := net.InterfaceByIndex(int(ifindex))
+			if err != nil {
+				return ""
+			}
+			return iface.Name
+		},
+		cgroup: func(id uint64) string {
+			if cgroups == nil {
+				cgroups = scanCgroups("/sys/fs/cgroup")
+			}
+			return cgroups[id]
+		},
+		btfFunc: btfTypeName,
+		symbol: func(addr uint64) string {
+			if !symsLoaded {
+				syms, _ = loadKallsyms()
+				symsLoaded = true
+			}
+			return syms.symbol(addr)
+		},
+	}
+}
+
+// scanCgroups maps cgroup IDs to paths by walking a cgroup2 hierarchy.
+// A cgroup's ID is the inode number of its directory.
+func scanCgroups(root string) map[uint64]string {
+	paths := make(map[uint64]string)
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); ok {
+			paths[st.Ino] = path
+		}
+		return nil
+	})
+	return paths
+}
+
+// btfTypeName returns the name of a type in the kernel or module BTF object
+// with the given ID, or "" if it can't be loaded.
+func btfTypeName(objID, typeID uint32) string {
+	h, err := btf.NewHandleFromID(btf.ID(objID))
+	if err != nil {
+		return ""
+	}
+	defer h.Close()
+
+	var base *btf.Spec
+	if info, err := h.Info(); err == nil && !info.IsVmlinux() {
+		if base, err = btf.LoadKernelSpec(); err != nil {
+			return ""
+		}
+	}
+	spec, err := h.Spec(base)
+	if err != nil {
+		return ""
+	}
+	typ, err := spec.TypeByID(btf.TypeID(typeID))
+	if err != nil {
+		return ""
+	}
+	return typ.TypeName()
+}
+
+// readLinkInfo reads and decodes a link's info. Links that report a name or
+// path take a second call with a buffer for the kernel to copy it into.
+func readLinkInfo(l link.Link, targets linkTargets) (LinkInfo, error) {
+	// Every link type wraps a RawLink, which exposes the fd
+	fdLink, ok := l.(interface{ FD() int })
+	if !ok {
+		return LinkInfo{}, fmt.Errorf("link has no file descriptor")
+	}
+	fd := fdLink.FD()
+
+	info := make([]byte, linkInfoSize)
+	if err := objGetInfoByFD(fd, unsafe.Pointer(&info[0]), uintptr(len(info))); err != nil {
+		return LinkInfo{}, fmt.Errorf("failed to get link info: %w", err)
+	}
+
+	var name string
+	if ptrOff, lenOff, ok := linkNameField(info); ok {
+		// Names are best effort; older kernels reject the buffer for some types
+		buf := make([]byte, linkNameSize)
+		withName := make([]byte, linkInfoSize)
+		binary.NativeEndian.PutUint64(withName[ptrOff:], uint64(uintptr(unsafe.Pointer(&buf[0]))))
+		binary.NativeEndian.PutUint32(withName[lenOff:], uint32(len(buf)))
+		err := objGetInfoByFD(fd, unsafe.Pointer(&withName[0]), uintptr(len(withName)))
+		runtime.KeepAlive(buf)
+		if err == nil {
+			info = withName
+			name = unix.ByteSliceToString(buf)
+		}
+	}
+
+	return decodeLinkInfo(info, name, targets), nil
+}
+
+// linkNameField returns the offsets of the name pointer and length fields for
+// link types whose info includes a name or path, based on a first read of info.
+func linkNameField(info []byte) (ptrOff, lenOff int, ok bool) {
+	switch linkTypeName(binary.NativeEndian.Uint32(info)) {
+	case "raw_tracepoint", "iter":
+		return linkInfoUnion, linkInfoUnion + 8, true
+	case "uprobe_multi":
+		return linkInfoUnion, linkInfoUnion + 32, true
+	case "perf_event":
+		switch enumName(perfEventTypeNames, binary.NativeEndian.Uint32(info[linkInfoUnion:])) {
+		case "uprobe", "uretprobe", "kprobe", "kretprobe", "tracepoint":
+			return perfEventUnion, perfEventUnion + 8, true
+		}
+	}
+	return 0, 0, false
+}
+
+// decodeLinkInfo decodes a bpf_link_info, with name holding the name or path
+// the kernel copied out for the link, if any.
+func decodeLinkInfo(info []byte, name string, targets linkTargets) LinkInfo {
+	if len(info) < linkInfoSize {
+		info = append(info, make([]byte, linkInfoSize-len(info))...)
+	}
+	u32 := func(off int) uint32 { return binary.NativeEndian.Uint32(info[off:]) }
+	u64 := func(off int) uint64 { return binary.NativeEndian.Uint64(info[off:]) }
+
+	l := LinkInfo{
+		Type:   linkTypeName(u32(0)),
+		ID:     u32(4),
+		ProgID: u32(8),
+	}
+
+	const u = linkInfoUnion
+	switch l.Type {
+	case "raw_tracepoint", "iter":
+		l.Target = name
+
+	case "tracing":
+		l.AttachType = attachTypeName(u32(u))
+		objID, typeID := u32(u+4), u32(u+8)
+		l.Target = cmp.Or(targets.btfFunc(objID, typeID), fmt.Sprintf("btf_id %d (obj %d)", typeID, objID))
+
+	case "cgroup":
+		id := u64(u)
+		l.AttachType = attachTypeName(u32(u + 8))
+		l.Target = cmp.Or(targets.cgroup(id), fmt.Sprintf("cgroup id %d", id))
+
+	case "netns":
+		l.AttachType = attachTypeName(u32(u + 4))
+		l.Target = fmt.Sprintf("net:[%d]", u32(u))
+
+	case "xdp":
+		l.Target = netdevName(u32(u), targets)
+
+	case "tcx", "netkit":
+		l.AttachType = attachTypeName(u32(u + 4))
+		l.Target = netdevName(u32(u), targets)
+
+	case "struct_ops":
+		l.Target = fmt.Sprintf("map %d", u32(u))
+
+	case "sockmap":
+		l.AttachType = attachTypeName(u32(u + 4))
+		l.Target = fmt.Sprintf("map %d", u32(u))
+
+	case "netfilter":
+		pf, hook, prio := u32(u), u32(u+4), int32(u32(u+8))
+		family, ok := nfProtoNames[pf]
+		if !ok {
+			family = fmt.Sprintf("pf %d", pf)
+		}
+		l.Target = fmt.Sprintf("%s %s prio %d", family, enumName(nfHookNames, hook), prio)
+
+	case "kprobe_multi":
+		l.Target = fmt.Sprintf("%d functions", u32(u+8))
+
+	case "uprobe_multi":
+		l.Target = fmt.Sprintf("%s (%d probes)", name, u32(u+36))
+		if pid := u32(u + 44); pid != 0 {
+			l.Target += fmt.Sprintf(" pid %d", pid)
+		}
+
+	case "perf_event":
+		const p = perfEventUnion
+		l.AttachType = enumName(perfEventTypeNames, u32(u))
+		switch l.AttachType {
+		case "kprobe", "kretprobe":
+			fn, offset, addr := name, u32(p+12), u64(p+16)
+			if fn == "" {
+				fn = cmp.Or(targets.symbol(addr), fmt.Sprintf("0x%x", addr))
+			}
+			l.Target = fn
+			if offset != 0 {
+				l.Target += fmt.Sprintf("+0x%x", offset)
+			}
+		case "uprobe", "uretprobe":
+			l.Target = fmt.Sprintf("%s:0x%x", name, u32(p+12))
+		case "tracepoint":
+			l.Target = name
+		case "event":
+			l.Target = fmt.Sprintf("type %d config 0x%x", u32(p+8), u64(p))
+		}
+	}
+	return l
+}
+
+// netdevName returns the name of the interface with the given index.
+func netdevName(ifindex uint32, targets linkTargets) string {
+	return cmp.Or(targets.netdev(ifindex), fmt.Sprintf("ifindex %d", ifindex))
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// testLinkInfo builds a bpf_link_info buffer with the given common fields.
+func testLinkInfo(linkType, id, progID uint32) []byte {
+	info := make([]byte, linkInfoSize)
+	binary.NativeEndian.PutUint32(info[0:], linkType)
+	binary.NativeEndian.PutUint32(info[4:], id)
+	binary.NativeEndian.PutUint32(info[8:], progID)
+	return info
+}
+
+// testLinkTargets resolves a fixed set of identifiers.
+var testLinkTargets = linkTargets{
+	netdev: func(ifindex uint32) string {
+		if ifindex == 2 {
+			return "eth0"
+		}
+		return ""
+	},
+	cgroup: func(id uint64) string {
+		if id == 7 {
+			return "/sys/fs/cgroup/system.slice"
+		}
+		return ""
+	},
+	btfFunc: func(objID, typeID uint32) string {
+		if objID == 1 && typeID == 100 {
+			return "tcp_connect"
+		}
+		return ""
+	},
+	symbol: func(addr uint64) string {
+		if addr == 0xffffffff81000000 {
+			return "do_sys_open"
+		}
+		return ""
+	},
+}
+
+func TestDecodeLinkInfo(t *testing.T) {
+	put32 := func(info []byte, off int, v uint32) { binary.NativeEndian.PutUint32(info[off:], v) }
+	put64 := func(info []byte, off int, v uint64) { binary.NativeEndian.PutUint64(info[off:], v) }
+	const u, p = linkInfoUnion, perfEventUnion
+
+	tests := []struct {
+		name       string
+		info       func() []byte
+		linkName   string
+		attachType string
+		target     string
+	}{
+		{
+			name: "cgroup",
+			info: func() []byte {
+				info := testLinkInfo(3, 1, 10)
+				put64(info, u, 7)
+				put32(info, u+8, 1) // cgroup_inet_egress
+				return info
+			},
+			attachType: "cgroup_inet_egress",
+			target:     "/sys/fs/cgroup/system.slice",
+		},
+		{
+			name: "cgroup unresolved",
+			info: func() []byte {
+				info := testLinkInfo(3, 1, 10)
+				put64(info, u, 8)
+				return info
+			},
+			attachType: "cgroup_inet_ingress",
+			target:     "cgroup id 8",
+		},
+		{
+			name: "tracing",
+			info: func() []byte {
+				info := testLinkInfo(2, 1, 10)
+				put32(info, u, 24) // trace_fentry
+				put32(info, u+4, 1)
+				put32(info, u+8, 100)
+				return info
+			},
+			attachType: "trace_fentry",
+			target:     "tcp_connect",
+		},
+		{
+			name: "xdp",
+			info: func() []byte {
+				info := testLinkInfo(6, 1, 10)
+				put32(info, u, 2)
+				return info
+			},
+			target: "eth0",
+		},
+		{
+			name: "tcx unresolved",
+			info: func() []byte {
+				info := testLinkInfo(11, 1, 10)
+				put32(info, u, 5)
+				put32(info, u+4, 46) // tcx_ingress
+				return info
+			},
+			attachType: "tcx_ingress",
+			target:     "ifindex 5",
+		},
+		{
+			name: "netfilter",
+			info: func() []byte {
+				info := testLinkInfo(10, 1, 10)
+				put32(info, u, 2)
+				put32(info, u+4, 0)
+				put32(info, u+8, uint32(0xffffff9c)) // -100
+				return info
+			},
+			target: "ipv4 prerouting prio -100",
+		},
+		{
+			name: "kprobe with name",
+			info: func() []byte {
+				info := testLinkInfo(7, 1, 10)
+				put32(info, u, 3) // kprobe
+				put32(info, p+12, 0x10)
+				return info
+			},
+			linkName:   "tcp_sendmsg",
+			attachType: "kprobe",
+			target:     "tcp_sendmsg+0x10",
+		},
+		{
+			name: "kprobe by address",
+			info: func() []byte {
+				info := testLinkInfo(7, 1, 10)
+				put32(info, u, 4) // kretprobe
+				put64(info, p+16, 0xffffffff81000000)
+				return info
+			},
+			attachType: "kretprobe",
+			target:     "do_sys_open",
+		},
+		{
+			name: "uprobe",
+			info: func() []byte {
+				info := testLinkInfo(7, 1, 10)
+				put32(info, u, 1) // uprobe
+				put32(info, p+12, 0x1234)
+				return info
+			},
+			linkName:   "/usr/lib/libc.so.6",
+			attachType: "uprobe",
+			target:     "/usr/lib/libc.so.6:0x1234",
+		},
+		{
+			name: "uprobe_multi",
+			info: func() []byte {
+				info := testLinkInfo(12, 1, 10)
+				put32(info, u+36, 3)
+				put32(info, u+44, 42)
+				return info
+			},
+			linkName: "/bin/bash",
+			target:   "/bin/bash (3 probes) pid 42",
+		},
+		{
+			name: "raw_tracepoint",
+			info: func() []byte {
+				return testLinkInfo(1, 1, 10)
+			},
+			linkName: "sched_switch",
+			target:   "sched_switch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := decodeLinkInfo(tt.info(), tt.linkName, testLinkTargets)
+			if l.ID != 1 || l.ProgID != 10 {
+				t.Errorf("ID/ProgID = %d/%d, want 1/10", l.ID, l.ProgID)
+			}
+			if l.AttachType != tt.attachType {
+				t.Errorf("AttachType = %q, want %q", l.AttachType, tt.attachType)
+			}
+			if l.Target != tt.target {
+				t.Errorf("Target = %q, want %q", l.Target, tt.target)
+			}
+		})
+	}
+}
+
+func TestDecodeLinkInfoUnknownType(t *testing.T) {
+	l := decodeLinkInfo(testLinkInfo(99, 3, 0)[:16], "", testLinkTargets)
+	if l.Type != "type 99" || l.ID != 3 || l.Target != "" {
+		t.Errorf("unexpected link %+v", l)
+	}
+}
+
+func TestLinkNameField(t *testing.T) {
+	perf := func(perfType uint32) []byte {
+		info := testLinkInfo(7, 1, 10)
+		binary.NativeEndian.PutUint32(info[linkInfoUnion:], perfType)
+		return info
+	}
+
+	tests := []struct {
+		name   string
+		info   []byte
+		ptrOff int
+		lenOff int
+		ok     bool
+	}{
+		{"raw_tracepoint", testLinkInfo(1, 1, 10), linkInfoUnion, linkInfoUnion + 8, true},
+		{"uprobe_multi", testLinkInfo(12, 1, 10), linkInfoUnion, linkInfoUnion + 32, true},
+		{"perf kprobe", perf(3), perfEventUnion, perfEventUnion + 8, true},
+		{"perf event", perf(6), 0, 0, false},
+		{"cgroup", testLinkInfo(3, 1, 10), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ptrOff, lenOff, ok := linkNameField(tt.info)
+			if ok != tt.ok || (ok && (ptrOff != tt.ptrOff || lenOff != tt.lenOff)) {
+				t.Errorf("linkNameField() = %d, %d, %v, want %d, %d, %v",
+					ptrOff, lenOff, ok, tt.ptrOff, tt.lenOff, tt.ok)
+			}
+		})
+	}
+}
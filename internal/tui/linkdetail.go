@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkDetailModel manages the link detail view state.
+type linkDetailModel struct {
+	link     *LinkInfo
+	viewport viewport.Model
+	width    int
+	height   int
+	ready    bool
+	loading  bool // Fetching the link by ID, e.g. when navigating from a pin
+	spinner  spinner.Model
+}
+
+// newLinkDetailModel creates a new link detail model.
+func newLinkDetailModel(width, height int) linkDetailModel {
+	return linkDetailModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+	}
+}
+
+// SetLink sets the link to display.
+func (m *linkDetailModel) SetLink(link *LinkInfo) {
+	m.link = link
+	m.loading = false
+	m.updateViewport()
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the previous link is cleared and the returned
+// command starts the spinner.
+func (m *linkDetailModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.link = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the viewport dimensions.
+func (m *linkDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if m.ready {
+		m.viewport.Width = width
+		m.viewport.Height = height - 4 // Leave room for title and help bar
+	}
+	m.updateViewport()
+}
+
+// updateViewport refreshes the viewport content.
+func (m *linkDetailModel) updateViewport() {
+	if m.link == nil {
+		return
+	}
+
+	content := m.renderContent()
+
+	if !m.ready {
+		m.viewport = viewport.New(m.width, m.height-4)
+		m.viewport.SetContent(content)
+		m.ready = true
+	} else {
+		m.viewport.SetContent(content)
+	}
+}
+
+// linkTargetLabel returns the label describing what a link attaches to.
+func linkTargetLabel(l *LinkInfo) string {
+	switch l.Type {
+	case "cgroup":
+		return "Cgroup:"
+	case "xdp", "tcx", "netkit":
+		return "Netdev:"
+	case "kprobe_multi":
+		return "Functions:"
+	case "uprobe_multi":
+		return "Uprobe:"
+	case "raw_tracepoint":
+		return "Tracepoint:"
+	case "netns":
+		return "Netns:"
+	case "netfilter":
+		return "Hook:"
+	case "iter":
+		return "Iterator:"
+	case "struct_ops", "sockmap":
+		return "Map:"
+	case "perf_event":
+		switch l.AttachType {
+		case "kprobe", "kretprobe":
+			return "Function:"
+		case "uprobe", "uretprobe":
+			return "Uprobe:"
+		case "tracepoint":
+			return "Tracepoint:"
+		case "event":
+			return "Event:"
+		}
+	}
+	return "Target:"
+}
+
+// renderContent generates the detail view content.
+func (m *linkDetailModel) renderContent() string {
+	if m.link == nil {
+		return dimStyle.Render("No link selected")
+	}
+
+	var b strings.Builder
+	l := m.link
+
+	b.WriteString(labelStyle.Render("ID:          "))
+	b.WriteString(valueStyle.Render(fmt.Sprintf("%d", l.ID)))
+	b.WriteString("\n")
+
+	b.WriteString(labelStyle.Render("Type:        "))
+	b.WriteString(valueStyle.Render(l.Type))
+	b.WriteString("\n")
+
+	if l.AttachType != "" {
+		b.WriteString(labelStyle.Render("Attach Type: "))
+		b.WriteString(valueStyle.Render(l.AttachType))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(labelStyle.Render(linkTargetLabel(l)))
+	if l.Target != "" {
+		b.WriteString(valueStyle.Render(l.Target))
+	} else {
+		b.WriteString(dimStyle.Render("Unknown"))
+	}
+	b.WriteString("\n")
+
+	// Attached program section
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("Attached Program"))
+	b.WriteString("\n")
+	if l.ProgID == 0 {
+		b.WriteString(dimStyle.Render("No program attached"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(selectedStyle.Render(fmt.Sprintf("▶ Program ID: %d", l.ProgID)))
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render("Press Enter to view program details"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Init implements tea.Model for linkDetailModel.
+func (m linkDetailModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the link detail view.
+// Returns the updated model, an optional command, and the attached program's ID if Enter was pressed.
+func (m linkDetailModel) Update(msg tea.Msg) (linkDetailModel, tea.Cmd, *uint32) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+		if m.link != nil && m.link.ProgID != 0 {
+			progID := m.link.ProgID
+			return m, nil, &progID
+		}
+		return m, nil, nil
+	}
+
+	// Handle viewport scrolling for other keys
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd, nil
+}
+
+// View renders the link detail view.
+func (m linkDetailModel) View() string {
+	if m.loading {
+		return titleStyle.Render("Link Details") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading link...")
+	}
+
+	if m.link == nil {
+		return titleStyle.Render("Link Details") + "\n\n" +
+			dimStyle.Render("No link selected")
+	}
+
+	title := titleStyle.Render(fmt.Sprintf("Link: [%d] %s", m.link.ID, m.link.Type))
+
+	if !m.ready {
+		return title + "\n\nLoading..."
+	}
+
+	return title + "\n\n" + m.viewport.View()
+}
+
+// IsLoading returns true if the link is being loaded.
+func (m linkDetailModel) IsLoading() bool {
+	return m.loading
+}
+
+// GetLink returns the currently displayed link.
+func (m linkDetailModel) GetLink() *LinkInfo {
+	return m.link
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLinkDetailModel_ViewNoLink(t *testing.T) {
+	m := newLinkDetailModel(80, 24)
+	if view := m.View(); !strings.Contains(view, "No link selected") {
+		t.Errorf("view should say no link is selected, got %q", view)
+	}
+}
+
+func TestLinkDetailModel_ViewRendersFields(t *testing.T) {
+	m := newLinkDetailModel(80, 24)
+	m.SetLink(&LinkInfo{ID: 5, Type: "cgroup", ProgID: 42, AttachType: "cgroup_inet_egress", Target: "/sys/fs/cgroup/system.slice"})
+
+	view := m.View()
+	for _, want := range []string{
+		"Link: [5] cgroup",
+		"Attach Type:", "cgroup_inet_egress",
+		"Cgroup:", "/sys/fs/cgroup/system.slice",
+		"Program ID: 42",
+		"Press Enter to view program details",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestLinkDetailModel_NoProgram(t *testing.T) {
+	m := newLinkDetailModel(80, 24)
+	m.SetLink(&LinkInfo{ID: 5, Type: "struct_ops", Target: "map 3"})
+
+	if view := m.View(); !strings.Contains(view, "No program attached") {
+		t.Errorf("view should say no program is attached, got %q", view)
+	}
+	if _, _, progID := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); progID != nil {
+		t.Errorf("Enter should not select a program, got %d", *progID)
+	}
+}
+
+func TestLinkDetailModel_EnterSelectsProgram(t *testing.T) {
+	m := newLinkDetailModel(80, 24)
+	m.SetLink(&LinkInfo{ID: 5, Type: "xdp", ProgID: 42, Target: "eth0"})
+
+	_, _, progID := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if progID == nil || *progID != 42 {
+		t.Fatalf("expected program ID 42, got %v", progID)
+	}
+}
+
+func TestLinkTargetLabel(t *testing.T) {
+	tests := []struct {
+		link LinkInfo
+		want string
+	}{
+		{LinkInfo{Type: "cgroup"}, "Cgroup:"},
+		{LinkInfo{Type: "tcx"}, "Netdev:"},
+		{LinkInfo{Type: "tracing"}, "Target:"},
+		{LinkInfo{Type: "perf_event", AttachType: "kretprobe"}, "Function:"},
+		{LinkInfo{Type: "perf_event", AttachType: "uprobe"}, "Uprobe:"},
+		{LinkInfo{Type: "perf_event", AttachType: "event"}, "Event:"},
+	}
+
+	for _, tt := range tests {
+		if got := linkTargetLabel(&tt.link); got != tt.want {
+			t.Errorf("linkTargetLabel(%s/%s) = %q, want %q", tt.link.Type, tt.link.AttachType, got, tt.want)
+		}
+	}
+}
+
+func TestLinkDetailModel_Loading(t *testing.T) {
+	m := newLinkDetailModel(80, 24)
+	m.SetLink(&LinkInfo{ID: 5, Type: "xdp"})
+
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if m.GetLink() != nil {
+		t.Error("loading should clear the previous link")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading link...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetLink(&LinkInfo{ID: 6, Type: "tcx"})
+	if m.IsLoading() || !strings.Contains(m.View(), "Link: [6] tcx") {
+		t.Error("setting a link should end loading")
+	}
+}
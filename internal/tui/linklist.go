@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkItem represents a BPF link in the list.
+type linkItem struct {
+	info   LinkInfo
+	status itemStatus // Change since the previous refresh
+}
+
+// FilterValue implements list.Item interface for fuzzy filtering.
+// Links have no name, so they're matched on type and target.
+func (i linkItem) FilterValue() string { return i.info.Type + " " + i.info.Target }
+
+// Title returns the link title for display (ID and Type),
+// prefixed with a marker if it appeared or disappeared since the last refresh.
+func (i linkItem) Title() string {
+	return i.status.marker() + fmt.Sprintf("[%d] %s", i.info.ID, i.info.Type)
+}
+
+// Description returns the link description for display (Program, AttachType and Target).
+func (i linkItem) Description() string {
+	desc := fmt.Sprintf("Prog: %d", i.info.ProgID)
+	if i.info.AttachType != "" {
+		desc += " | " + i.info.AttachType
+	}
+	if i.info.Target != "" {
+		desc += " | " + i.info.Target
+	}
+	return desc
+}
+
+// linkListModel manages the links list state.
+type linkListModel struct {
+	list    list.Model
+	links   []LinkInfo
+	err     error
+	loading bool
+	spinner spinner.Model
+}
+
+// newLinkListModel creates a new links list model.
+func newLinkListModel(width, height int) linkListModel {
+	// Create delegate for custom item rendering
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = selectedStyle
+	delegate.Styles.SelectedDesc = selectedStyle.Foreground(dimStyle.GetForeground())
+
+	// Calculate list dimensions (leave room for title and help bar)
+	listHeight := height - 6
+	if listHeight < 3 {
+		listHeight = 3
+	}
+
+	l := list.New([]list.Item{}, delegate, width, listHeight)
+	l.Title = "BPF Links"
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+	l.Styles.Title = titleStyle
+
+	return linkListModel{
+		list:    l,
+		links:   []LinkInfo{},
+		spinner: newSpinner(),
+	}
+}
+
+// Init implements tea.Model for linkListModel.
+func (m linkListModel) Init() tea.Cmd {
+	return nil
+}
+
+// SetLinks updates the list with new link data.
+func (m *linkListModel) SetLinks(links []LinkInfo) {
+	m.links = links
+	m.loading = false
+	m.err = nil
+	items := make([]list.Item, len(links))
+	for i, linkInfo := range links {
+		items[i] = linkItem{info: linkInfo}
+	}
+	m.list.Title = "BPF Links"
+	m.list.SetItems(items)
+}
+
+// RefreshLinks replaces the list with freshly polled data while preserving the
+// cursor and any active filter. Items that appeared since the previous poll
+// are marked as added; items that disappeared are kept until the next poll
+// and marked as removed.
+func (m *linkListModel) RefreshLinks(links []LinkInfo) tea.Cmd {
+	var selectedID *uint32
+	if item, ok := m.list.SelectedItem().(linkItem); ok {
+		selectedID = &item.info.ID
+	}
+
+	prevIDs := make([]uint32, len(m.links))
+	prev := make(map[uint32]LinkInfo, len(m.links))
+	for i, p := range m.links {
+		prevIDs[i] = p.ID
+		prev[p.ID] = p
+	}
+	currIDs := make([]uint32, len(links))
+	for i, p := range links {
+		currIDs[i] = p.ID
+	}
+	added, removed := diffIDs(prevIDs, currIDs)
+
+	m.links = links
+	m.loading = false
+	m.err = nil
+	newItems := make([]list.Item, 0, len(links)+len(removed))
+	for _, p := range links {
+		status := itemUnchanged
+		if added[p.ID] {
+			status = itemAdded
+		}
+		newItems = append(newItems, linkItem{info: p, status: status})
+	}
+	for _, id := range removed {
+		newItems = append(newItems, linkItem{info: prev[id], status: itemRemoved})
+	}
+
+	m.list.Title = changeTitle("BPF Links", len(added), len(removed))
+	cmd := m.list.SetItems(newItems)
+
+	// Keep the cursor on the same link when the list isn't filtered.
+	// While filtered, the list keeps its cursor index across re-filtering.
+	if selectedID != nil && m.list.FilterState() == list.Unfiltered {
+		for i, item := range newItems {
+			if item.(linkItem).info.ID == *selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return cmd
+}
+
+// Update handles messages for the links list.
+// Returns the updated model, an optional command, and the selected link if Enter was pressed.
+func (m linkListModel) Update(msg tea.Msg) (linkListModel, tea.Cmd, *LinkInfo) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
+	case tea.KeyMsg:
+		// Don't handle enter if we're filtering
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "enter":
+			// Get selected item and return its link info
+			if item, ok := m.list.SelectedItem().(linkItem); ok {
+				return m, nil, &item.info
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd, nil
+}
+
+// View renders the links list.
+func (m linkListModel) View() string {
+	if m.loading {
+		return titleStyle.Render("BPF Links") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading BPF links...")
+	}
+
+	if len(m.links) == 0 && m.err == nil {
+		return titleStyle.Render("BPF Links") + "\n\n" +
+			dimStyle.Render("No BPF links loaded")
+	}
+
+	if m.err != nil {
+		return titleStyle.Render("BPF Links") + "\n\n" +
+			errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	return m.list.View()
+}
+
+// SetSize updates the list dimensions.
+func (m *linkListModel) SetSize(width, height int) {
+	listHeight := height - 6
+	if listHeight < 3 {
+		listHeight = 3
+	}
+	m.list.SetSize(width, listHeight)
+}
+
+// SetError sets an error state for the list.
+func (m *linkListModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *linkListModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.err = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// IsLoading returns true if links are being loaded.
+func (m linkListModel) IsLoading() bool {
+	return m.loading
+}
+
+// SelectedItem returns the currently selected link, if any.
+func (m linkListModel) SelectedItem() *LinkInfo {
+	if item, ok := m.list.SelectedItem().(linkItem); ok {
+		return &item.info
+	}
+	return nil
+}
+
+// IsFiltering returns true if the list is currently in filtering mode.
+func (m linkListModel) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
+// ResetFilter clears any active filter and restores the full list.
+func (m *linkListModel) ResetFilter() {
+	m.list.ResetFilter()
+}
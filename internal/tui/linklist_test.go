@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewLinkListModel(t *testing.T) {
+	m := newLinkListModel(80, 24)
+
+	if m.list.Title != "BPF Links" {
+		t.Errorf("expected title 'BPF Links', got '%s'", m.list.Title)
+	}
+	if len(m.links) != 0 {
+		t.Errorf("expected empty links slice, got %d items", len(m.links))
+	}
+}
+
+func TestLinkItemInterface(t *testing.T) {
+	item := linkItem{
+		info: LinkInfo{ID: 7, Type: "cgroup", ProgID: 42, AttachType: "cgroup_inet_egress", Target: "/sys/fs/cgroup"},
+	}
+
+	if got, want := item.Title(), "[7] cgroup"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := item.Description(), "Prog: 42 | cgroup_inet_egress | /sys/fs/cgroup"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+	if got, want := item.FilterValue(), "cgroup /sys/fs/cgroup"; got != want {
+		t.Errorf("FilterValue() = %q, want %q", got, want)
+	}
+
+	// Links without an attach type omit it
+	item = linkItem{info: LinkInfo{ID: 8, Type: "xdp", ProgID: 43, Target: "eth0"}}
+	if got, want := item.Description(), "Prog: 43 | eth0"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+}
+
+func TestLinkListUpdateEnterKey(t *testing.T) {
+	m := newLinkListModel(80, 24)
+	m.SetLinks([]LinkInfo{
+		{ID: 1, Type: "xdp", ProgID: 10, Target: "eth0"},
+		{ID: 2, Type: "cgroup", ProgID: 11},
+	})
+
+	_, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if selected == nil {
+		t.Fatal("expected selected link, got nil")
+	}
+	if selected.ID != 1 {
+		t.Errorf("expected link ID 1, got %d", selected.ID)
+	}
+}
+
+func TestLinkListView(t *testing.T) {
+	m := newLinkListModel(80, 24)
+	if view := m.View(); !strings.Contains(view, "No BPF links loaded") {
+		t.Errorf("empty view should say no links are loaded, got %q", view)
+	}
+
+	m.SetLinks([]LinkInfo{{ID: 1, Type: "xdp", ProgID: 10, Target: "eth0"}})
+	if view := m.View(); !strings.Contains(view, "[1] xdp") {
+		t.Errorf("view should list the link, got %q", view)
+	}
+
+	m.SetError(errors.New("boom"))
+	if view := m.View(); !strings.Contains(view, "Error: boom") {
+		t.Errorf("view should show the error, got %q", view)
+	}
+}
+
+func TestLinkListLoading(t *testing.T) {
+	m := newLinkListModel(80, 24)
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading BPF links...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetLinks([]LinkInfo{{ID: 1, Type: "xdp"}})
+	if m.IsLoading() {
+		t.Error("SetLinks should clear the loading state")
+	}
+}
+
+func TestLinkListRefreshLinksMarksChanges(t *testing.T) {
+	m := newLinkListModel(80, 24)
+	m.SetLinks([]LinkInfo{
+		{ID: 1, Type: "xdp"},
+		{ID: 2, Type: "cgroup"},
+	})
+
+	m.RefreshLinks([]LinkInfo{
+		{ID: 2, Type: "cgroup"},
+		{ID: 3, Type: "tracing"},
+	})
+
+	statuses := map[uint32]itemStatus{}
+	for _, item := range m.list.Items() {
+		li := item.(linkItem)
+		statuses[li.info.ID] = li.status
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("expected 3 items (2 live + 1 removed), got %d", len(statuses))
+	}
+	if statuses[1] != itemRemoved || statuses[2] != itemUnchanged || statuses[3] != itemAdded {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+}
+
+func TestLinkListFilterMatchesTarget(t *testing.T) {
+	m := newLinkListModel(80, 24)
+	m.SetLinks([]LinkInfo{
+		{ID: 1, Type: "xdp", Target: "eth0"},
+		{ID: 2, Type: "cgroup", Target: "/sys/fs/cgroup/user.slice"},
+	})
+
+	m.list.SetFilterText("user.slice")
+	visible := m.list.VisibleItems()
+	if len(visible) != 1 || visible[0].(linkItem).info.ID != 2 {
+		t.Errorf("expected only link 2 to match, got %d items", len(visible))
+	}
+
+	m.ResetFilter()
+	if m.IsFiltering() || len(m.list.VisibleItems()) != 2 {
+		t.Error("ResetFilter should show all links")
+	}
+}
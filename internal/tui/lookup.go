@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// parseLookupKey converts a key typed by the user into the bytes of a map key
+// of the given size. Accepted forms, tried in order:
+//
+//   - BTF-structured JSON such as {"pid": 1} or "FLAG_ON", if keyType is known
+//   - an IPv4 address such as 10.0.0.1, stored in network byte order
+//   - a decimal integer such as 42 or -1, stored in native byte order
+//   - hex bytes such as 0a 0b 0c 0d or 0x0a0b0c0d
+//
+// Digits-only input is always decimal; use spaces or 0x to enter it as hex.
+func parseLookupKey(input string, size uint32, keyType *BTFType) ([]byte, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return nil, fmt.Errorf("no key entered")
+	}
+
+	if strings.ContainsAny(s[:1], "{[\"") {
+		if keyType == nil {
+			return nil, fmt.Errorf("structured keys need BTF, which this map doesn't have")
+		}
+		return encodeBTF(keyType, s)
+	}
+
+	if strings.Count(s, ".") == 3 {
+		ip := net.ParseIP(s).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", s)
+		}
+		if size != 4 {
+			return nil, fmt.Errorf("IPv4 keys need a 4 byte key, map has %d", size)
+		}
+		return []byte(ip), nil
+	}
+
+	if isDecimal(s) {
+		switch size {
+		case 1, 2, 4, 8:
+		default:
+			return nil, fmt.Errorf("decimal keys need a 1, 2, 4 or 8 byte key, map has %d", size)
+		}
+		n, err := parseInt(s, size, strings.HasPrefix(s, "-"))
+		if err != nil {
+			return nil, err
+		}
+		key := make([]byte, size)
+		writeUint(key, size, n)
+		return key, nil
+	}
+
+	return parseHexBytes(s, size)
+}
+
+// isDecimal returns true if s is an optionally negative run of digits.
+func isDecimal(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestParseLookupKey(t *testing.T) {
+	keyType := &BTFType{Kind: BTFKindStruct, Name: "key", Size: 4, Members: []BTFMember{
+		{Name: "pid", Offset: 0, Type: btfU32},
+	}}
+
+	u32 := func(v uint32) []byte {
+		return binary.NativeEndian.AppendUint32(nil, v)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		size    uint32
+		keyType *BTFType
+		want    []byte
+		wantErr bool
+	}{
+		{"decimal", "42", 4, nil, u32(42), false},
+		{"negative decimal", "-1", 4, nil, u32(0xffffffff), false},
+		{"decimal u8", "255", 1, nil, []byte{255}, false},
+		{"decimal too large", "256", 1, nil, nil, true},
+		{"decimal odd size", "1", 3, nil, nil, true},
+		{"ipv4", "10.0.0.1", 4, nil, []byte{10, 0, 0, 1}, false},
+		{"ipv4 wrong size", "10.0.0.1", 8, nil, nil, true},
+		{"invalid ipv4", "10.0.0.300", 4, nil, nil, true},
+		{"hex with spaces", "0a 0b 0c 0d", 4, nil, []byte{0x0a, 0x0b, 0x0c, 0x0d}, false},
+		{"hex with prefix", "0x0a0b0c0d", 4, nil, []byte{0x0a, 0x0b, 0x0c, 0x0d}, false},
+		{"hex with letters", "0a0b0c0d", 4, nil, []byte{0x0a, 0x0b, 0x0c, 0x0d}, false},
+		{"btf struct", `{"pid": 7}`, 4, keyType, u32(7), false},
+		{"btf without type", `{"pid": 7}`, 4, nil, nil, true},
+		{"empty", "  ", 4, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLookupKey(tt.input, tt.size, tt.keyType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLookupKey(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("parseLookupKey(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,43 +1,160 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// mapDetailAction identifies an action in the map detail view.
+type mapDetailAction int
+
+const (
+	mapActionDump   mapDetailAction = iota // Dump all entries
+	mapActionLookup                        // Look up a single key
+	mapActionBTF                           // Browse the key and value types
+	mapActionTail                          // Tail the records of a ring buffer or perf event array
+)
+
+// mapDetailActions describes each action, indexed by action.
+var mapDetailActions = []struct {
+	label string
+	hint  string
+}{
+	mapActionDump:   {"Dump Contents", "Press Enter to dump map contents"},
+	mapActionLookup: {"Lookup Key", "Press Enter to look up a single key"},
+	mapActionBTF:    {"Browse BTF Types", "Press Enter to browse the key and value types"},
+	mapActionTail:   {"Tail Events", "Press Enter to stream records as they're written; they're taken from the map's other readers"},
+}
+
+// actionsFor returns the actions offered for a map of the given type, in
+// display order. Ring buffers and perf event arrays have no entries to dump
+// or look up, so their records are tailed instead.
+func actionsFor(mapType string) []mapDetailAction {
+	if isEventMapType(mapType) {
+		return []mapDetailAction{mapActionTail, mapActionBTF}
+	}
+	return []mapDetailAction{mapActionDump, mapActionLookup, mapActionBTF}
+}
+
+// mapDetailRequest is returned by the map detail view when an action is run.
+type mapDetailRequest struct {
+	action mapDetailAction
+	key    string // Key as typed by the user, for mapActionLookup
+}
+
+// mapLookupResult is the outcome of looking up a single key.
+type mapLookupResult struct {
+	key   []byte  // Parsed key, or nil if the input was invalid
+	value []byte  // Value stored under key
+	btf   *MapBTF // Key/value types, or nil if the map has no BTF
+	err   error   // ErrKeyNotFound if the key isn't in the map
+}
+
 // mapDetailModel manages the map detail view state.
 type mapDetailModel struct {
-	mapInfo  *MapInfo
-	viewport viewport.Model
-	cursor   int // 0 = Dump option selected
-	width    int
-	height   int
-	ready    bool
+	mapInfo        *MapInfo
+	owners         processOwners
+	viewport       viewport.Model
+	actions        []mapDetailAction // Actions offered for the map
+	cursor         int               // Index into actions
+	width          int
+	height         int
+	ready          bool
+	loading        bool
+	spinner        spinner.Model
+	prompting      bool // Reading a key to look up
+	input          textinput.Model
+	confirmingTail bool // Asking before taking the map's records from its owner
+	lookingUp      bool
+	lookup         *mapLookupResult // Result of the last lookup, if any
 }
 
 // newMapDetailModel creates a new map detail model.
 func newMapDetailModel(width, height int) mapDetailModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "e.g. 42, 10.0.0.1, 0a 0b 0c 0d or {\"pid\": 1}"
+	input.Width = width - 4
+
 	return mapDetailModel{
-		width:  width,
-		height: height,
-		cursor: 0,
+		width:   width,
+		height:  height,
+		cursor:  0,
+		spinner: newSpinner(),
+		input:   input,
 	}
 }
 
 // SetMap sets the map to display.
 func (m *mapDetailModel) SetMap(mapInfo *MapInfo) {
 	m.mapInfo = mapInfo
-	m.cursor = 0 // Reset cursor to Dump option
+	m.owners = processOwners{}
+	m.actions = nil
+	if mapInfo != nil {
+		m.actions = actionsFor(mapInfo.Type)
+	}
+	m.cursor = 0 // Reset cursor to the first action
+	m.loading = false
+	m.prompting = false
+	m.confirmingTail = false
+	m.input.Blur()
+	m.input.SetValue("")
+	m.lookingUp = false
+	m.lookup = nil
+	m.updateViewport()
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the previous map is cleared and the returned command
+// starts the spinner. Stopping also abandons a pending lookup.
+func (m *mapDetailModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	m.lookingUp = false
+	if loading {
+		m.mapInfo = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetProcesses shows which of procs hold the displayed map, or why they
+// couldn't be found.
+func (m *mapDetailModel) SetProcesses(procs []ProcessInfo, err error) {
+	if m.mapInfo == nil {
+		return
+	}
+	m.owners = processOwners{known: true, procs: ownersOf(procs, m.mapInfo.ID, heldMaps), err: err}
 	m.updateViewport()
 }
 
+// StartLookup shows the lookup as in progress.
+// The returned command starts the spinner.
+func (m *mapDetailModel) StartLookup() tea.Cmd {
+	m.lookingUp = true
+	m.lookup = nil
+	m.updateViewport()
+	return m.spinner.Tick
+}
+
+// SetLookupResult shows the result of a lookup.
+func (m *mapDetailModel) SetLookupResult(result mapLookupResult) {
+	m.lookingUp = false
+	m.lookup = &result
+	m.updateViewport()
+	m.viewport.GotoBottom()
+}
+
 // SetSize updates the viewport dimensions.
 func (m *mapDetailModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.input.Width = width - 4
 	if m.ready {
 		m.viewport.Width = width
 		m.viewport.Height = height - 4 // Leave room for title and help bar
@@ -127,61 +244,234 @@ func (m *mapDetailModel) renderContent() string {
 		b.WriteString("\n")
 	}
 
+	// Owning processes section
+	b.WriteString(m.owners.render("map"))
+
 	// Actions section
 	b.WriteString("\n")
 	b.WriteString(titleStyle.Render("Actions"))
 	b.WriteString("\n")
 
-	// Dump Contents action
-	if m.cursor == 0 {
-		b.WriteString(selectedStyle.Render("▶ Dump Contents"))
-	} else {
-		b.WriteString(normalStyle.Render("  Dump Contents"))
+	for i, action := range m.actions {
+		if m.cursor == i {
+			b.WriteString(selectedStyle.Render("▶ " + mapDetailActions[action].label))
+		} else {
+			b.WriteString(normalStyle.Render("  " + mapDetailActions[action].label))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(mapDetailActions[m.actions[m.cursor]].hint))
+
+	// Lookup section
+	switch {
+	case m.prompting:
+		b.WriteString("\n\n")
+		b.WriteString(titleStyle.Render("Key (hex, decimal, IPv4 or BTF JSON)"))
+		b.WriteString("\n")
+		b.WriteString(m.input.View())
+	case m.confirmingTail:
+		b.WriteString("\n\n")
+		b.WriteString(errorStyle.Render("Tail events and take them from the map's owner?"))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render(m.tailWarning()))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("y: tail • n/esc: cancel"))
+	case m.lookingUp:
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + dimStyle.Render(" Looking up key..."))
+	case m.lookup != nil:
+		b.WriteString("\n\n")
+		b.WriteString(m.renderLookup())
 	}
+
+	return b.String()
+}
+
+// renderLookup renders the result of the last lookup.
+func (m *mapDetailModel) renderLookup() string {
+	r := m.lookup
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Lookup Result"))
 	b.WriteString("\n")
+
+	if r.key == nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", r.err)))
+		return b.String()
+	}
+
+	var keyType, valueType *BTFType
+	if r.btf != nil {
+		keyType, valueType = r.btf.Key, r.btf.Value
+	}
+	pad := strings.Repeat(" ", labelStyle.GetWidth())
+
+	b.WriteString(labelStyle.Render("Key:   "))
+	b.WriteString(valueStyle.Render(indentLines(formatEntryBytes(keyType, r.key, displayMode{}), pad)))
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render("Press Enter to dump map contents"))
 
+	switch {
+	case errors.Is(r.err, ErrKeyNotFound):
+		b.WriteString(errorStyle.Render("Key not found"))
+	case r.err != nil:
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", r.err)))
+	default:
+		b.WriteString(labelStyle.Render("Value: "))
+		b.WriteString(m.formatLookupValue(valueType, r.value, pad))
+	}
 	return b.String()
 }
 
+// formatLookupValue renders a looked up value, with per-CPU values as a
+// table with a row per CPU like the dump view shows them.
+func (m *mapDetailModel) formatLookupValue(t *BTFType, value []byte, pad string) string {
+	if m.mapInfo != nil && isPerCPUMapType(m.mapInfo.Type) {
+		if values := splitPerCPU(value, m.mapInfo.ValueSize); values != nil {
+			cols := perCPUColumns(t, m.mapInfo.ValueSize)
+			return indentLines(perCPUTable(values, cols, false, valueStyle), pad)
+		}
+	}
+	return valueStyle.Render(indentLines(formatEntryBytes(t, value, displayMode{}), pad))
+}
+
 // Init implements tea.Model for mapDetailModel.
 func (m mapDetailModel) Init() tea.Cmd {
 	return nil
 }
 
 // Update handles messages for the map detail view.
-// Returns the updated model, an optional command, and whether Dump was selected.
-func (m mapDetailModel) Update(msg tea.Msg) (mapDetailModel, tea.Cmd, bool) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
+// Returns the updated model, an optional command, and the action to run, if any.
+func (m mapDetailModel) Update(msg tea.Msg) (mapDetailModel, tea.Cmd, *mapDetailRequest) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load or lookup is in flight
+		if !m.loading && !m.lookingUp {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		if m.lookingUp {
+			m.updateViewport()
+		}
+		return m, cmd, nil
+	}
+
+	if m.prompting {
+		return m.updatePrompt(msg)
+	}
+	if m.confirmingTail {
+		return m.updateConfirmTail(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch msg.String() {
 		case "up", "k":
-			// Currently only one action, so no movement needed
-			// But keep the handler for future extensibility
-			return m, nil, false
+			if m.cursor > 0 {
+				m.cursor--
+				m.updateViewport()
+			}
+			return m, nil, nil
 
 		case "down", "j":
-			// Currently only one action, so no movement needed
-			return m, nil, false
+			if m.cursor < len(m.actions)-1 {
+				m.cursor++
+				m.updateViewport()
+			}
+			return m, nil, nil
 
 		case "enter":
-			// If Dump is selected and we have a map, signal to navigate to MapDump
-			if m.mapInfo != nil && m.cursor == 0 {
-				return m, nil, true
+			if m.mapInfo == nil {
+				return m, nil, nil
+			}
+			switch m.actions[m.cursor] {
+			case mapActionDump:
+				// Signal to navigate to MapDump
+				return m, nil, &mapDetailRequest{action: mapActionDump}
+			case mapActionTail:
+				m.confirmingTail = true
+				m.updateViewport()
+				m.viewport.GotoBottom()
+				return m, nil, nil
+			case mapActionLookup:
+				m.prompting = true
+				cmd := m.input.Focus()
+				m.updateViewport()
+				m.viewport.GotoBottom()
+				return m, cmd, nil
+			case mapActionBTF:
+				return m, nil, &mapDetailRequest{action: mapActionBTF}
 			}
-			return m, nil, false
+			return m, nil, nil
 		}
 	}
 
 	// Handle viewport scrolling for other keys
 	var cmd tea.Cmd
 	m.viewport, cmd = m.viewport.Update(msg)
-	return m, cmd, false
+	return m, cmd, nil
+}
+
+// updatePrompt handles messages while reading a key to look up.
+func (m mapDetailModel) updatePrompt(msg tea.Msg) (mapDetailModel, tea.Cmd, *mapDetailRequest) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.prompting = false
+			m.input.Blur()
+			m.updateViewport()
+			return m, nil, nil
+
+		case "enter":
+			key := strings.TrimSpace(m.input.Value())
+			if key == "" {
+				return m, nil, nil
+			}
+			m.prompting = false
+			m.input.Blur()
+			m.updateViewport()
+			return m, nil, &mapDetailRequest{action: mapActionLookup, key: key}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.updateViewport()
+	return m, cmd, nil
+}
+
+// updateConfirmTail handles messages while asking whether to tail the map.
+func (m mapDetailModel) updateConfirmTail(msg tea.Msg) (mapDetailModel, tea.Cmd, *mapDetailRequest) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "y", "Y":
+			m.confirmingTail = false
+			m.updateViewport()
+			return m, nil, &mapDetailRequest{action: mapActionTail}
+		case "n", "N", "esc":
+			m.confirmingTail = false
+			m.updateViewport()
+		}
+	}
+	return m, nil, nil
+}
+
+// tailWarning explains what tailing the map does to its owner.
+func (m mapDetailModel) tailWarning() string {
+	if m.mapInfo != nil && strings.ReplaceAll(strings.ToLower(m.mapInfo.Type), "_", "") == "perfeventarray" {
+		return "The map's entries will point at bpftui's buffers, so its owner\n" +
+			"stops receiving events. Its own buffers are not restored on\n" +
+			"leaving: the owner has to reattach to get events again."
+	}
+	return "bpftui shares the ring buffer's consumer position, so records it\n" +
+		"reads are taken from the owner, which won't receive them."
 }
 
 // View renders the map detail view.
 func (m mapDetailModel) View() string {
+	if m.loading {
+		return titleStyle.Render("Map Details") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading map...")
+	}
+
 	if m.mapInfo == nil {
 		return titleStyle.Render("Map Details") + "\n\n" +
 			dimStyle.Render("No map selected")
@@ -196,6 +486,36 @@ func (m mapDetailModel) View() string {
 	return title + "\n\n" + m.viewport.View()
 }
 
+// IsLoading returns true if the map is being loaded.
+func (m mapDetailModel) IsLoading() bool {
+	return m.loading
+}
+
+// IsPrompting returns true while a key to look up is being typed.
+func (m mapDetailModel) IsPrompting() bool {
+	return m.prompting
+}
+
+// IsConfirmingTail returns true while asking whether to tail the map.
+func (m mapDetailModel) IsConfirmingTail() bool {
+	return m.confirmingTail
+}
+
+// IsLookingUp returns true while a lookup is in flight.
+func (m mapDetailModel) IsLookingUp() bool {
+	return m.lookingUp
+}
+
+// GetLookupResult returns the result of the last lookup, or nil if none.
+func (m mapDetailModel) GetLookupResult() *mapLookupResult {
+	return m.lookup
+}
+
+// GetOwners returns the processes holding the map, once they're known.
+func (m mapDetailModel) GetOwners() []ProcessInfo {
+	return m.owners.procs
+}
+
 // GetMapInfo returns the currently displayed map info.
 func (m mapDetailModel) GetMapInfo() *MapInfo {
 	return m.mapInfo
@@ -77,10 +77,10 @@ func TestMapDetailModel_EnterSelectsDump(t *testing.T) {
 	m.SetMap(mapInfo)
 
 	// Press enter - should signal dump selection
-	var dumpSelected bool
-	m, _, dumpSelected = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	var req *mapDetailRequest
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
-	if !dumpSelected {
+	if req == nil || req.action != mapActionDump {
 		t.Error("expected dump to be selected on Enter")
 	}
 }
@@ -89,10 +89,10 @@ func TestMapDetailModel_EnterNoMap(t *testing.T) {
 	m := newMapDetailModel(80, 24)
 
 	// Press enter when no map is set
-	var dumpSelected bool
-	m, _, dumpSelected = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	var req *mapDetailRequest
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
-	if dumpSelected {
+	if req != nil {
 		t.Error("expected dump not to be selected when no map is set")
 	}
 }
@@ -120,17 +120,17 @@ func TestMapDetailModel_ViewRendersAllFields(t *testing.T) {
 
 	// Check that all fields are rendered
 	expectedFields := []string{
-		"42",         // ID
-		"my_bpf_map", // Name
-		"hash",       // Type
-		"8",          // KeySize
-		"16",         // ValueSize
-		"2048",       // MaxEntries
-		"1",          // Flags (need to be careful, this appears in multiple places)
-		"8192",       // MemLock
-		"2024-06-15", // LoadedAt (partial)
-		"1000",       // UID
-		"Yes",        // Pinned
+		"42",                 // ID
+		"my_bpf_map",         // Name
+		"hash",               // Type
+		"8",                  // KeySize
+		"16",                 // ValueSize
+		"2048",               // MaxEntries
+		"1",                  // Flags (need to be careful, this appears in multiple places)
+		"8192",               // MemLock
+		"2024-06-15",         // LoadedAt (partial)
+		"1000",               // UID
+		"Yes",                // Pinned
 		"/sys/fs/bpf/my_map", // PinnedPath
 		"Dump Contents",
 	}
@@ -219,27 +219,28 @@ func TestMapDetailModel_KeyNavigation(t *testing.T) {
 	m := newMapDetailModel(80, 24)
 	m.SetMap(&MapInfo{ID: 1, Name: "test"})
 
-	// Test up key - should not change cursor (only one action)
+	// Up on the first action stays in place
 	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	if m.cursor != 0 {
 		t.Errorf("expected cursor to stay at 0, got %d", m.cursor)
 	}
 
-	// Test down key - should not change cursor (only one action)
+	// Down moves to Lookup Key
 	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
-	if m.cursor != 0 {
-		t.Errorf("expected cursor to stay at 0, got %d", m.cursor)
+	if m.cursor != 1 {
+		t.Errorf("expected cursor to move to 1, got %d", m.cursor)
 	}
 
-	// Test arrow keys
-	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
-	if m.cursor != 0 {
-		t.Errorf("expected cursor to stay at 0, got %d", m.cursor)
+	// Down moves to Browse BTF Types, and stays on the last action
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.cursor != 2 {
+		t.Errorf("expected cursor to stay at 2, got %d", m.cursor)
 	}
 
-	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
-	if m.cursor != 0 {
-		t.Errorf("expected cursor to stay at 0, got %d", m.cursor)
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.cursor != 1 {
+		t.Errorf("expected cursor to move back to 1, got %d", m.cursor)
 	}
 }
 
@@ -283,3 +284,160 @@ func TestMapDetailModel_ActionsSection(t *testing.T) {
 		t.Error("expected content to contain help text for dump action")
 	}
 }
+
+func TestMapDetailModel_LookupPrompt(t *testing.T) {
+	m := newMapDetailModel(80, 40)
+	m.SetMap(&MapInfo{ID: 1, Name: "test", KeySize: 4})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.IsPrompting() {
+		t.Fatal("enter on Lookup Key should prompt for a key")
+	}
+	if !strings.Contains(m.viewport.View(), "Key (hex, decimal, IPv4 or BTF JSON)") {
+		t.Error("view should show the key prompt")
+	}
+
+	for _, r := range "42" {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	var req *mapDetailRequest
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.action != mapActionLookup || req.key != "42" {
+		t.Fatalf("expected a lookup request for 42, got %+v", req)
+	}
+	if m.IsPrompting() {
+		t.Error("prompt should close after submitting")
+	}
+}
+
+func TestMapDetailModel_LookupPromptCancel(t *testing.T) {
+	m := newMapDetailModel(80, 40)
+	m.SetMap(&MapInfo{ID: 1, Name: "test", KeySize: 4})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	var req *mapDetailRequest
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if req != nil || m.IsPrompting() {
+		t.Error("esc should close the prompt without a request")
+	}
+}
+
+func TestMapDetailModel_LookupResult(t *testing.T) {
+	m := newMapDetailModel(80, 40)
+	m.SetMap(&MapInfo{ID: 1, Name: "test", KeySize: 4, ValueSize: 4})
+
+	m.StartLookup()
+	if !m.IsLookingUp() || !strings.Contains(m.viewport.View(), "Looking up key...") {
+		t.Error("view should show the lookup in progress")
+	}
+
+	m.SetLookupResult(mapLookupResult{key: []byte{1, 0, 0, 0}, value: []byte{0xaa, 0xbb, 0xcc, 0xdd}})
+	content := m.viewport.View()
+	if !strings.Contains(content, "Lookup Result") || !strings.Contains(content, "aa bb cc dd") {
+		t.Errorf("view should show the looked up value, got:\n%s", content)
+	}
+
+	m.SetLookupResult(mapLookupResult{key: []byte{2, 0, 0, 0}, err: ErrKeyNotFound})
+	content = m.viewport.View()
+	if !strings.Contains(content, "02 00 00 00") || !strings.Contains(content, "Key not found") {
+		t.Errorf("view should report the key as not found, got:\n%s", content)
+	}
+
+	// A new map clears the previous result
+	m.SetMap(&MapInfo{ID: 2, Name: "other", KeySize: 4})
+	if m.GetLookupResult() != nil {
+		t.Error("SetMap should clear the lookup result")
+	}
+}
+
+func TestMapDetailModel_LookupResultPerCPU(t *testing.T) {
+	m := newMapDetailModel(80, 40)
+	m.SetMap(&MapInfo{ID: 1, Name: "pkt_count", Type: "percpu_array", KeySize: 4, ValueSize: 8})
+
+	m.SetLookupResult(mapLookupResult{key: []byte{0, 0, 0, 0}, value: perCPUValue(10, 20, 30, 40)})
+	content := m.viewport.View()
+	for _, want := range []string{"CPU", "sum", "100", "avg", "25"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("view should show the value as a per-CPU table with %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestMapDetailModel_EnterBrowseBTF(t *testing.T) {
+	m := newMapDetailModel(80, 24)
+	m.SetMap(&MapInfo{ID: 1, Name: "test"})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !strings.Contains(m.View(), "browse the key and value types") {
+		t.Error("view should show the Browse BTF Types hint")
+	}
+
+	_, _, req := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.action != mapActionBTF {
+		t.Errorf("expected Browse BTF Types request, got %+v", req)
+	}
+}
+
+func TestMapDetailModel_Processes(t *testing.T) {
+	m := newMapDetailModel(80, 40)
+	m.SetMap(&MapInfo{ID: 5, Name: "events"})
+
+	m.SetProcesses([]ProcessInfo{{PID: 1234, Comm: "cilium-agent", ProgIDs: []uint32{5}}}, nil)
+	if len(m.GetOwners()) != 0 {
+		t.Errorf("program IDs shouldn't match the map, got %+v", m.GetOwners())
+	}
+	if !strings.Contains(m.viewport.View(), "No process holds this map open") {
+		t.Error("view should say no process holds the map")
+	}
+
+	m.SetProcesses([]ProcessInfo{{PID: 1234, Comm: "cilium-agent", MapIDs: []uint32{5}}}, nil)
+	if !strings.Contains(m.viewport.View(), "1234 (cilium-agent)") {
+		t.Error("view should show the owning process")
+	}
+}
+
+func TestMapDetailModel_EventMapActions(t *testing.T) {
+	m := newMapDetailModel(80, 24)
+	m.SetMap(&MapInfo{ID: 1, Name: "events", Type: "ringbuf"})
+
+	content := m.renderContent()
+	if !strings.Contains(content, "Tail Events") || strings.Contains(content, "Dump Contents") {
+		t.Errorf("ring buffers should offer tailing instead of dumping:\n%s", content)
+	}
+
+	// Tailing takes the records from the owner, so it's confirmed first
+	var req *mapDetailRequest
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if req != nil || !m.IsConfirmingTail() {
+		t.Fatal("Enter should ask before tailing")
+	}
+	if content := m.renderContent(); !strings.Contains(content, "taken from the owner") {
+		t.Errorf("confirmation should warn that the owner loses records:\n%s", content)
+	}
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if req != nil || m.IsConfirmingTail() {
+		t.Error("esc should cancel tailing")
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _, req = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if req == nil || req.action != mapActionTail {
+		t.Error("expected tail events to be selected once confirmed")
+	}
+}
+
+func TestMapDetailModel_TailWarningPerfEventArray(t *testing.T) {
+	m := newMapDetailModel(80, 24)
+	m.SetMap(&MapInfo{ID: 1, Name: "events", Type: "perf_event_array"})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	content := m.renderContent()
+	if !strings.Contains(content, "stops receiving events") || !strings.Contains(content, "not restored") {
+		t.Errorf("perf event arrays should warn that the owner's buffers aren't restored:\n%s", content)
+	}
+}
@@ -2,30 +2,76 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// selectedMarker marks the selected entry in the dump view.
+const selectedMarker = "▶ "
+
 // mapDumpModel manages the map dump view state.
 type mapDumpModel struct {
-	mapID    uint32
-	mapName  string
-	entries  []MapEntry
-	viewport viewport.Model
-	width    int
-	height   int
-	ready    bool
-	loading  bool
-	err      error
+	mapID   uint32
+	mapName string
+	entries []MapEntry // Entries loaded so far
+	btf     *MapBTF    // Key/value types, or nil if the map has no BTF
+	showRaw bool       // Show raw hex even when BTF is available
+	perCPU  bool       // Values hold a value per possible CPU, back to back
+	totals  bool       // Show only the aggregation rows of per-CPU values
+	width   int
+	height  int
+	loading bool
+	spinner spinner.Model
+	err     error
+
+	// Large maps are loaded a page at a time as the cursor nears the end
+	iter     MapIterator                 // Source of further entries, or nil once all are loaded
+	reopen   func() (MapIterator, error) // Reads the map again from the start, for exports
+	fetching bool                        // A page is being read
+	inserted map[string]bool             // Keys inserted while pages remain, skipped when read
+
+	cursor      int // Index of the selected entry
+	offset      int // Index of the first entry shown; only visible entries are rendered
+	keySize     uint32
+	valueSize   uint32
+	mode        editMode
+	input       textinput.Model
+	inputErr    string
+	pendingKey  []byte // Key of the entry being inserted
+	status      string // Result of the last edit or export
+	statusIsErr bool
+	search      dumpSearch
+
+	// How keys and values are shown without BTF, remembered per map ID
+	modes        dumpModes
+	mapModes     map[uint32]dumpModes
+	defaultModes dumpModes            // For maps shown for the first time
+	nameModes    map[string]dumpModes // Configured for maps by name
+
+	// Comparison against the map's entries in a baseline snapshot
+	baseline []MapEntry // nil when not comparing
+	diff     entryDiff
+}
+
+// dumpModes are the display modes of a map's keys and values.
+type dumpModes struct {
+	key   displayMode
+	value displayMode
 }
 
 // newMapDumpModel creates a new map dump model.
 func newMapDumpModel(width, height int) mapDumpModel {
 	return mapDumpModel{
-		width:  width,
-		height: height,
+		width:    width,
+		height:   height,
+		spinner:  newSpinner(),
+		input:    newEditInput(width),
+		mapModes: make(map[uint32]dumpModes),
 	}
 }
 
@@ -36,85 +82,439 @@ func (m *mapDumpModel) SetMapDump(mapID uint32, mapName string, entries []MapEnt
 	m.entries = entries
 	m.loading = false
 	m.err = nil
-	m.updateViewport()
+	if m.cursor >= len(entries) {
+		m.cursor = max(len(entries)-1, 0)
+	}
+	if m.baseline != nil {
+		m.diff.reset()
+		m.diff.add(entries)
+		if m.iter == nil {
+			m.diff.finish(m.baseline, entries)
+		}
+	}
+	m.search.update(entries)
+	m.ensureCursorVisible()
+}
+
+// SetRemaining sets the iterator the rest of the map is read from as the
+// cursor nears the end of the loaded entries, replacing any previous one,
+// and reopen, which reads the whole map again for exports. A nil iter means
+// all entries are loaded.
+func (m *mapDumpModel) SetRemaining(iter MapIterator, reopen func() (MapIterator, error)) {
+	m.StopPaging()
+	m.iter = iter
+	m.reopen = reopen
+	if iter != nil {
+		// Removed entries are only known once all are loaded
+		m.diff.removed = nil
+	}
+}
+
+// StopPaging closes the iterator further entries are read from. An iterator
+// with a page being read is closed when the page arrives.
+func (m *mapDumpModel) StopPaging() {
+	if m.iter != nil && !m.fetching {
+		m.iter.Close()
+	}
+	m.iter = nil
+	m.fetching = false
+	m.inserted = nil
+}
+
+// AppendPage adds a page of entries read from iter. Pages from an iterator
+// that was replaced or stopped are discarded, and the iterator is closed.
+// Once the map is exhausted, the iterator is closed.
+func (m *mapDumpModel) AppendPage(iter MapIterator, entries []MapEntry, done bool, err error) tea.Cmd {
+	if iter != m.iter {
+		iter.Close()
+		return nil
+	}
+	m.fetching = false
+	if err != nil {
+		m.search.seeking = false
+		m.StopPaging()
+		m.SetStatus(fmt.Sprintf("Error loading more entries: %v", err), true)
+		return nil
+	}
+
+	if len(m.inserted) > 0 {
+		// Inserted entries are already shown
+		entries = slices.DeleteFunc(entries, func(e MapEntry) bool { return m.inserted[string(e.Key)] })
+	}
+	start := len(m.entries)
+	m.entries = append(m.entries, entries...)
+	if m.baseline != nil {
+		m.diff.add(entries)
+		if done {
+			m.diff.finish(m.baseline, m.entries)
+		}
+	}
+	m.search.extend(entries, start)
+	if done {
+		m.StopPaging()
+	}
+	if m.search.seeking {
+		// Continue the jump to the next match
+		return m.jumpToMatch(m.search.seekFrom, m.search.seekDir)
+	}
+	if done {
+		return nil
+	}
+	// Keep loading if the cursor is still near the end
+	return m.fetchMore()
+}
+
+// fetchMore returns the command reading the next page if the cursor is
+// within a page of the end of the loaded entries.
+func (m *mapDumpModel) fetchMore() tea.Cmd {
+	if m.cursor < len(m.entries)-dumpPageSize/2 {
+		return nil
+	}
+	return m.fetchPage()
+}
+
+// fetchPage returns the command reading the next page and starting the
+// spinner, unless one is being read or all entries are loaded.
+func (m *mapDumpModel) fetchPage() tea.Cmd {
+	if m.iter == nil || m.fetching {
+		return nil
+	}
+	m.fetching = true
+	return tea.Batch(dumpPageCmd(m.iter, dumpPageSize), m.spinner.Tick)
+}
+
+// IsComplete returns true once all of the map's entries are loaded.
+func (m mapDumpModel) IsComplete() bool {
+	return m.iter == nil
+}
+
+// SetEntrySizes sets the key and value sizes used to validate edits.
+// Zero means the size is unknown and any non-empty input is accepted.
+func (m *mapDumpModel) SetEntrySizes(keySize, valueSize uint32) {
+	m.keySize = keySize
+	m.valueSize = valueSize
+}
+
+// SetPerCPU sets whether the map stores a value per CPU. Per-CPU values
+// are split by the value size and shown as a table with a row per CPU.
+func (m *mapDumpModel) SetPerCPU(perCPU bool) {
+	m.perCPU = perCPU
+}
+
+// cpuCount returns the number of CPUs per-CPU values are stored for, or 0
+// if the map isn't per-CPU or it isn't known yet.
+func (m mapDumpModel) cpuCount() int {
+	if !m.perCPU || len(m.entries) == 0 {
+		return 0
+	}
+	return len(splitPerCPU(m.entries[0].Value, m.valueSize))
+}
+
+// SetStatus shows the result of an edit or export next to the title.
+func (m *mapDumpModel) SetStatus(status string, isErr bool) {
+	m.status = status
+	m.statusIsErr = isErr
+}
+
+// SetBaseline sets the entries to compare the dump against, e.g. the map's
+// entries in a snapshot. Entries are then marked as added or changed, with
+// the baseline value shown for changed ones, and removed entries are listed
+// after the current ones. Pass nil to stop comparing.
+func (m *mapDumpModel) SetBaseline(entries []MapEntry) {
+	m.baseline = entries
+	if entries == nil {
+		m.diff = entryDiff{}
+		return
+	}
+	m.diff = newEntryDiff(entries)
+	m.diff.add(m.entries)
+	if m.iter == nil {
+		m.diff.finish(entries, m.entries)
+	}
+}
+
+// SetBTF sets the BTF types used to decode keys and values.
+// Pass nil to render raw hex only.
+func (m *mapDumpModel) SetBTF(btf *MapBTF) {
+	m.btf = btf
+	m.ensureCursorVisible()
+}
+
+// SetDefaultModes sets the display modes of maps shown for the first time:
+// those in byName for maps with these names, and modes for the others.
+func (m *mapDumpModel) SetDefaultModes(modes dumpModes, byName map[string]dumpModes) {
+	m.defaultModes = modes
+	m.nameModes = byName
+}
+
+// modesFor returns the display modes of a map: those chosen for it earlier
+// in the session, or else those configured for its name or for all maps.
+func (m mapDumpModel) modesFor(mapID uint32, mapName string) dumpModes {
+	if modes, ok := m.mapModes[mapID]; ok {
+		return modes
+	}
+	if modes, ok := m.nameModes[mapName]; ok {
+		return modes
+	}
+	return m.defaultModes
+}
+
+// setModes sets how the map's keys and values are shown and remembers it
+// for the next time the map is dumped. Raw output is switched to, as modes
+// don't apply to BTF-decoded output.
+func (m *mapDumpModel) setModes(modes dumpModes) {
+	m.modes = modes
+	m.mapModes[m.mapID] = modes
+	if m.btf != nil {
+		m.showRaw = true
+	}
+	m.ensureCursorVisible()
 }
 
 // SetError sets an error state for the dump view.
 func (m *mapDumpModel) SetError(err error) {
 	m.err = err
 	m.loading = false
-	m.updateViewport()
 }
 
 // SetLoading sets the loading state.
-func (m *mapDumpModel) SetLoading(loading bool) {
+// When loading starts, the returned command starts the spinner.
+func (m *mapDumpModel) SetLoading(loading bool) tea.Cmd {
 	m.loading = loading
-	m.updateViewport()
+	if loading {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// StartLoading prepares the view for a new dump of the given map, clearing
+// any previous entries or error.
+func (m *mapDumpModel) StartLoading(mapID uint32, mapName string) tea.Cmd {
+	m.mapID = mapID
+	m.mapName = mapName
+	m.entries = nil
+	m.StopPaging()
+	m.reopen = nil
+	m.btf = nil
+	m.baseline = nil
+	m.diff = entryDiff{}
+	m.err = nil
+	m.cursor = 0
+	m.offset = 0
+	m.status = ""
+	m.search = dumpSearch{}
+	m.modes = m.modesFor(mapID, mapName)
+	m.setEditMode(editNone)
+	return m.SetLoading(true)
 }
 
 // SetSize updates the viewport dimensions.
 func (m *mapDumpModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	if m.ready {
-		m.viewport.Width = width
-		m.viewport.Height = height - 4 // Leave room for title and help bar
-	}
-	m.updateViewport()
+	m.input.Width = width - 4
+	m.ensureCursorVisible()
 }
 
-// updateViewport refreshes the viewport content.
-func (m *mapDumpModel) updateViewport() {
-	content := m.renderContent()
-
-	if !m.ready {
-		m.viewport = viewport.New(m.width, m.height-4)
-		m.viewport.SetContent(content)
-		m.ready = true
-	} else {
-		m.viewport.SetContent(content)
+// viewportHeight returns the viewport height, leaving room for the title,
+// help bar and, while editing, the editor dialog.
+func (m mapDumpModel) viewportHeight() int {
+	h := m.height - 4
+	if m.mode != editNone {
+		h -= editorHeight
 	}
+	return max(h, 1)
 }
 
-// renderContent generates the dump view content.
-func (m *mapDumpModel) renderContent() string {
+// renderContent renders the entries that fit in the viewport, starting at
+// the first visible one.
+func (m mapDumpModel) renderContent() string {
 	if m.err != nil {
 		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
 	}
 
 	if m.loading {
-		return dimStyle.Render("Loading map contents...")
+		return m.spinner.View() + dimStyle.Render(" Loading map contents...")
 	}
 
-	if len(m.entries) == 0 {
+	if len(m.entries) == 0 && len(m.diff.removed) == 0 {
 		return dimStyle.Render("Map contains no entries")
 	}
 
+	height := m.viewportHeight()
+	total := m.total()
+	var lines []string
+	for i := m.offset; i < total && len(lines) < height; i++ {
+		// Separator between entries
+		if i > m.offset {
+			lines = append(lines, dimStyle.Render("---"))
+		}
+		lines = append(lines, m.renderEntry(i)...)
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	if m.fetching && len(lines) < height {
+		lines = append(lines, m.spinner.View()+dimStyle.Render(" Loading more entries..."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// total returns the number of entries shown: the loaded entries followed,
+// once all are loaded, by those removed since the baseline.
+func (m mapDumpModel) total() int {
+	if m.iter != nil {
+		return len(m.entries)
+	}
+	return len(m.entries) + len(m.diff.removed)
+}
+
+// renderEntry renders the i-th entry shown as lines.
+func (m mapDumpModel) renderEntry(i int) []string {
+	// Continuation lines of multi-line BTF output are aligned with the first
+	var keyType, valueType *BTFType
+	if m.btf != nil && !m.showRaw {
+		keyType, valueType = m.btf.Key, m.btf.Value
+	}
+	gutter := lipgloss.Width(selectedMarker)
+	pad := strings.Repeat(" ", gutter+labelStyle.GetWidth())
+	entry, status, old := m.entryAt(i)
+
 	var b strings.Builder
 
-	for i, entry := range m.entries {
-		// Key
-		b.WriteString(labelStyle.Render("Key:   "))
-		b.WriteString(valueStyle.Render(formatHex(entry.Key)))
-		b.WriteString("\n")
+	// Key, marked with how it differs from the baseline
+	switch {
+	case i == m.cursor:
+		b.WriteString(selectedStyle.Render(selectedMarker))
+	case m.baseline != nil && status != itemUnchanged:
+		b.WriteString(status.marker())
+	default:
+		b.WriteString(strings.Repeat(" ", gutter))
+	}
+	b.WriteString(m.searchLabel("Key:   ", entry.Key))
+	if m.search.active() {
+		b.WriteString(m.formatMatches(keyType, entry.Key, m.modes.key, status.style(), pad))
+	} else {
+		b.WriteString(status.style().Render(indentLines(formatEntryBytes(keyType, entry.Key, m.modes.key), pad)))
+	}
+	b.WriteString("\n")
 
-		// Value
-		b.WriteString(labelStyle.Render("Value: "))
-		b.WriteString(valueStyle.Render(formatHex(entry.Value)))
+	// Value
+	b.WriteString(strings.Repeat(" ", gutter))
+	b.WriteString(m.searchLabel("Value: ", entry.Value))
+	b.WriteString(m.formatValue(valueType, entry.Value, valueStyle, pad))
+
+	// Value in the baseline, for changed entries
+	if status == itemChanged {
 		b.WriteString("\n")
+		b.WriteString(strings.Repeat(" ", gutter))
+		b.WriteString(labelStyle.Render("Was:   "))
+		b.WriteString(m.formatValue(valueType, old, dimStyle, pad))
+	}
+
+	return strings.Split(b.String(), "\n")
+}
 
-		// Separator between entries (except for last entry)
-		if i < len(m.entries)-1 {
-			b.WriteString(dimStyle.Render("---"))
-			b.WriteString("\n")
+// formatValue renders a value decoded with t, if available, in style, with
+// continuation lines prefixed by pad. Per-CPU values are rendered as a table
+// with a row per CPU.
+func (m mapDumpModel) formatValue(t *BTFType, data []byte, style lipgloss.Style, pad string) string {
+	mode := m.modes.value
+	values := splitPerCPU(data, m.valueSize)
+	if (!m.perCPU || values == nil) && m.search.active() {
+		return m.formatMatches(t, data, mode, style, pad)
+	}
+	if !m.perCPU || values == nil {
+		return style.Render(indentLines(formatEntryBytes(t, data, mode), pad))
+	}
+	if mode.kind == displayHexdump {
+		// Table cells are a line each
+		mode = displayMode{kind: displayHex}
+	}
+	cols := []perCPUColumn{{name: "value", mode: mode}}
+	if !m.showRaw && (t != nil || mode.kind == displayHex) {
+		// Without BTF, values that fit are shown as integers by default
+		cols = perCPUColumns(t, m.valueSize)
+	}
+	return indentLines(perCPUTable(values, cols, m.totals, style), pad)
+}
+
+// entryAt returns the i-th rendered entry with its status against the
+// baseline and, if changed, its baseline value. Entries removed since the
+// baseline follow the current entries.
+func (m mapDumpModel) entryAt(i int) (MapEntry, itemStatus, []byte) {
+	if i >= len(m.entries) {
+		return m.diff.removed[i-len(m.entries)], itemRemoved, nil
+	}
+	if m.baseline == nil {
+		return m.entries[i], itemUnchanged, nil
+	}
+	return m.entries[i], m.diff.status[i], m.diff.old[i]
+}
+
+// ensureCursorVisible scrolls so the selected entry is shown, rendering only
+// the entries between it and the top of the view.
+func (m *mapDumpModel) ensureCursorVisible() {
+	m.offset = min(m.offset, max(m.total()-1, 0))
+	if m.cursor <= m.offset {
+		m.offset = m.cursor
+		return
+	}
+
+	// Scroll down until the entries from the top through the cursor fit
+	height := m.viewportHeight()
+	lines := len(m.renderEntry(m.cursor))
+	top := m.cursor
+	for top > m.offset {
+		above := len(m.renderEntry(top-1)) + 1 // Plus the separator
+		if lines+above > height {
+			break
 		}
+		lines += above
+		top--
+	}
+	m.offset = top
+}
+
+// visibleEntries returns how many entries are shown, at least one.
+func (m mapDumpModel) visibleEntries() int {
+	height := m.viewportHeight()
+	lines, n := 0, 0
+	for i := m.offset; i < m.total() && lines < height; i++ {
+		lines += len(m.renderEntry(i)) + 1
+		n++
+	}
+	return max(n, 1)
+}
+
+// moveCursor moves the entry selection by delta, staying within the loaded
+// entries, and returns the command loading more if it nears their end.
+func (m *mapDumpModel) moveCursor(delta int) tea.Cmd {
+	if len(m.entries) == 0 {
+		return nil
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.entries)-1)
+	m.ensureCursorVisible()
+	return m.fetchMore()
+}
+
+// formatEntryBytes decodes data using t if available, or renders it in mode
+// otherwise.
+func formatEntryBytes(t *BTFType, data []byte, mode displayMode) string {
+	if t == nil {
+		return mode.format(data)
 	}
+	return formatBTF(t, data)
+}
 
-	return b.String()
+// indentLines prefixes every line after the first with pad.
+func indentLines(s, pad string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+pad)
 }
 
-// formatHex converts a byte slice to a space-separated hex string.
-func formatHex(data []byte) string {
+// FormatHex converts a byte slice to a space-separated hex string, or
+// "(empty)" if there are no bytes.
+func FormatHex(data []byte) string {
 	if len(data) == 0 {
 		return "(empty)"
 	}
@@ -132,11 +532,119 @@ func (m mapDumpModel) Init() tea.Cmd {
 }
 
 // Update handles messages for the map dump view.
-func (m mapDumpModel) Update(msg tea.Msg) (mapDumpModel, tea.Cmd) {
-	// Handle viewport scrolling
-	var cmd tea.Cmd
-	m.viewport, cmd = m.viewport.Update(msg)
-	return m, cmd
+// Returns a non-nil edit when the user confirms a write to the map.
+func (m mapDumpModel) Update(msg tea.Msg) (mapDumpModel, tea.Cmd, *mapEdit) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load or a page is in flight
+		if !m.loading && !m.fetching {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+	}
+
+	if m.mode != editNone {
+		return m.updateEditor(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "x":
+			// Toggle between BTF-decoded and raw hex output
+			if m.btf != nil {
+				m.showRaw = !m.showRaw
+				m.ensureCursorVisible()
+			}
+			return m, nil, nil
+
+		case "m":
+			m.setModes(dumpModes{key: m.modes.key, value: m.modes.value.next()})
+			return m, nil, nil
+
+		case "M":
+			m.setModes(dumpModes{key: m.modes.key.next(), value: m.modes.value})
+			return m, nil, nil
+
+		case "o":
+			m.setModes(dumpModes{key: m.modes.key, value: m.modes.value.swapped()})
+			return m, nil, nil
+
+		case "O":
+			m.setModes(dumpModes{key: m.modes.key.swapped(), value: m.modes.value})
+			return m, nil, nil
+
+		case "a":
+			// Toggle between all CPUs and only the aggregation rows
+			if m.perCPU {
+				m.totals = !m.totals
+				m.ensureCursorVisible()
+			}
+			return m, nil, nil
+
+		case "up", "k":
+			return m, m.moveCursor(-1), nil
+
+		case "down", "j":
+			return m, m.moveCursor(1), nil
+
+		case "pgup", "b":
+			return m, m.moveCursor(-m.visibleEntries()), nil
+
+		case "pgdown", "f", " ":
+			return m, m.moveCursor(m.visibleEntries()), nil
+
+		case "home", "g":
+			return m, m.moveCursor(-m.cursor), nil
+
+		case "end", "G":
+			// Loaded entries only; more load from there
+			return m, m.moveCursor(len(m.entries)), nil
+
+		case "e":
+			if !m.canEdit() {
+				return m, nil, nil
+			}
+			return m, m.startEdit(), nil
+
+		case "i":
+			if !m.canEdit() {
+				return m, nil, nil
+			}
+			return m, m.startInsert(), nil
+
+		case "d":
+			if !m.canEdit() {
+				return m, nil, nil
+			}
+			return m, m.startDelete(), nil
+
+		case "s":
+			if !m.canEdit() {
+				return m, nil, nil
+			}
+			return m, m.startExport(), nil
+
+		case "/":
+			if m.loading || m.err != nil {
+				return m, nil, nil
+			}
+			return m, m.startSearch(), nil
+
+		case "n":
+			return m, m.jumpToMatch(m.cursor, 1), nil
+
+		case "N":
+			return m, m.jumpToMatch(m.cursor, -1), nil
+		}
+	}
+
+	return m, nil, nil
+}
+
+// canEdit returns true if the dump is in a state where entries can be edited.
+func (m mapDumpModel) canEdit() bool {
+	return !m.loading && m.err == nil && m.mapID != 0
 }
 
 // View renders the map dump view.
@@ -150,11 +658,49 @@ func (m mapDumpModel) View() string {
 		title = titleStyle.Render("Map Dump")
 	}
 
-	if !m.ready {
-		return title + "\n\nLoading..."
+	if m.mapID != 0 && !m.loading && m.err == nil {
+		count := fmt.Sprintf("%d entries", len(m.entries))
+		if m.iter != nil {
+			count = fmt.Sprintf("%d+ entries", len(m.entries))
+		}
+		title += "  " + dimStyle.Render(count)
+	}
+	if n := m.cpuCount(); n > 0 && !m.loading && m.err == nil {
+		perCPU := fmt.Sprintf("per-CPU values, %d CPUs", n)
+		if m.totals {
+			perCPU += ", totals only"
+		}
+		title += "  " + dimStyle.Render(perCPU)
+	}
+	if m.baseline != nil && !m.loading && m.err == nil {
+		counts := diffCounts(m.diff.added, len(m.diff.removed), m.diff.changed)
+		if m.iter != nil {
+			counts += " so far"
+		}
+		title += "  " + dimStyle.Render(counts)
+	}
+
+	if m.modes != (dumpModes{}) && (m.btf == nil || m.showRaw) && m.mapID != 0 && !m.loading && m.err == nil {
+		title += "  " + dimStyle.Render(fmt.Sprintf("key %s, value %s", m.modes.key, m.modes.value))
+	}
+	if m.search.active() && !m.loading && m.err == nil {
+		title += "  " + dimStyle.Render(m.searchStatus())
+	}
+
+	status := ""
+	if m.status != "" {
+		if m.statusIsErr {
+			status = "  " + errorStyle.Render(m.status)
+		} else {
+			status = "  " + addedStyle.Render(m.status)
+		}
 	}
 
-	return title + "\n\n" + m.viewport.View()
+	view := title + status + "\n\n" + m.renderContent()
+	if m.mode != editNone {
+		view += "\n" + m.renderEditor()
+	}
+	return view
 }
 
 // GetMapID returns the ID of the map being dumped.
@@ -162,6 +708,26 @@ func (m mapDumpModel) GetMapID() uint32 {
 	return m.mapID
 }
 
+// HasBTF returns true if entries can be decoded using BTF.
+func (m mapDumpModel) HasBTF() bool {
+	return m.btf != nil
+}
+
+// GetModes returns the display modes of keys and values.
+func (m mapDumpModel) GetModes() (key, value displayMode) {
+	return m.modes.key, m.modes.value
+}
+
+// IsShowingRaw returns true if raw hex is shown instead of BTF-decoded output.
+func (m mapDumpModel) IsShowingRaw() bool {
+	return m.showRaw
+}
+
+// IsComparing returns true while the dump is compared against a baseline.
+func (m mapDumpModel) IsComparing() bool {
+	return m.baseline != nil
+}
+
 // GetEntryCount returns the number of entries in the dump.
 func (m mapDumpModel) GetEntryCount() int {
 	return len(m.entries)
@@ -177,6 +743,30 @@ func (m mapDumpModel) GetError() error {
 	return m.err
 }
 
+// SelectedEntry returns the selected entry, or nil if there are none.
+func (m mapDumpModel) SelectedEntry() *MapEntry {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return nil
+	}
+	return &m.entries[m.cursor]
+}
+
+// IsEditing returns true while the entry editor is open and capturing input.
+func (m mapDumpModel) IsEditing() bool {
+	return m.mode != editNone
+}
+
+// GetStatus returns the result of the last edit.
+func (m mapDumpModel) GetStatus() string {
+	return m.status
+}
+
+// GetSearchMatches returns the indexes of the loaded entries matching the
+// search, or nil when not searching.
+func (m mapDumpModel) GetSearchMatches() []int {
+	return m.search.matches
+}
+
 // IsLoading returns true if the dump is loading.
 func (m mapDumpModel) IsLoading() bool {
 	return m.loading
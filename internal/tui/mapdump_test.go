@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestNewMapDumpModel(t *testing.T) {
@@ -166,9 +168,9 @@ func TestFormatHex(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatHex(tt.input)
+			result := FormatHex(tt.input)
 			if result != tt.expected {
-				t.Errorf("formatHex(%v) = %q, want %q", tt.input, result, tt.expected)
+				t.Errorf("FormatHex(%v) = %q, want %q", tt.input, result, tt.expected)
 			}
 		})
 	}
@@ -437,3 +439,79 @@ func TestMapDumpModel_ErrorPriorityOverLoading(t *testing.T) {
 		t.Error("expected error to take priority over loading state")
 	}
 }
+
+func TestMapDumpModel_StartLoading(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	m.SetMapDump(1, "old_map", []MapEntry{{Key: []byte{0x01}, Value: []byte{0x02}}})
+	m.SetError(errors.New("old error"))
+
+	cmd := m.StartLoading(2, "new_map")
+
+	if cmd == nil {
+		t.Error("StartLoading should return a spinner command")
+	}
+	if !m.IsLoading() {
+		t.Error("expected loading after StartLoading")
+	}
+	if m.GetMapID() != 2 {
+		t.Errorf("map ID = %d, want 2", m.GetMapID())
+	}
+	if m.GetEntryCount() != 0 {
+		t.Errorf("entry count = %d, want 0", m.GetEntryCount())
+	}
+	if m.HasError() {
+		t.Error("StartLoading should clear the previous error")
+	}
+	if !strings.Contains(m.View(), "new_map") {
+		t.Error("title should show the map being loaded")
+	}
+}
+
+func TestMapDumpModel_RenderContentWithBTF(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	m.SetMapDump(1, "test_map", []MapEntry{
+		{Key: []byte{0x2a, 0, 0, 0}, Value: []byte{0x01, 0, 0, 0, 0x02, 0, 0, 0}},
+	})
+	m.SetBTF(&MapBTF{
+		Key: &BTFType{Kind: BTFKindInt, Size: 4},
+		Value: &BTFType{
+			Kind: BTFKindStruct,
+			Size: 8,
+			Members: []BTFMember{
+				{Name: "rx", Offset: 0, Type: &BTFType{Kind: BTFKindInt, Size: 4}},
+				{Name: "tx", Offset: 32, Type: &BTFType{Kind: BTFKindInt, Size: 4}},
+			},
+		},
+	})
+
+	content := m.renderContent()
+	if !strings.Contains(content, "42") {
+		t.Error("key should be decoded as an integer")
+	}
+	if !strings.Contains(content, `"rx": 1`) || !strings.Contains(content, `"tx": 2`) {
+		t.Errorf("value should be decoded as a struct, got:\n%s", content)
+	}
+
+	// Toggle to raw hex
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !m.IsShowingRaw() {
+		t.Fatal("expected raw mode after pressing 'x'")
+	}
+	content = m.renderContent()
+	if !strings.Contains(content, "2a 00 00 00") {
+		t.Error("raw mode should render the key as hex")
+	}
+	if strings.Contains(content, `"rx"`) {
+		t.Error("raw mode should not render decoded fields")
+	}
+}
+
+func TestMapDumpModel_ToggleRawWithoutBTF(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	m.SetMapDump(1, "test_map", []MapEntry{{Key: []byte{0x01}, Value: []byte{0x02}}})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.IsShowingRaw() {
+		t.Error("'x' should do nothing when the map has no BTF")
+	}
+}
@@ -0,0 +1,320 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mapEditOp identifies a write operation on a map entry.
+type mapEditOp int
+
+const (
+	mapEditUpdate mapEditOp = iota // Create or replace an entry
+	mapEditInsert                  // Create an entry that doesn't exist yet
+	mapEditDelete                  // Remove an entry
+)
+
+// mapEdit is a write to a map entry requested from the dump view.
+// The root model performs it through the MapsService.
+type mapEdit struct {
+	op    mapEditOp
+	key   []byte
+	value []byte // Unused for deletes
+}
+
+// editMode is the state of the entry editor in the dump view.
+type editMode int
+
+const (
+	editNone          editMode = iota
+	editValue                  // Editing the value of the selected entry
+	editInsertKey              // Entering the key of a new entry
+	editInsertValue            // Entering the value of a new entry
+	editConfirmDelete          // Confirming deletion of the selected entry
+	editExport                 // Entering the file to export the entries to
+	editSearch                 // Entering a pattern to search keys and values for
+)
+
+// editorHeight is the number of lines the editor dialog takes below the entries.
+const editorHeight = 4
+
+// parseHexBytes parses a hex byte string such as "0a 0b 0c", "0a0b0c" or
+// "0x0a0b0c". If size is non-zero, exactly that many bytes are required.
+func parseHexBytes(s string, size uint32) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	s = strings.Join(strings.Fields(s), "")
+	if s == "" {
+		return nil, fmt.Errorf("no bytes entered")
+	}
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("odd number of hex digits")
+	}
+
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	if size != 0 && uint32(len(data)) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(data))
+	}
+	return data, nil
+}
+
+// Placeholders shown in the editor's empty text input.
+const (
+	hexPlaceholder    = "hex bytes, e.g. 0a 0b 0c 0d"
+	exportPlaceholder = "file name ending in .json, .csv or .bin"
+)
+
+// newEditInput creates the text input used by the entry editor.
+func newEditInput(width int) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = hexPlaceholder
+	ti.Width = width - 4
+	return ti
+}
+
+// startEdit opens the editor on the value of the selected entry.
+func (m *mapDumpModel) startEdit() tea.Cmd {
+	entry := m.SelectedEntry()
+	if entry == nil {
+		return nil
+	}
+	m.input.SetValue(FormatHex(entry.Value))
+	m.input.CursorEnd()
+	return m.setEditMode(editValue)
+}
+
+// startInsert opens the editor for the key of a new entry.
+func (m *mapDumpModel) startInsert() tea.Cmd {
+	m.input.SetValue("")
+	m.pendingKey = nil
+	return m.setEditMode(editInsertKey)
+}
+
+// startDelete asks for confirmation before deleting the selected entry.
+func (m *mapDumpModel) startDelete() tea.Cmd {
+	if m.SelectedEntry() == nil {
+		return nil
+	}
+	return m.setEditMode(editConfirmDelete)
+}
+
+// setEditMode switches the editor mode, scrolling to keep the selected entry
+// visible above the dialog. Returns the text input's blink command when it
+// gains focus.
+func (m *mapDumpModel) setEditMode(mode editMode) tea.Cmd {
+	m.mode = mode
+	m.inputErr = ""
+	m.ensureCursorVisible()
+
+	switch mode {
+	case editValue, editInsertKey, editInsertValue:
+		m.input.Placeholder = hexPlaceholder
+		return m.input.Focus()
+	case editExport:
+		m.input.Placeholder = exportPlaceholder
+		return m.input.Focus()
+	case editSearch:
+		m.input.Placeholder = searchPlaceholder
+		return m.input.Focus()
+	default:
+		m.input.Blur()
+		return nil
+	}
+}
+
+// updateEditor handles messages while the editor is open.
+// Returns the requested edit once the user confirms it.
+func (m mapDumpModel) updateEditor(msg tea.Msg) (mapDumpModel, tea.Cmd, *mapEdit) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+
+	if m.mode == editConfirmDelete {
+		if !isKey {
+			return m, nil, nil
+		}
+		switch keyMsg.String() {
+		case "y", "Y":
+			entry := m.SelectedEntry()
+			m.setEditMode(editNone)
+			if entry == nil {
+				return m, nil, nil
+			}
+			return m, nil, &mapEdit{op: mapEditDelete, key: entry.Key}
+		case "n", "N", "esc":
+			m.setEditMode(editNone)
+		}
+		return m, nil, nil
+	}
+
+	if isKey {
+		switch keyMsg.String() {
+		case "esc":
+			m.setEditMode(editNone)
+			return m, nil, nil
+
+		case "enter":
+			return m.submitEditor()
+
+		case "tab":
+			if m.mode == editExport {
+				m.cycleExportFormat()
+				return m, nil, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd, nil
+}
+
+// submitEditor validates the input for the current mode and advances the editor.
+func (m mapDumpModel) submitEditor() (mapDumpModel, tea.Cmd, *mapEdit) {
+	switch m.mode {
+	case editValue:
+		value, err := parseHexBytes(m.input.Value(), m.editValueSize())
+		if err != nil {
+			m.inputErr = err.Error()
+			return m, nil, nil
+		}
+		entry := m.SelectedEntry()
+		m.setEditMode(editNone)
+		if entry == nil {
+			return m, nil, nil
+		}
+		return m, nil, &mapEdit{op: mapEditUpdate, key: entry.Key, value: value}
+
+	case editInsertKey:
+		key, err := parseHexBytes(m.input.Value(), m.keySize)
+		if err != nil {
+			m.inputErr = err.Error()
+			return m, nil, nil
+		}
+		m.pendingKey = key
+		m.input.SetValue("")
+		return m, m.setEditMode(editInsertValue), nil
+
+	case editInsertValue:
+		value, err := parseHexBytes(m.input.Value(), m.editValueSize())
+		if err != nil {
+			m.inputErr = err.Error()
+			return m, nil, nil
+		}
+		key := m.pendingKey
+		m.pendingKey = nil
+		m.setEditMode(editNone)
+		return m, nil, &mapEdit{op: mapEditInsert, key: key, value: value}
+
+	case editExport:
+		return m, m.submitExport(), nil
+
+	case editSearch:
+		return m, m.submitSearch(), nil
+	}
+
+	return m, nil, nil
+}
+
+// PutEntry sets the value of the loaded entry with key, adding the entry if
+// it isn't loaded. Added entries are skipped if their page is read later.
+func (m *mapDumpModel) PutEntry(key, value []byte) {
+	entries := slices.Clone(m.entries)
+	if i := m.indexOf(key); i >= 0 {
+		entries[i] = MapEntry{Key: key, Value: value}
+	} else {
+		entries = append(entries, MapEntry{Key: key, Value: value})
+		if m.iter != nil {
+			if m.inserted == nil {
+				m.inserted = make(map[string]bool)
+			}
+			m.inserted[string(key)] = true
+		}
+	}
+	m.SetMapDump(m.mapID, m.mapName, entries)
+}
+
+// RemoveEntry drops the loaded entry with key, if any.
+func (m *mapDumpModel) RemoveEntry(key []byte) {
+	i := m.indexOf(key)
+	if i < 0 {
+		return
+	}
+	delete(m.inserted, string(key))
+	m.SetMapDump(m.mapID, m.mapName, slices.Delete(slices.Clone(m.entries), i, i+1))
+}
+
+// indexOf returns the index of the loaded entry with key, or -1.
+func (m mapDumpModel) indexOf(key []byte) int {
+	return slices.IndexFunc(m.entries, func(e MapEntry) bool { return bytes.Equal(e.Key, key) })
+}
+
+// renderEditor renders the editor dialog shown below the entries.
+func (m mapDumpModel) renderEditor() string {
+	var prompt, hint string
+
+	switch m.mode {
+	case editValue:
+		prompt = "Edit value" + sizeHint(m.editValueSize())
+		hint = "enter: save • esc: cancel"
+	case editInsertKey:
+		prompt = "New entry key" + sizeHint(m.keySize)
+		hint = "enter: next • esc: cancel"
+	case editInsertValue:
+		prompt = fmt.Sprintf("Value for key %s", FormatHex(m.pendingKey)) + sizeHint(m.editValueSize())
+		hint = "enter: insert • esc: cancel"
+	case editConfirmDelete:
+		key := ""
+		if entry := m.SelectedEntry(); entry != nil {
+			key = FormatHex(entry.Key)
+		}
+		return "\n" + errorStyle.Render(fmt.Sprintf("Delete entry with key %s?", key)) + "\n\n" +
+			helpStyle.Render("y: delete • n/esc: cancel")
+	case editExport:
+		prompt = fmt.Sprintf("Export %d entries to file", len(m.entries))
+		if m.iter != nil {
+			// The export reads the rest of the map as well
+			prompt = "Export all entries to file"
+		}
+		if f, err := exportFormatFor(m.input.Value()); err == nil {
+			prompt += " (" + f.String() + ")"
+		}
+		hint = "enter: export • tab: change format • esc: cancel"
+	case editSearch:
+		prompt = "Search keys and values"
+		hint = "enter: search • empty: clear • esc: cancel"
+	default:
+		return ""
+	}
+
+	footer := helpStyle.Render(hint)
+	if m.inputErr != "" {
+		footer = errorStyle.Render("Error: " + m.inputErr)
+	}
+	return "\n" + titleStyle.Render(prompt) + "\n" + m.input.View() + "\n" + footer
+}
+
+// editValueSize returns the size of values entered in the editor. Per-CPU
+// values are entered as the values of all CPUs, back to back.
+func (m mapDumpModel) editValueSize() uint32 {
+	if n := m.cpuCount(); n > 0 {
+		return m.valueSize * uint32(n)
+	}
+	return m.valueSize
+}
+
+// sizeHint describes the expected input size for the editor prompt.
+func sizeHint(size uint32) string {
+	if size == 0 {
+		return " (hex)"
+	}
+	return fmt.Sprintf(" (%d bytes, hex)", size)
+}
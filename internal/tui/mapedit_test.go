@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseHexBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		size    uint32
+		want    []byte
+		wantErr bool
+	}{
+		{"space separated", "0a 0b 0c 0d", 4, []byte{0x0a, 0x0b, 0x0c, 0x0d}, false},
+		{"contiguous", "0a0b0c0d", 4, []byte{0x0a, 0x0b, 0x0c, 0x0d}, false},
+		{"0x prefix", "0x0a0B", 0, []byte{0x0a, 0x0b}, false},
+		{"surrounding whitespace", "  ff  ", 1, []byte{0xff}, false},
+		{"unknown size accepts any length", "01 02 03", 0, []byte{1, 2, 3}, false},
+		{"empty", "   ", 4, nil, true},
+		{"odd digits", "0a0", 0, nil, true},
+		{"invalid digit", "zz", 1, nil, true},
+		{"wrong size", "01 02", 4, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHexBytes(tt.input, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHexBytes(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("parseHexBytes(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// newEditableDump returns a dump model with two 4-byte entries.
+func newEditableDump() mapDumpModel {
+	m := newMapDumpModel(80, 24)
+	m.SetEntrySizes(4, 4)
+	m.SetMapDump(1, "test_map", []MapEntry{
+		{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0, 0, 0}},
+		{Key: []byte{2, 0, 0, 0}, Value: []byte{0x0b, 0, 0, 0}},
+	})
+	return m
+}
+
+// typeText feeds s to the model one rune at a time.
+func typeText(m mapDumpModel, s string) mapDumpModel {
+	for _, r := range s {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestMapDumpModel_CursorMovement(t *testing.T) {
+	m := newEditableDump()
+
+	if m.SelectedEntry() == nil || m.SelectedEntry().Key[0] != 1 {
+		t.Fatal("first entry should be selected initially")
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.SelectedEntry().Key[0] != 2 {
+		t.Error("down should select the second entry")
+	}
+
+	// Cursor stays in bounds
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.SelectedEntry().Key[0] != 2 {
+		t.Error("down on the last entry should keep it selected")
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.SelectedEntry().Key[0] != 1 {
+		t.Error("up should select the first entry")
+	}
+}
+
+func TestMapDumpModel_CursorClampedOnReload(t *testing.T) {
+	m := newEditableDump()
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	m.SetMapDump(1, "test_map", []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{0, 0, 0, 0}}})
+
+	if m.SelectedEntry() == nil || m.SelectedEntry().Key[0] != 1 {
+		t.Error("cursor should be clamped to the remaining entry")
+	}
+}
+
+func TestMapDumpModel_EditValue(t *testing.T) {
+	m := newEditableDump()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !m.IsEditing() {
+		t.Fatal("e should open the editor")
+	}
+	if m.input.Value() != "0a 00 00 00" {
+		t.Errorf("editor should be prefilled with the current value, got %q", m.input.Value())
+	}
+	if !containsString(m.View(), "Edit value (4 bytes, hex)") {
+		t.Error("view should show the edit prompt")
+	}
+
+	m.input.SetValue("")
+	m = typeText(m, "ff 00 00 00")
+
+	var edit *mapEdit
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if edit == nil {
+		t.Fatal("enter should confirm the edit")
+	}
+	if edit.op != mapEditUpdate || !bytes.Equal(edit.key, []byte{1, 0, 0, 0}) || !bytes.Equal(edit.value, []byte{0xff, 0, 0, 0}) {
+		t.Errorf("unexpected edit: %+v", edit)
+	}
+	if m.IsEditing() {
+		t.Error("editor should close after confirming")
+	}
+}
+
+func TestMapDumpModel_EditValueRejectsWrongSize(t *testing.T) {
+	m := newEditableDump()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m.input.SetValue("ff")
+
+	var edit *mapEdit
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if edit != nil {
+		t.Error("invalid input should not produce an edit")
+	}
+	if !m.IsEditing() {
+		t.Error("editor should stay open on invalid input")
+	}
+	if !containsString(m.View(), "expected 4 bytes, got 1") {
+		t.Error("view should show the validation error")
+	}
+}
+
+func TestMapDumpModel_EditCancelWithEsc(t *testing.T) {
+	m := newEditableDump()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+
+	var edit *mapEdit
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if edit != nil || m.IsEditing() {
+		t.Error("esc should close the editor without an edit")
+	}
+}
+
+func TestMapDumpModel_InsertEntry(t *testing.T) {
+	m := newEditableDump()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if !containsString(m.View(), "New entry key") {
+		t.Fatal("i should prompt for the new key")
+	}
+
+	m = typeText(m, "03000000")
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !containsString(m.View(), "Value for key 03 00 00 00") {
+		t.Fatal("entering a key should prompt for the value")
+	}
+
+	m = typeText(m, "0c000000")
+
+	var edit *mapEdit
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if edit == nil {
+		t.Fatal("entering a value should confirm the insert")
+	}
+	if edit.op != mapEditInsert || !bytes.Equal(edit.key, []byte{3, 0, 0, 0}) || !bytes.Equal(edit.value, []byte{0x0c, 0, 0, 0}) {
+		t.Errorf("unexpected edit: %+v", edit)
+	}
+}
+
+func TestMapDumpModel_DeleteRequiresConfirmation(t *testing.T) {
+	m := newEditableDump()
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !containsString(m.View(), "Delete entry with key 02 00 00 00?") {
+		t.Fatal("d should ask for confirmation")
+	}
+
+	// Declining keeps the entry
+	var edit *mapEdit
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if edit != nil || m.IsEditing() {
+		t.Fatal("n should cancel the delete")
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m, _, edit = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if edit == nil || edit.op != mapEditDelete || !bytes.Equal(edit.key, []byte{2, 0, 0, 0}) {
+		t.Errorf("y should confirm deleting the selected entry, got %+v", edit)
+	}
+}
+
+func TestMapDumpModel_NoEditingWhileLoading(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	m.StartLoading(1, "test_map")
+
+	for _, r := range "eid" {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		if m.IsEditing() {
+			t.Errorf("%q should not open the editor while loading", r)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tuning of the events view.
+const (
+	eventBufferSize  = 10000                  // Records kept for scrolling back
+	eventReadBatch   = 500                    // Most records read at a time
+	eventReadTimeout = 100 * time.Millisecond // How long a read waits for records
+)
+
+// isEventMapType returns true if maps of type t hold records written by BPF
+// programs for userspace to read, rather than entries to dump.
+func isEventMapType(t string) bool {
+	switch strings.ReplaceAll(strings.ToLower(t), "_", "") {
+	case "ringbuf", "perfeventarray":
+		return true
+	default:
+		return false
+	}
+}
+
+// mapEventsModel manages the view tailing a ring buffer or perf event array.
+type mapEventsModel struct {
+	mapID   uint32
+	mapName string
+	reader  MapEventReader // nil until attached and once stopped
+	events  []MapEvent     // Records shown, oldest first
+	pending []MapEvent     // Records read while paused
+	paused  bool
+	scroll  int // Lines scrolled up from the newest record; 0 follows new records
+
+	received uint64 // Records read, loss records excluded
+	lost     uint64 // Samples the kernel dropped, reported by perf event arrays
+	dropped  uint64 // Records discarded because the buffer filled up while paused
+
+	valueType *BTFType // Type records are decoded with, or nil for hex
+	typeName  string
+	showRaw   bool // Show raw hex even when a type is set
+	prompting bool // Reading the name of the type to decode records with
+	input     textinput.Model
+
+	width       int
+	height      int
+	loading     bool
+	spinner     spinner.Model
+	err         error
+	status      string // Result of the last type lookup
+	statusIsErr bool
+}
+
+// newMapEventsModel creates a new map events model.
+func newMapEventsModel(width, height int) mapEventsModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "type name, e.g. event; empty for hex"
+	input.Width = width - 4
+
+	return mapEventsModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+		input:   input,
+	}
+}
+
+// StartLoading prepares the view for tailing the given map, stopping and
+// clearing any previous one. The returned command starts the spinner.
+func (m *mapEventsModel) StartLoading(mapID uint32, mapName string) tea.Cmd {
+	m.Stop()
+	*m = mapEventsModel{
+		mapID:   mapID,
+		mapName: mapName,
+		width:   m.width,
+		height:  m.height,
+		spinner: m.spinner,
+		input:   m.input,
+		loading: true,
+	}
+	m.input.Blur()
+	m.input.SetValue("")
+	return m.spinner.Tick
+}
+
+// SetReader starts showing the records read by reader. The returned command
+// reads the first batch.
+func (m *mapEventsModel) SetReader(reader MapEventReader) tea.Cmd {
+	m.Stop()
+	m.loading = false
+	m.err = nil
+	m.reader = reader
+	return readEventsCmd(reader)
+}
+
+// SetError sets an error state for the view.
+func (m *mapEventsModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// Stop closes the reader. Records already read are kept.
+func (m *mapEventsModel) Stop() {
+	if m.reader != nil {
+		m.reader.Close()
+	}
+	m.reader = nil
+}
+
+// AddEvents adds records read from reader and returns the command reading
+// the next batch. Records from a reader that was stopped are discarded.
+// While paused, records are held back until resuming, and those beyond the
+// buffer size are dropped.
+func (m *mapEventsModel) AddEvents(reader MapEventReader, events []MapEvent, err error) tea.Cmd {
+	if reader != m.reader {
+		return nil
+	}
+
+	added := 0
+	for _, e := range events {
+		m.lost += e.Lost
+		if e.Data != nil {
+			m.received++
+		}
+		switch {
+		case !m.paused:
+			m.events = append(m.events, e)
+			added++
+		case len(m.pending) < eventBufferSize:
+			m.pending = append(m.pending, e)
+		default:
+			m.dropped++
+		}
+	}
+	m.trim()
+	if m.scroll > 0 {
+		// Keep the records on screen in place
+		m.scroll = min(m.scroll+added, m.maxScroll())
+	}
+
+	if err != nil {
+		m.Stop()
+		m.err = err
+		return nil
+	}
+	return readEventsCmd(reader)
+}
+
+// trim discards the oldest records beyond the buffer size.
+func (m *mapEventsModel) trim() {
+	if n := len(m.events) - eventBufferSize; n > 0 {
+		m.events = slices.Clone(m.events[n:])
+	}
+}
+
+// togglePause pauses or resumes showing new records. On resuming, the
+// records read while paused are added and the view follows new records.
+func (m *mapEventsModel) togglePause() {
+	m.paused = !m.paused
+	if !m.paused {
+		m.events = append(m.events, m.pending...)
+		m.pending = nil
+		m.trim()
+		m.scroll = 0
+	}
+}
+
+// SetEventType sets the type records are decoded with, as looked up for
+// name. A nil type with no error decodes records as hex.
+func (m *mapEventsModel) SetEventType(name string, t *BTFType, err error) {
+	if err != nil {
+		m.status = err.Error()
+		m.statusIsErr = true
+		return
+	}
+	m.valueType = t
+	m.typeName = name
+	m.showRaw = false
+	m.status = ""
+	if t != nil {
+		m.status = fmt.Sprintf("Decoding records as %s", name)
+		m.statusIsErr = false
+	}
+}
+
+// SetSize updates the view dimensions.
+func (m *mapEventsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = width - 4
+	m.scroll = min(m.scroll, m.maxScroll())
+}
+
+// viewportHeight returns the number of record lines shown, leaving room for
+// the title, counters, help bar and, while prompting, the type prompt.
+func (m mapEventsModel) viewportHeight() int {
+	h := m.height - 5
+	if m.prompting {
+		h -= 3
+	}
+	return max(h, 1)
+}
+
+// maxScroll returns how far the view can scroll up from the newest record.
+func (m mapEventsModel) maxScroll() int {
+	return max(len(m.events)-m.viewportHeight(), 0)
+}
+
+// Init implements tea.Model for mapEventsModel.
+func (m mapEventsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the events view. Returns a non-nil type name
+// when the user asks to decode records with another type.
+func (m mapEventsModel) Update(msg tea.Msg) (mapEventsModel, tea.Cmd, *string) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while the reader is being attached
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+	}
+
+	if m.prompting {
+		return m.updatePrompt(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case " ", "p":
+			m.togglePause()
+
+		case "c":
+			// Clear the buffer; the counters keep running
+			m.events = nil
+			m.pending = nil
+			m.scroll = 0
+
+		case "x":
+			if m.valueType != nil {
+				m.showRaw = !m.showRaw
+			}
+
+		case "t":
+			if m.loading || m.err != nil {
+				return m, nil, nil
+			}
+			m.prompting = true
+			m.input.SetValue(m.typeName)
+			m.input.CursorEnd()
+			return m, m.input.Focus(), nil
+
+		case "up", "k":
+			m.scroll = min(m.scroll+1, m.maxScroll())
+
+		case "down", "j":
+			m.scroll = max(m.scroll-1, 0)
+
+		case "pgup", "b":
+			m.scroll = min(m.scroll+m.viewportHeight(), m.maxScroll())
+
+		case "pgdown", "f":
+			m.scroll = max(m.scroll-m.viewportHeight(), 0)
+
+		case "home", "g":
+			m.scroll = m.maxScroll()
+
+		case "end", "G":
+			m.scroll = 0
+		}
+	}
+	return m, nil, nil
+}
+
+// updatePrompt handles messages while reading the name of the type to
+// decode records with.
+func (m mapEventsModel) updatePrompt(msg tea.Msg) (mapEventsModel, tea.Cmd, *string) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.prompting = false
+			m.input.Blur()
+			return m, nil, nil
+
+		case "enter":
+			m.prompting = false
+			m.input.Blur()
+			name := strings.TrimSpace(m.input.Value())
+			if name == "" {
+				m.SetEventType("", nil, nil)
+				return m, nil, nil
+			}
+			return m, nil, &name
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd, nil
+}
+
+// renderEvent renders a record as a single line: the time it was read, the
+// CPU it was written on for perf event arrays, its size and its payload.
+func (m mapEventsModel) renderEvent(e MapEvent) string {
+	var b strings.Builder
+	b.WriteString(dimStyle.Render(e.Time.Format("15:04:05.000000")))
+	if e.CPU >= 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  cpu %-3d", e.CPU)))
+	}
+
+	if e.Data == nil {
+		b.WriteString("  ")
+		b.WriteString(errorStyle.Render(fmt.Sprintf("lost %d samples", e.Lost)))
+		return b.String()
+	}
+
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %4d B  ", len(e.Data))))
+	payload := FormatHex(e.Data)
+	if m.valueType != nil && !m.showRaw {
+		// Nested values on a single line
+		payload = strings.Join(strings.Fields(formatBTF(m.valueType, e.Data)), " ")
+	}
+	b.WriteString(valueStyle.Render(payload))
+	return b.String()
+}
+
+// renderContent renders the records that fit in the view, newest last.
+func (m mapEventsModel) renderContent() string {
+	if m.loading {
+		return m.spinner.View() + dimStyle.Render(" Attaching reader...")
+	}
+	if m.err != nil && len(m.events) == 0 {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+	if len(m.events) == 0 {
+		return dimStyle.Render("Waiting for records...")
+	}
+
+	end := len(m.events) - m.scroll
+	start := max(end-m.viewportHeight(), 0)
+	line := lipgloss.NewStyle().MaxWidth(m.width)
+	lines := make([]string, 0, end-start)
+	for _, e := range m.events[start:end] {
+		lines = append(lines, line.Render(m.renderEvent(e)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCounters renders the record counters and the state of the view.
+func (m mapEventsModel) renderCounters() string {
+	parts := []string{
+		fmt.Sprintf("received %d", m.received),
+		fmt.Sprintf("lost %d", m.lost),
+		fmt.Sprintf("dropped %d", m.dropped),
+		fmt.Sprintf("buffered %d/%d", len(m.events), eventBufferSize),
+	}
+	counters := dimStyle.Render(strings.Join(parts, "  "))
+
+	var state string
+	switch {
+	case m.reader == nil:
+		state = dimStyle.Render("stopped")
+	case m.paused:
+		state = changedStyle.Render(fmt.Sprintf("PAUSED (%d pending)", len(m.pending)))
+	case m.scroll > 0:
+		state = dimStyle.Render(fmt.Sprintf("scrolled up %d", m.scroll))
+	default:
+		state = addedStyle.Render("following")
+	}
+	return counters + "  " + state
+}
+
+// View renders the events view.
+func (m mapEventsModel) View() string {
+	var title string
+	if m.mapName != "" {
+		title = titleStyle.Render(fmt.Sprintf("Tail Events: %s (ID: %d)", m.mapName, m.mapID))
+	} else {
+		title = titleStyle.Render(fmt.Sprintf("Tail Events: ID %d", m.mapID))
+	}
+
+	switch {
+	case m.err != nil && len(m.events) > 0:
+		title += "  " + errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	case m.status != "" && m.statusIsErr:
+		title += "  " + errorStyle.Render(m.status)
+	case m.status != "":
+		title += "  " + addedStyle.Render(m.status)
+	}
+
+	view := title + "\n" + m.renderCounters() + "\n\n" + m.renderContent()
+	if m.prompting {
+		view += "\n\n" + titleStyle.Render("Decode records as type") + "\n" + m.input.View()
+	}
+	return view
+}
+
+// IsPrompting returns true while the name of a type is being typed.
+func (m mapEventsModel) IsPrompting() bool {
+	return m.prompting
+}
+
+// IsPaused returns true while new records are held back.
+func (m mapEventsModel) IsPaused() bool {
+	return m.paused
+}
+
+// IsDecoding returns true if records are decoded with a BTF type.
+func (m mapEventsModel) IsDecoding() bool {
+	return m.valueType != nil
+}
+
+// GetMapID returns the ID of the map being tailed.
+func (m mapEventsModel) GetMapID() uint32 {
+	return m.mapID
+}
+
+// GetEventCount returns the number of records in the buffer.
+func (m mapEventsModel) GetEventCount() int {
+	return len(m.events)
+}
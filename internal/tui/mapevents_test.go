@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeEventReader returns its queued batches of records, one per Read.
+type fakeEventReader struct {
+	batches [][]MapEvent
+	closed  bool
+}
+
+func (r *fakeEventReader) Read(n int, timeout time.Duration) ([]MapEvent, error) {
+	if len(r.batches) == 0 {
+		return nil, nil
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+func (r *fakeEventReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+// mockMapsServiceWithEvents tails its maps with a fakeEventReader.
+type mockMapsServiceWithEvents struct {
+	mockMapsServiceWithDump
+	reader *fakeEventReader
+	types  map[string]*BTFType
+}
+
+func (s *mockMapsServiceWithEvents) Tail(id uint32) (MapEventReader, error) {
+	return s.reader, nil
+}
+
+func (s *mockMapsServiceWithEvents) EventType(id uint32, name string) (*BTFType, error) {
+	if t, ok := s.types[name]; ok {
+		return t, nil
+	}
+	return nil, errors.New("no type named " + name)
+}
+
+// sampleEvent returns a ring buffer record holding a 4-byte counter.
+func sampleEvent(n byte) MapEvent {
+	return MapEvent{Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local), CPU: -1, Data: []byte{n, 0, 0, 0}}
+}
+
+func TestIsEventMapType(t *testing.T) {
+	for _, typ := range []string{"ringbuf", "perf_event_array", "perfeventarray"} {
+		if !isEventMapType(typ) {
+			t.Errorf("%q should have records to tail", typ)
+		}
+	}
+	if isEventMapType("hash") {
+		t.Error("hash maps have no records to tail")
+	}
+}
+
+func TestMapEventsModel_AddEvents(t *testing.T) {
+	m := newMapEventsModel(80, 24)
+	m.StartLoading(1, "events")
+	reader := &fakeEventReader{}
+	m.SetReader(reader)
+
+	if cmd := m.AddEvents(reader, []MapEvent{sampleEvent(1), {CPU: 2, Lost: 3}}, nil); cmd == nil {
+		t.Error("expected the next batch to be read")
+	}
+	if m.GetEventCount() != 2 || m.received != 1 || m.lost != 3 {
+		t.Errorf("got %d events, %d received, %d lost", m.GetEventCount(), m.received, m.lost)
+	}
+	view := m.View()
+	for _, want := range []string{"Tail Events: events (ID: 1)", "12:00:00.000000", "01 00 00 00", "lost 3 samples", "following"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Records of a stopped reader are discarded
+	if cmd := m.AddEvents(&fakeEventReader{}, []MapEvent{sampleEvent(2)}, nil); cmd != nil || m.GetEventCount() != 2 {
+		t.Error("records from another reader should be ignored")
+	}
+
+	// Read errors stop tailing
+	m.AddEvents(reader, nil, errors.New("bad file descriptor"))
+	if !reader.closed || !containsString(m.View(), "bad file descriptor") {
+		t.Errorf("a read error should close the reader:\n%s", m.View())
+	}
+}
+
+func TestMapEventsModel_Pause(t *testing.T) {
+	m := newMapEventsModel(80, 24)
+	reader := &fakeEventReader{}
+	m.SetReader(reader)
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if !m.IsPaused() {
+		t.Fatal("space should pause")
+	}
+	events := make([]MapEvent, eventBufferSize+5)
+	for i := range events {
+		events[i] = sampleEvent(byte(i))
+	}
+	m.AddEvents(reader, events, nil)
+	if m.GetEventCount() != 0 || m.dropped != 5 {
+		t.Errorf("paused records should be held back, got %d shown and %d dropped", m.GetEventCount(), m.dropped)
+	}
+	if !containsString(m.View(), "PAUSED") {
+		t.Errorf("view should show that tailing is paused:\n%s", m.View())
+	}
+
+	// Resuming adds the held back records
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if m.IsPaused() || m.GetEventCount() != eventBufferSize {
+		t.Errorf("expected %d records after resuming, got %d", eventBufferSize, m.GetEventCount())
+	}
+
+	// The oldest records make room for new ones
+	m.AddEvents(reader, []MapEvent{sampleEvent(1)}, nil)
+	if m.GetEventCount() != eventBufferSize {
+		t.Errorf("buffer should stay at %d records, got %d", eventBufferSize, m.GetEventCount())
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.GetEventCount() != 0 || m.received != eventBufferSize+6 {
+		t.Errorf("c should clear the buffer but keep counting, got %d events and %d received", m.GetEventCount(), m.received)
+	}
+}
+
+func TestMapEventsModel_DecodeAsType(t *testing.T) {
+	m := newMapEventsModel(80, 24)
+	reader := &fakeEventReader{}
+	m.SetReader(reader)
+	m.AddEvents(reader, []MapEvent{sampleEvent(7)}, nil)
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !m.IsPrompting() {
+		t.Fatal("t should prompt for a type name")
+	}
+	m.input.SetValue("event")
+	var name *string
+	m, _, name = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if name == nil || *name != "event" {
+		t.Fatalf("expected a lookup of type event, got %v", name)
+	}
+
+	m.SetEventType("event", &BTFType{Kind: BTFKindStruct, Name: "event", Size: 4, Members: []BTFMember{
+		{Name: "pid", Type: &BTFType{Kind: BTFKindInt, Name: "__u32", Size: 4}},
+	}}, nil)
+	if view := m.View(); !containsString(view, "pid") || !containsString(view, "7") {
+		t.Errorf("records should be decoded:\n%s", view)
+	}
+
+	// x shows the raw bytes again
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !containsString(m.View(), "07 00 00 00") {
+		t.Errorf("x should show records as hex:\n%s", m.View())
+	}
+}
+
+// TestIntegrationMapEvents tests tailing a ring buffer from the map detail
+// view, and that leaving the view closes the reader.
+func TestIntegrationMapEvents(t *testing.T) {
+	svc := &mockMapsServiceWithEvents{
+		mockMapsServiceWithDump: mockMapsServiceWithDump{
+			maps: []MapInfo{{ID: 3, Name: "events", Type: "ringbuf", MaxEntries: 4096}},
+		},
+		reader: &fakeEventReader{batches: [][]MapEvent{{sampleEvent(1), sampleEvent(2)}}},
+	}
+	m := openMapDump(NewModel(&mockProgService{}, svc))
+	if !m.mapDetail.IsConfirmingTail() {
+		t.Fatal("tailing should be confirmed first")
+	}
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.state != ViewMapEvents {
+		t.Fatalf("expected the events view, got %v", m.state)
+	}
+
+	// Read the first batch
+	m = updateAndRun(m, readEventsCmd(svc.reader)())
+	if m.mapEvents.GetEventCount() != 2 {
+		t.Errorf("expected 2 records, got %d", m.mapEvents.GetEventCount())
+	}
+	if view := m.View(); !containsString(view, "received 2") || !strings.Contains(view, "space: pause") {
+		t.Errorf("view should count records and show its keys:\n%s", view)
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapDetail || !svc.reader.closed {
+		t.Errorf("esc should go back and close the reader, state %v, closed %v", m.state, svc.reader.closed)
+	}
+}
+
+// TestIntegrationMapEventsStaleReader tests that a reader attached after the
+// user left the view is closed.
+func TestIntegrationMapEventsStaleReader(t *testing.T) {
+	svc := &mockMapsServiceWithEvents{
+		mockMapsServiceWithDump: mockMapsServiceWithDump{
+			maps: []MapInfo{{ID: 3, Name: "events", Type: "perf_event_array"}},
+		},
+		reader: &fakeEventReader{},
+	}
+	m := NewModel(&mockProgService{}, svc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // MapList → MapDetail
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Tail Events → confirmation
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	for _, msg := range runCmd(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !svc.reader.closed {
+		t.Error("a reader attached after leaving the view should be closed")
+	}
+}
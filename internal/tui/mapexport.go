@@ -0,0 +1,438 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportFormat is a file format map entries can be exported to.
+type exportFormat int
+
+const (
+	exportJSON   exportFormat = iota // Array of entries with hex and BTF-decoded fields
+	exportCSV                        // key,value rows of hex strings
+	exportBinary                     // Length-prefixed keys and values
+)
+
+// exportFormats lists the formats in the order Tab cycles through them.
+var exportFormats = []exportFormat{exportJSON, exportCSV, exportBinary}
+
+// String returns the format's name.
+func (f exportFormat) String() string {
+	switch f {
+	case exportCSV:
+		return "CSV"
+	case exportBinary:
+		return "binary"
+	default:
+		return "JSON"
+	}
+}
+
+// extension returns the file extension for the format.
+func (f exportFormat) extension() string {
+	switch f {
+	case exportCSV:
+		return ".csv"
+	case exportBinary:
+		return ".bin"
+	default:
+		return ".json"
+	}
+}
+
+// exportFormatFor picks the format from a file name's extension.
+func exportFormatFor(path string) (exportFormat, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, f := range exportFormats {
+		if f.extension() == ext {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown file extension %q, use .json, .csv or .bin", ext)
+}
+
+// defaultExportName suggests a file name for exporting a map.
+func defaultExportName(mapID uint32, mapName string) string {
+	name := fmt.Sprintf("map-%d", mapID)
+	if mapName != "" {
+		name += "-" + strings.Map(func(r rune) rune {
+			if r == '/' || r == ' ' {
+				return '_'
+			}
+			return r
+		}, mapName)
+	}
+	return name + exportJSON.extension()
+}
+
+// withExtension replaces the extension of path with that of format f.
+func withExtension(path string, f exportFormat) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + f.extension()
+}
+
+// exportEntries writes entries to w in the given format. For JSON, keys and
+// values are decoded with keyType and valueType when they are non-nil.
+func exportEntries(w io.Writer, f exportFormat, entries []MapEntry, keyType, valueType *BTFType) error {
+	_, err := exportIterator(w, f, &entriesIterator{entries: entries}, keyType, valueType)
+	return err
+}
+
+// exportIterator writes the entries read from iter to w a page at a time, so
+// large maps are never held in memory, and returns how many were written.
+func exportIterator(w io.Writer, f exportFormat, iter MapIterator, keyType, valueType *BTFType) (int, error) {
+	enc := &entryEncoder{w: w, format: f, keyType: keyType, valueType: valueType}
+	if err := enc.begin(); err != nil {
+		return 0, err
+	}
+	for {
+		page, err := iter.Next(dumpPageSize)
+		if err != nil {
+			return enc.count, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, e := range page {
+			if err := enc.encode(e); err != nil {
+				return enc.count, err
+			}
+		}
+	}
+	return enc.count, enc.end()
+}
+
+// entryEncoder writes map entries in an export format one at a time.
+type entryEncoder struct {
+	w                  io.Writer
+	format             exportFormat
+	keyType, valueType *BTFType
+	csv                *csv.Writer
+	count              int // Entries written so far
+}
+
+// begin writes what precedes the first entry.
+func (e *entryEncoder) begin() error {
+	switch e.format {
+	case exportCSV:
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write([]string{"key", "value"})
+	case exportJSON:
+		_, err := io.WriteString(e.w, "[")
+		return err
+	default:
+		return nil
+	}
+}
+
+// encode writes a single entry.
+func (e *entryEncoder) encode(entry MapEntry) error {
+	e.count++
+	switch e.format {
+	case exportCSV:
+		return e.csv.Write([]string{hex.EncodeToString(entry.Key), hex.EncodeToString(entry.Value)})
+
+	case exportBinary:
+		// Each entry is the key length, key, value length and value, with
+		// lengths as little-endian uint32s
+		for _, data := range [][]byte{entry.Key, entry.Value} {
+			if err := binary.Write(e.w, binary.LittleEndian, uint32(len(data))); err != nil {
+				return err
+			}
+			if _, err := e.w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		type formatted struct {
+			Key   any `json:"key,omitempty"`
+			Value any `json:"value,omitempty"`
+		}
+		out := struct {
+			Key       []string   `json:"key"`
+			Value     []string   `json:"value"`
+			Formatted *formatted `json:"formatted,omitempty"`
+		}{Key: hexStrings(entry.Key), Value: hexStrings(entry.Value)}
+		if e.keyType != nil || e.valueType != nil {
+			out.Formatted = &formatted{}
+			if e.keyType != nil {
+				out.Formatted.Key = btfJSON(e.keyType, entry.Key)
+			}
+			if e.valueType != nil {
+				out.Formatted.Value = btfJSON(e.valueType, entry.Value)
+			}
+		}
+
+		// Indented as an element of a JSON array
+		data, err := json.MarshalIndent(out, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if e.count == 1 {
+			sep = "\n  "
+		}
+		if _, err := io.WriteString(e.w, sep); err != nil {
+			return err
+		}
+		_, err = e.w.Write(data)
+		return err
+	}
+}
+
+// end writes what follows the last entry.
+func (e *entryEncoder) end() error {
+	switch e.format {
+	case exportCSV:
+		e.csv.Flush()
+		return e.csv.Error()
+	case exportJSON:
+		end := "\n]\n"
+		if e.count == 0 {
+			end = "]\n"
+		}
+		_, err := io.WriteString(e.w, end)
+		return err
+	default:
+		return nil
+	}
+}
+
+// exportToFile writes the entries read from iter to the file at path,
+// replacing it if it exists, and returns how many were written.
+func exportToFile(path string, f exportFormat, iter MapIterator, keyType, valueType *BTFType) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	w := bufio.NewWriter(file)
+	count, err := exportIterator(w, f, iter, keyType, valueType)
+	if err != nil {
+		file.Close()
+		return count, err
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return count, err
+	}
+	return count, file.Close()
+}
+
+// hexStrings formats data as bpftool-style "0x.." strings.
+func hexStrings(data []byte) []string {
+	s := make([]string, len(data))
+	for i, b := range data {
+		s[i] = fmt.Sprintf("0x%02x", b)
+	}
+	return s
+}
+
+// jsonField is a member of a jsonObject.
+type jsonField struct {
+	Name  string
+	Value any
+}
+
+// jsonObject is a JSON object that keeps its fields in order, so decoded
+// structs list their members in declaration order.
+type jsonObject []jsonField
+
+// MarshalJSON implements json.Marshaler.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, f := range o {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// btfJSON decodes data as type t into a value that encodes to JSON, the
+// machine-readable counterpart of formatBTF. Values that can't be decoded
+// are given as hex strings.
+func btfJSON(t *BTFType, data []byte) any {
+	if uint32(len(data)) < t.Size {
+		return FormatHex(data)
+	}
+
+	switch t.Kind {
+	case BTFKindInt:
+		return btfJSONInt(t, readUint(data, t.Size))
+
+	case BTFKindFloat:
+		var f float64
+		switch t.Size {
+		case 4:
+			f = float64(math.Float32frombits(uint32(readUint(data, 4))))
+		case 8:
+			f = math.Float64frombits(readUint(data, 8))
+		default:
+			return FormatHex(data[:t.Size])
+		}
+		// JSON has no NaN or infinity
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Sprintf("%g", f)
+		}
+		return f
+
+	case BTFKindPointer:
+		return fmt.Sprintf("0x%x", readUint(data, t.Size))
+
+	case BTFKindEnum:
+		return btfJSONEnum(t, readUint(data, t.Size))
+
+	case BTFKindArray:
+		elem := t.Elem
+		if elem == nil {
+			return FormatHex(data[:t.Size])
+		}
+		if elem.Kind == BTFKindInt && elem.Size == 1 && elem.Char {
+			str := data[:t.Len]
+			if i := bytes.IndexByte(str, 0); i >= 0 {
+				str = str[:i]
+			}
+			return string(str)
+		}
+		arr := make([]any, t.Len)
+		for i := range arr {
+			arr[i] = btfJSON(elem, data[uint32(i)*elem.Size:])
+		}
+		return arr
+
+	case BTFKindStruct, BTFKindUnion:
+		obj := make(jsonObject, 0, len(t.Members))
+		for _, mem := range t.Members {
+			f := jsonField{Name: mem.Name}
+			if f.Name == "" {
+				f.Name = "(anon)"
+			}
+			switch {
+			case mem.Type == nil:
+			case mem.BitfieldSize > 0:
+				v := readBits(data, mem.Offset, mem.BitfieldSize)
+				if mem.Type.Kind == BTFKindEnum {
+					f.Value = btfJSONEnum(mem.Type, v)
+				} else {
+					if mem.Type.Signed {
+						v = signExtend(v, mem.BitfieldSize)
+					}
+					f.Value = btfJSONInt(mem.Type, v)
+				}
+			case mem.Offset/8 <= uint32(len(data)):
+				f.Value = btfJSON(mem.Type, data[mem.Offset/8:])
+			}
+			obj = append(obj, f)
+		}
+		return obj
+
+	default:
+		return FormatHex(data[:t.Size])
+	}
+}
+
+// btfJSONInt converts an integer value according to its encoding.
+func btfJSONInt(t *BTFType, v uint64) any {
+	switch {
+	case t.Bool:
+		return v != 0
+	case t.Signed:
+		return int64(signExtend(v, t.Size*8))
+	default:
+		return v
+	}
+}
+
+// btfJSONEnum converts an enum value to its name, falling back to its number.
+func btfJSONEnum(t *BTFType, v uint64) any {
+	if t.Signed {
+		v = signExtend(v, t.Size*8)
+	}
+	for _, ev := range t.Values {
+		if ev.Value == v {
+			return ev.Name
+		}
+	}
+	if t.Signed {
+		return int64(v)
+	}
+	return v
+}
+
+// startExport opens the editor for the name of the file to export to.
+func (m *mapDumpModel) startExport() tea.Cmd {
+	m.input.SetValue(defaultExportName(m.mapID, m.mapName))
+	m.input.CursorEnd()
+	return m.setEditMode(editExport)
+}
+
+// cycleExportFormat switches the file name in the export prompt to the next
+// format's extension.
+func (m *mapDumpModel) cycleExportFormat() {
+	next := exportJSON
+	if f, err := exportFormatFor(m.input.Value()); err == nil {
+		next = exportFormats[(int(f)+1)%len(exportFormats)]
+	}
+	m.input.SetValue(withExtension(m.input.Value(), next))
+	m.input.CursorEnd()
+	m.inputErr = ""
+}
+
+// submitExport validates the file name and returns the command writing the
+// map's entries to it. If only some entries are loaded, the map is read again
+// from the start. JSON exports are BTF-decoded unless raw hex is shown.
+func (m *mapDumpModel) submitExport() tea.Cmd {
+	path := strings.TrimSpace(m.input.Value())
+	if path == "" {
+		m.inputErr = "no file name entered"
+		return nil
+	}
+	format, err := exportFormatFor(path)
+	if err != nil {
+		m.inputErr = err.Error()
+		return nil
+	}
+
+	var keyType, valueType *BTFType
+	if m.btf != nil && !m.showRaw {
+		keyType, valueType = m.btf.Key, m.btf.Value
+	}
+	if n := m.cpuCount(); n > 0 && valueType != nil {
+		// Per-CPU values are decoded as an array with an element per CPU
+		valueType = &BTFType{Kind: BTFKindArray, Size: valueType.Size * uint32(n), Elem: valueType, Len: uint32(n)}
+	}
+	open := m.reopen
+	if m.iter == nil || open == nil {
+		entries := m.entries
+		open = func() (MapIterator, error) { return &entriesIterator{entries: entries}, nil }
+	}
+	m.setEditMode(editNone)
+	return exportMapCmd(path, format, open, keyType, valueType)
+}
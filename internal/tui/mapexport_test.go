@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportFormatFor(t *testing.T) {
+	tests := map[string]exportFormat{
+		"dump.json":      exportJSON,
+		"dump.CSV":       exportCSV,
+		"/tmp/dump.bin":  exportBinary,
+		"map-1.tar.json": exportJSON,
+	}
+	for path, want := range tests {
+		got, err := exportFormatFor(path)
+		if err != nil || got != want {
+			t.Errorf("exportFormatFor(%q) = %v, %v, want %v", path, got, err, want)
+		}
+	}
+
+	for _, path := range []string{"dump", "dump.txt"} {
+		if _, err := exportFormatFor(path); err == nil {
+			t.Errorf("exportFormatFor(%q) should fail", path)
+		}
+	}
+}
+
+func TestDefaultExportName(t *testing.T) {
+	if got := defaultExportName(7, "conn track"); got != "map-7-conn_track.json" {
+		t.Errorf("defaultExportName() = %q", got)
+	}
+	if got := defaultExportName(7, ""); got != "map-7.json" {
+		t.Errorf("defaultExportName() without name = %q", got)
+	}
+}
+
+var exportTestEntries = []MapEntry{
+	{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0x0b}},
+	{Key: []byte{2, 0, 0, 0}, Value: []byte{}},
+}
+
+func TestExportEntriesCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := exportEntries(&b, exportCSV, exportTestEntries, nil, nil); err != nil {
+		t.Fatalf("exportEntries() error = %v", err)
+	}
+	want := "key,value\n01000000,0a0b\n02000000,\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestExportEntriesBinary(t *testing.T) {
+	var b bytes.Buffer
+	if err := exportEntries(&b, exportBinary, exportTestEntries, nil, nil); err != nil {
+		t.Fatalf("exportEntries() error = %v", err)
+	}
+	want := []byte{
+		4, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 0x0a, 0x0b,
+		4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("binary = %v, want %v", b.Bytes(), want)
+	}
+}
+
+func TestExportEntriesJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := exportEntries(&b, exportJSON, exportTestEntries[:1], nil, nil); err != nil {
+		t.Fatalf("exportEntries() error = %v", err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if len(got) != 1 || got[0]["formatted"] != nil {
+		t.Fatalf("entries = %v, want one hex-only entry", got)
+	}
+	if value := got[0]["value"].([]any); len(value) != 2 || value[1] != "0x0b" {
+		t.Errorf("value = %v", value)
+	}
+}
+
+func TestExportEntriesJSONWithBTF(t *testing.T) {
+	value := &BTFType{
+		Kind: BTFKindStruct,
+		Size: 12,
+		Members: []BTFMember{
+			{Name: "pid", Offset: 0, Type: btfU32},
+			{Name: "comm", Offset: 32, Type: &BTFType{Kind: BTFKindArray, Size: 4, Elem: btfChar, Len: 4}},
+			{Name: "state", Offset: 64, Type: &BTFType{Kind: BTFKindEnum, Size: 4, Values: []BTFEnumValue{{Name: "RUNNING", Value: 1}}}},
+		},
+	}
+	data := []byte{0xe8, 0x03, 0, 0, 's', 's', 'h', 0, 1, 0, 0, 0}
+
+	var b bytes.Buffer
+	entries := []MapEntry{{Key: []byte{0xff, 0xff}, Value: data}}
+	if err := exportEntries(&b, exportJSON, entries, btfS16, value); err != nil {
+		t.Fatalf("exportEntries() error = %v", err)
+	}
+
+	var got []struct {
+		Formatted struct {
+			Key   int64          `json:"key"`
+			Value map[string]any `json:"value"`
+		} `json:"formatted"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	f := got[0].Formatted
+	if f.Key != -1 || f.Value["pid"] != 1000.0 || f.Value["comm"] != "ssh" || f.Value["state"] != "RUNNING" {
+		t.Errorf("formatted = %+v", f)
+	}
+
+	// Members keep their declaration order
+	out := b.String()
+	if !(strings.Index(out, `"pid"`) < strings.Index(out, `"comm"`) && strings.Index(out, `"comm"`) < strings.Index(out, `"state"`)) {
+		t.Errorf("members out of order:\n%s", out)
+	}
+}
+
+func TestExportToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.csv")
+	count, err := exportToFile(path, exportCSV, &entriesIterator{entries: exportTestEntries}, nil, nil)
+	if err != nil || count != 2 {
+		t.Fatalf("exportToFile() = %d, %v", count, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "key,value\n") {
+		t.Errorf("file contents = %q", data)
+	}
+
+	if _, err := exportToFile(filepath.Join(t.TempDir(), "missing", "dump.csv"), exportCSV, &entriesIterator{}, nil, nil); err == nil {
+		t.Error("exporting into a missing directory should fail")
+	}
+}
+
+func TestMapDumpModel_Export(t *testing.T) {
+	m := newEditableDump()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !m.IsEditing() {
+		t.Fatal("s should open the export prompt")
+	}
+	if m.input.Value() != "map-1-test_map.json" {
+		t.Errorf("export prompt should suggest a file name, got %q", m.input.Value())
+	}
+	if !containsString(m.View(), "Export 2 entries to file (JSON)") {
+		t.Error("view should show the export prompt and format")
+	}
+
+	// Tab cycles the format through the extension
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.input.Value() != "map-1-test_map.csv" {
+		t.Errorf("tab should switch to CSV, got %q", m.input.Value())
+	}
+
+	path := filepath.Join(t.TempDir(), "out.bin")
+	m.input.SetValue(path)
+	var cmd tea.Cmd
+	m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsEditing() || cmd == nil {
+		t.Fatal("enter should close the prompt and start the export")
+	}
+
+	msg, ok := cmd().(mapExportedMsg)
+	if !ok || msg.err != nil || msg.count != 2 || msg.path != path {
+		t.Fatalf("unexpected export result: %+v", msg)
+	}
+	if data, err := os.ReadFile(path); err != nil || len(data) != 32 {
+		t.Errorf("exported file = %d bytes, %v; want 32", len(data), err)
+	}
+}
+
+func TestMapDumpModel_ExportRejectsUnknownExtension(t *testing.T) {
+	m := newEditableDump()
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m.input.SetValue("dump.txt")
+
+	var cmd tea.Cmd
+	m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.IsEditing() || cmd != nil {
+		t.Error("an unknown extension should keep the prompt open")
+	}
+	if !containsString(m.View(), "unknown file extension") {
+		t.Error("view should show the validation error")
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+// dumpPageSize is how many entries the dump view loads at a time.
+const dumpPageSize = 1000
+
+// iterateMap starts reading a map's entries, a page at a time if the service
+// supports it and from a full dump otherwise.
+func iterateMap(svc MapsService, id uint32) (MapIterator, error) {
+	if pageSvc, ok := svc.(MapPageService); ok {
+		return pageSvc.Iterate(id)
+	}
+	entries, err := svc.Dump(id)
+	if err != nil {
+		return nil, err
+	}
+	return &entriesIterator{entries: entries}, nil
+}
+
+// nextPage reads up to n entries from iter, fewer only if it's exhausted.
+// done reports whether iter has no more entries.
+func nextPage(iter MapIterator, n int) (entries []MapEntry, done bool, err error) {
+	for len(entries) < n {
+		page, err := iter.Next(n - len(entries))
+		if err != nil {
+			return entries, false, err
+		}
+		if len(page) == 0 {
+			return entries, true, nil
+		}
+		entries = append(entries, page...)
+	}
+	return entries, false, nil
+}
+
+// readAll reads all remaining entries from iter.
+func readAll(iter MapIterator) ([]MapEntry, error) {
+	var entries []MapEntry
+	for {
+		page, err := iter.Next(dumpPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return entries, nil
+		}
+		entries = append(entries, page...)
+	}
+}
+
+// entriesIterator iterates over entries that are already in memory.
+type entriesIterator struct {
+	entries []MapEntry
+}
+
+// Next returns up to n of the remaining entries.
+func (it *entriesIterator) Next(n int) ([]MapEntry, error) {
+	n = min(n, len(it.entries))
+	page := it.entries[:n:n]
+	it.entries = it.entries[n:]
+	return page, nil
+}
+
+// Close releases the remaining entries.
+func (it *entriesIterator) Close() error {
+	it.entries = nil
+	return nil
+}
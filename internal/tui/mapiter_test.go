@@ -0,0 +1,324 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pagedMapsService serves its entries through an iterator that returns at
+// most 100 entries per call, like batch lookups of a hash map.
+type pagedMapsService struct {
+	mockMapsServiceWithDump
+	opened int // Iterators opened
+	closed int // Iterators closed
+}
+
+func (s *pagedMapsService) Iterate(id uint32) (MapIterator, error) {
+	s.opened++
+	return &smallPageIterator{entriesIterator: entriesIterator{entries: s.entries}, svc: s}, nil
+}
+
+type smallPageIterator struct {
+	entriesIterator
+	svc *pagedMapsService
+}
+
+func (it *smallPageIterator) Next(n int) ([]MapEntry, error) {
+	return it.entriesIterator.Next(min(n, 100))
+}
+
+func (it *smallPageIterator) Close() error {
+	it.svc.closed++
+	return it.entriesIterator.Close()
+}
+
+// countEntries returns n entries with 4-byte keys and values.
+func countEntries(n int) []MapEntry {
+	entries := make([]MapEntry, n)
+	for i := range entries {
+		key := binary.LittleEndian.AppendUint32(nil, uint32(i))
+		entries[i] = MapEntry{Key: key, Value: key}
+	}
+	return entries
+}
+
+func TestNextPage(t *testing.T) {
+	iter := &smallPageIterator{entriesIterator: entriesIterator{entries: countEntries(250)}, svc: &pagedMapsService{}}
+
+	page, done, err := nextPage(iter, 200)
+	if err != nil || done || len(page) != 200 {
+		t.Fatalf("nextPage() = %d entries, %v, %v; want a full page", len(page), done, err)
+	}
+	page, done, err = nextPage(iter, 200)
+	if err != nil || !done || len(page) != 50 {
+		t.Errorf("nextPage() = %d entries, %v, %v; want the last 50", len(page), done, err)
+	}
+}
+
+func TestIterateMapFallsBackToDump(t *testing.T) {
+	svc := &mockMapsServiceWithDump{entries: countEntries(3)}
+	iter, err := iterateMap(svc, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, done, _ := nextPage(iter, 10); len(page) != 3 || !done {
+		t.Errorf("nextPage() = %d entries, done %v", len(page), done)
+	}
+
+	svc.dumpErr = errors.New("operation not supported")
+	if _, err := iterateMap(svc, 1); err == nil {
+		t.Error("dump errors should be returned")
+	}
+}
+
+func TestByteRows(t *testing.T) {
+	rows := byteRows(3, 4)
+	if rows.Len() != 3 || rows.Index(0).Len() != 4 {
+		t.Fatalf("byteRows(3, 4) = %d rows of %d bytes", rows.Len(), rows.Index(0).Len())
+	}
+	rows.Index(1).Index(2).SetUint(7)
+	if got := rows.Index(1).Bytes(); got[2] != 7 {
+		t.Errorf("row 1 = %v", got)
+	}
+}
+
+// TestIntegrationMapDumpPaging tests that large maps load a page at a time
+// as the cursor moves, and that only visible entries are rendered.
+func TestIntegrationMapDumpPaging(t *testing.T) {
+	svc := &pagedMapsService{mockMapsServiceWithDump: mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 1, Name: "big_map", Type: "lru_hash", KeySize: 4, ValueSize: 4}},
+		entries: countEntries(2500),
+	}}
+	m := openMapDump(NewModel(&mockProgService{}, svc))
+
+	if m.mapDump.GetEntryCount() != dumpPageSize || m.mapDump.IsComplete() {
+		t.Fatalf("expected the first page only, got %d entries", m.mapDump.GetEntryCount())
+	}
+	if view := m.View(); !containsString(view, "1000+ entries") || strings.Count(view, "Key:") > 24 {
+		t.Errorf("view should count the loaded entries and render only visible ones:\n%s", view)
+	}
+
+	// Jumping to the end loads the next page
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("nearing the end of the loaded entries should load more")
+	}
+	for _, msg := range runCmd(cmd) {
+		m = updateAndRun(m, msg)
+	}
+	if m.mapDump.GetEntryCount() != 2*dumpPageSize {
+		t.Errorf("expected two pages, got %d entries", m.mapDump.GetEntryCount())
+	}
+	if entry := m.mapDump.SelectedEntry(); entry == nil || binary.LittleEndian.Uint32(entry.Key) != dumpPageSize-1 {
+		t.Errorf("cursor should stay on the entry it jumped to, got %v", entry)
+	}
+
+	// Keep going until the map is exhausted
+	for range 3 {
+		result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+		m = result.(Model)
+		for _, msg := range runCmd(cmd) {
+			m = updateAndRun(m, msg)
+		}
+	}
+	if !m.mapDump.IsComplete() || m.mapDump.GetEntryCount() != 2500 {
+		t.Errorf("expected all 2500 entries, got %d (complete %v)", m.mapDump.GetEntryCount(), m.mapDump.IsComplete())
+	}
+	if svc.closed != svc.opened {
+		t.Errorf("opened %d iterators but closed %d", svc.opened, svc.closed)
+	}
+}
+
+// TestMapDumpPagingBaseline tests that pages are compared against the
+// baseline as they arrive, and removed entries counted after the last one.
+func TestMapDumpPagingBaseline(t *testing.T) {
+	entries := countEntries(250)
+	baseline := append(countEntries(240), MapEntry{Key: []byte{0xff, 0xff, 0xff, 0xff}, Value: []byte{1, 2, 3, 4}})
+	baseline[5].Value = []byte{9, 9, 9, 9}
+
+	m := newMapDumpModel(80, 24)
+	iter := &entriesIterator{entries: entries}
+	first, _ := iter.Next(100)
+	m.SetMapDump(1, "big_map", first)
+	m.SetRemaining(iter, nil)
+	m.SetBaseline(baseline)
+	if m.diff.changed != 1 || len(m.diff.removed) != 0 {
+		t.Errorf("first page: changed %d, removed %d; want 1 and 0", m.diff.changed, len(m.diff.removed))
+	}
+	if view := m.View(); !containsString(view, "+0 -0 ~1 so far") {
+		t.Errorf("title should say the counts are partial:\n%s", view)
+	}
+
+	page, _ := iter.Next(100)
+	m.AppendPage(iter, page, false, nil)
+	page, _ = iter.Next(100)
+	m.AppendPage(iter, page, true, nil)
+	if m.diff.added != 10 || m.diff.changed != 1 || len(m.diff.removed) != 1 {
+		t.Errorf("all pages: +%d -%d ~%d, want +10 -1 ~1", m.diff.added, len(m.diff.removed), m.diff.changed)
+	}
+	if len(m.diff.status) != 250 || m.diff.status[5] != itemChanged || m.diff.status[245] != itemAdded {
+		t.Errorf("statuses don't line up with the entries: %d", len(m.diff.status))
+	}
+}
+
+// TestMapDumpPagingSpinner tests that the spinner runs while a page is read.
+func TestMapDumpPagingSpinner(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	iter := &entriesIterator{entries: countEntries(1500)}
+	first, _ := iter.Next(dumpPageSize)
+	m.SetMapDump(1, "big_map", first)
+	m.SetRemaining(iter, nil)
+
+	m, cmd, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	var page *mapDumpPageMsg
+	var tick *spinner.TickMsg
+	for _, msg := range runCmd(cmd) {
+		switch msg := msg.(type) {
+		case mapDumpPageMsg:
+			page = &msg
+		case spinner.TickMsg:
+			tick = &msg
+		}
+	}
+	if page == nil || tick == nil {
+		t.Fatal("reading a page should start the spinner")
+	}
+	if _, cmd, _ := m.Update(*tick); cmd == nil {
+		t.Error("the spinner should keep ticking while the page is read")
+	}
+
+	m.AppendPage(page.iter, page.entries, page.done, page.err)
+	if _, cmd, _ := m.Update(m.spinner.Tick()); cmd != nil {
+		t.Error("the spinner should stop once all pages are read")
+	}
+}
+
+// TestIntegrationMapDumpStaleFirstPage tests that the iterator of a first
+// page loaded after the user left the view is closed.
+func TestIntegrationMapDumpStaleFirstPage(t *testing.T) {
+	svc := &pagedMapsService{mockMapsServiceWithDump: mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 1, Name: "big_map", Type: "hash", KeySize: 4, ValueSize: 4}},
+		entries: countEntries(2500),
+	}}
+	m := NewModel(&mockProgService{}, svc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // MapList → MapDetail
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	for _, msg := range runCmd(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if svc.opened == 0 || svc.closed != svc.opened {
+		t.Errorf("opened %d iterators but closed %d", svc.opened, svc.closed)
+	}
+}
+
+// TestIntegrationMapDumpPagingExport tests that exporting a partly loaded
+// dump writes every entry of the map.
+func TestIntegrationMapDumpPagingExport(t *testing.T) {
+	svc := &pagedMapsService{mockMapsServiceWithDump: mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 1, Name: "big_map", Type: "hash", KeySize: 4, ValueSize: 4}},
+		entries: countEntries(1500),
+	}}
+	m := openMapDump(NewModel(&mockProgService{}, svc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = result.(Model)
+	if !containsString(m.View(), "Export all entries") {
+		t.Error("prompt should say that the whole map is exported")
+	}
+	path := filepath.Join(t.TempDir(), "big.bin")
+	m.mapDump.input.SetValue(path)
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !containsString(m.View(), "Exported 1500 entries") {
+		t.Errorf("expected all entries to be exported:\n%s", m.View())
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != 1500*16 {
+		t.Errorf("exported file = %v, %v", info, err)
+	}
+
+	// Leaving the view releases the map
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if svc.closed != svc.opened {
+		t.Errorf("opened %d iterators but closed %d", svc.opened, svc.closed)
+	}
+}
+
+// TestIntegrationMapDumpPagingEdit tests that edits are applied to the loaded
+// entries without reading the map again.
+func TestIntegrationMapDumpPagingEdit(t *testing.T) {
+	svc := &pagedMapsService{mockMapsServiceWithDump: mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 1, Name: "big_map", Type: "hash", KeySize: 4, ValueSize: 4}},
+		entries: countEntries(1500),
+	}}
+	m := openMapDump(NewModel(&mockProgService{}, svc))
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = result.(Model)
+	m.mapDump.input.SetValue("ff 00 00 00")
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if entry := m.mapDump.SelectedEntry(); entry == nil || binary.LittleEndian.Uint32(entry.Key) != 1 || entry.Value[0] != 0xff {
+		t.Errorf("the selected entry should show the new value, got %v", entry)
+	}
+	if svc.opened != 1 || m.mapDump.IsComplete() {
+		t.Errorf("the edit shouldn't read the map again: opened %d iterators", svc.opened)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = result.(Model)
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	if m.mapDump.GetEntryCount() != dumpPageSize-1 {
+		t.Errorf("the deleted entry should be dropped, got %d entries", m.mapDump.GetEntryCount())
+	}
+	if entry := m.mapDump.SelectedEntry(); entry == nil || binary.LittleEndian.Uint32(entry.Key) != 2 {
+		t.Errorf("the cursor should move to the next entry, got %v", entry)
+	}
+	if svc.opened != 1 {
+		t.Errorf("the delete shouldn't read the map again: opened %d iterators", svc.opened)
+	}
+}
+
+// TestMapDumpPagingInsert tests that an entry inserted while pages remain
+// isn't shown twice when its page is read.
+func TestMapDumpPagingInsert(t *testing.T) {
+	m := newMapDumpModel(80, 24)
+	iter := &entriesIterator{entries: countEntries(200)}
+	first, _ := iter.Next(100)
+	m.SetMapDump(1, "big_map", first)
+	m.SetRemaining(iter, nil)
+
+	m.PutEntry(countEntries(150)[149].Key, []byte{1, 2, 3, 4})
+	if m.GetEntryCount() != 101 {
+		t.Fatalf("the inserted entry should be added, got %d entries", m.GetEntryCount())
+	}
+
+	page, _ := iter.Next(100)
+	m.AppendPage(iter, page, true, nil)
+	if m.GetEntryCount() != 200 {
+		t.Errorf("the inserted entry should be skipped in its page, got %d entries", m.GetEntryCount())
+	}
+	if e := m.entries[100]; !bytes.Equal(e.Value, []byte{1, 2, 3, 4}) {
+		t.Errorf("the inserted entry should keep its place, got %v", e)
+	}
+}
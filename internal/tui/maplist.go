@@ -2,43 +2,108 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // mapItem represents a BPF map in the list.
 type mapItem struct {
-	info MapInfo
+	info    MapInfo
+	status  itemStatus // Change since the previous refresh or the baseline
+	changes []string   // Fields that differ from the baseline
+	// Processes holding the map, shown while grouping by process
+	owners    []ProcessInfo
+	byProcess bool
 }
 
 // FilterValue implements list.Item interface for fuzzy filtering.
-func (i mapItem) FilterValue() string { return i.info.Name }
+// While grouping by process, maps also match their processes' names.
+func (i mapItem) FilterValue() string {
+	if i.byProcess {
+		return i.info.Name + " " + formatOwners(i.owners)
+	}
+	return i.info.Name
+}
 
-// Title returns the map title for display (ID and Name).
+// Title returns the map title for display (ID and Name),
+// prefixed with a marker if it appeared, disappeared or changed.
 func (i mapItem) Title() string {
-	return fmt.Sprintf("[%d] %s", i.info.ID, i.info.Name)
+	return i.status.marker() + fmt.Sprintf("[%d] %s", i.info.ID, i.info.Name)
 }
 
 // Description returns the map description for display (Type, KeySize, ValueSize, MaxEntries).
+// While grouping by process, the processes holding the map follow.
 func (i mapItem) Description() string {
-	return fmt.Sprintf("Type: %s | Key: %d | Value: %d | Max: %d",
+	desc := fmt.Sprintf("Type: %s | Key: %d | Value: %d | Max: %d",
 		i.info.Type, i.info.KeySize, i.info.ValueSize, i.info.MaxEntries)
+	if len(i.changes) > 0 {
+		desc += " | Changed: " + strings.Join(i.changes, ", ")
+	}
+	if i.byProcess {
+		desc += " | Held by: " + formatOwners(i.owners)
+	}
+	return desc
+}
+
+// mapColumns are the columns of the maps table.
+var mapColumns = []tableColumn{
+	{name: "id", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(mapItem).info.ID) }},
+	{name: "name", flex: true, cell: func(i list.Item) string { return i.(mapItem).info.Name }},
+	{name: "process", auto: true, cell: func(i list.Item) string { return i.(mapItem).ownersCell() }},
+	{name: "type", cell: func(i list.Item) string { return i.(mapItem).info.Type }},
+	{name: "key", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(mapItem).info.KeySize) }},
+	{name: "value", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(mapItem).info.ValueSize) }},
+	{name: "max", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(mapItem).info.MaxEntries) }},
+	{name: "flags", right: true, cell: func(i list.Item) string { return fmt.Sprintf("0x%x", i.(mapItem).info.Flags) }},
+	{name: "memlock", right: true, cell: func(i list.Item) string { return formatSize(i.(mapItem).info.MemLock) }},
+	{name: "loaded", cell: func(i list.Item) string { return i.(mapItem).info.LoadedAt }},
+	{name: "uid", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(mapItem).info.UID) }},
+	{name: "pinned", cell: func(i list.Item) string { return formatPinned(i.(mapItem).info.Pinned) }},
+	{name: "changed", auto: true, cell: func(i list.Item) string { return strings.Join(i.(mapItem).changes, ", ") }},
+}
+
+// defaultMapColumns are the columns of the maps table until the user
+// chooses others.
+var defaultMapColumns = []string{"id", "name", "type", "key", "value", "max", "memlock", "loaded", "uid"}
+
+// changeStatus implements tableItem.
+func (i mapItem) changeStatus() itemStatus {
+	return i.status
+}
+
+// ownersCell returns the processes holding the map while grouping by
+// process, and "" otherwise.
+func (i mapItem) ownersCell() string {
+	if !i.byProcess {
+		return ""
+	}
+	return formatOwners(i.owners)
 }
 
 // mapListModel manages the maps list state.
 type mapListModel struct {
-	list list.Model
-	maps []MapInfo
-	err  error
+	list  list.Model
+	maps  []MapInfo
+	items []list.Item // Items in load order, before grouping
+	err   error
+	// Grouping by owning process
+	byProcess bool
+	sort      listSort // Column and direction to sort by
+	table     listTable
+	owners    map[uint32][]ProcessInfo
+	baseline  []MapInfo // Maps to compare against, or nil
+	loading   bool
+	spinner   spinner.Model
 }
 
 // newMapListModel creates a new maps list model.
 func newMapListModel(width, height int) mapListModel {
 	// Create delegate for custom item rendering
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = selectedStyle
-	delegate.Styles.SelectedDesc = selectedStyle.Foreground(dimStyle.GetForeground())
+	delegate := newItemDelegate()
 
 	// Calculate list dimensions (leave room for title and help bar)
 	listHeight := height - 6
@@ -53,9 +118,14 @@ func newMapListModel(width, height int) mapListModel {
 	l.SetShowHelp(false)
 	l.Styles.Title = titleStyle
 
+	table := newListTable(mapColumns, defaultMapColumns...)
+	table.width, table.height = width, height
+
 	return mapListModel{
-		list: l,
-		maps: []MapInfo{},
+		list:    l,
+		maps:    []MapInfo{},
+		table:   table,
+		spinner: newSpinner(),
 	}
 }
 
@@ -67,18 +137,222 @@ func (m mapListModel) Init() tea.Cmd {
 // SetMaps updates the list with new map data.
 func (m *mapListModel) SetMaps(maps []MapInfo) {
 	m.maps = maps
+	m.loading = false
+	m.err = nil
 	items := make([]list.Item, len(maps))
 	for i, mapInfo := range maps {
 		items[i] = mapItem{info: mapInfo}
 	}
+	m.items = m.compare(items)
+	m.list.Title = m.listTitle()
+	items = m.arrange(m.items)
+	m.list.Filter = queryFilter(items, mapQueryFields)
 	m.list.SetItems(items)
+	m.table.apply(&m.list)
+}
+
+// RefreshMaps replaces the list with freshly polled data while preserving the
+// cursor and any active filter. Items that appeared since the previous poll
+// are marked as added; items that disappeared are kept until the next poll
+// and marked as removed.
+func (m *mapListModel) RefreshMaps(maps []MapInfo) tea.Cmd {
+	var selectedID *uint32
+	if item, ok := m.list.SelectedItem().(mapItem); ok {
+		selectedID = &item.info.ID
+	}
+
+	prevIDs := make([]uint32, len(m.maps))
+	prev := make(map[uint32]MapInfo, len(m.maps))
+	for i, p := range m.maps {
+		prevIDs[i] = p.ID
+		prev[p.ID] = p
+	}
+	currIDs := make([]uint32, len(maps))
+	for i, p := range maps {
+		currIDs[i] = p.ID
+	}
+	added, removed := diffIDs(prevIDs, currIDs)
+
+	m.maps = maps
+	m.loading = false
+	m.err = nil
+	newItems := make([]list.Item, 0, len(maps)+len(removed))
+	for _, p := range maps {
+		status := itemUnchanged
+		if added[p.ID] {
+			status = itemAdded
+		}
+		newItems = append(newItems, mapItem{info: p, status: status})
+	}
+	for _, id := range removed {
+		newItems = append(newItems, mapItem{info: prev[id], status: itemRemoved})
+	}
+
+	if m.baseline != nil {
+		// Compare against the baseline instead of the previous poll
+		newItems = m.compare(newItems[:len(maps)])
+		m.list.Title = m.listTitle()
+	} else {
+		m.list.Title = changeTitle(m.title(), len(added), len(removed))
+	}
+	m.items = newItems
+	newItems = m.arrange(newItems)
+	m.list.Filter = queryFilter(newItems, mapQueryFields)
+	cmd := m.list.SetItems(newItems)
+	m.table.apply(&m.list)
+
+	// Keep the cursor on the same map when the list isn't filtered.
+	// While filtered, the list keeps its cursor index across re-filtering.
+	if selectedID != nil && m.list.FilterState() == list.Unfiltered {
+		for i, item := range newItems {
+			if item.(mapItem).info.ID == *selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return cmd
+}
+
+// SetBaseline sets maps to compare against, e.g. from a snapshot. Items
+// are then marked as added, removed or changed relative to the baseline
+// rather than the previous poll. Pass nil to stop comparing.
+func (m *mapListModel) SetBaseline(maps []MapInfo) {
+	m.baseline = maps
+}
+
+// compare marks items for the current maps against the baseline and
+// appends the baseline maps that are gone. Without a baseline, items are
+// returned unchanged.
+func (m mapListModel) compare(items []list.Item) []list.Item {
+	if m.baseline == nil {
+		return items
+	}
+	d := diffMaps(m.baseline, m.maps)
+	for i, item := range items {
+		mi := item.(mapItem)
+		mi.status = d.status[mi.info.ID]
+		mi.changes = d.changes[mi.info.ID]
+		items[i] = mi
+	}
+	for _, p := range d.removed {
+		items = append(items, mapItem{info: p, status: itemRemoved})
+	}
+	return items
+}
+
+// listTitle returns the list title, with counts of the differences from the
+// baseline if there is one.
+func (m mapListModel) listTitle() string {
+	if m.baseline == nil {
+		return m.title()
+	}
+	d := diffMaps(m.baseline, m.maps)
+	return diffTitle(m.title(), d.added, len(d.removed), d.changed)
+}
+
+// SetByProcess turns grouping by owning process on or off.
+func (m *mapListModel) SetByProcess(on bool) tea.Cmd {
+	m.byProcess = on
+	return m.regroup()
+}
+
+// SetProcesses records which processes hold each map.
+func (m *mapListModel) SetProcesses(procs []ProcessInfo) tea.Cmd {
+	m.owners = ownerIndex(procs, heldMaps)
+	return m.regroup()
+}
+
+// title returns the list title with the sort indicator, before change
+// counts.
+func (m mapListModel) title() string {
+	if m.byProcess {
+		return "BPF Maps by Process" + m.sort.title()
+	}
+	return "BPF Maps" + m.sort.title()
+}
+
+// SetSort sets the column and direction to sort the list by.
+func (m *mapListModel) SetSort(s listSort) tea.Cmd {
+	m.sort = s
+	return m.regroup()
+}
+
+// CycleSort sorts the list by the next column, ascending.
+func (m *mapListModel) CycleSort() tea.Cmd {
+	return m.SetSort(m.sort.next(mapSortKeys))
+}
+
+// ReverseSort flips the sort direction. In kernel order, it does nothing.
+func (m *mapListModel) ReverseSort() tea.Cmd {
+	if m.sort.key == sortNone {
+		return nil
+	}
+	return m.SetSort(m.sort.reversed())
+}
+
+// arrange returns items annotated with their owning processes, sorted by
+// the sort column and, while grouping by process, by the processes first.
+func (m mapListModel) arrange(items []list.Item) []list.Item {
+	items = slices.Clone(items)
+	for i, item := range items {
+		mi := item.(mapItem)
+		mi.owners = m.owners[mi.info.ID]
+		mi.byProcess = m.byProcess
+		items[i] = mi
+	}
+	sortItems(items, m.sort, mapSortField)
+	if m.byProcess {
+		sortByOwner(items, func(item list.Item) []ProcessInfo { return item.(mapItem).owners })
+	}
+	return items
+}
+
+// regroup re-arranges the list after a change in grouping or order,
+// keeping the cursor on the same map when the list isn't filtered.
+func (m *mapListModel) regroup() tea.Cmd {
+	var selectedID *uint32
+	if item, ok := m.list.SelectedItem().(mapItem); ok {
+		selectedID = &item.info.ID
+	}
+
+	items := m.arrange(m.items)
+	m.list.Title = m.listTitle()
+	m.list.Filter = queryFilter(items, mapQueryFields)
+	cmd := m.list.SetItems(items)
+	m.table.apply(&m.list)
+
+	if selectedID != nil && m.list.FilterState() == list.Unfiltered {
+		for i, item := range items {
+			if item.(mapItem).info.ID == *selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return cmd
 }
 
 // Update handles messages for the maps list.
 // Returns the updated model, an optional command, and the selected map if Enter was pressed.
 func (m mapListModel) Update(msg tea.Msg) (mapListModel, tea.Cmd, *MapInfo) {
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
 	case tea.KeyMsg:
+		if m.table.choosing {
+			m.table = m.table.Update(msg)
+			m.table.apply(&m.list)
+			return m, nil, nil
+		}
+
 		// Don't handle enter if we're filtering
 		if m.list.FilterState() == list.Filtering {
 			break
@@ -87,7 +361,8 @@ func (m mapListModel) Update(msg tea.Msg) (mapListModel, tea.Cmd, *MapInfo) {
 		switch msg.String() {
 		case "enter":
 			// Get selected item and return its map info
-			if item, ok := m.list.SelectedItem().(mapItem); ok {
+			// Removed items no longer exist, so there's nothing to open
+			if item, ok := m.list.SelectedItem().(mapItem); ok && item.status != itemRemoved {
 				return m, nil, &item.info
 			}
 		}
@@ -100,7 +375,12 @@ func (m mapListModel) Update(msg tea.Msg) (mapListModel, tea.Cmd, *MapInfo) {
 
 // View renders the maps list.
 func (m mapListModel) View() string {
-	if len(m.maps) == 0 && m.err == nil {
+	if m.loading {
+		return titleStyle.Render("BPF Maps") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading BPF maps...")
+	}
+
+	if len(m.items) == 0 && m.err == nil {
 		return titleStyle.Render("BPF Maps") + "\n\n" +
 			dimStyle.Render("No BPF maps loaded")
 	}
@@ -110,21 +390,85 @@ func (m mapListModel) View() string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
 	}
 
-	return m.list.View()
+	view := m.list.View()
+	if m.table.on {
+		view = m.table.render(m.list, "maps")
+	}
+	if errView := queryError(m.list, mapQueryFields); errView != "" {
+		view += "\n" + errView
+	}
+	return view
 }
 
 // SetSize updates the list dimensions.
 func (m *mapListModel) SetSize(width, height int) {
-	listHeight := height - 6
-	if listHeight < 3 {
-		listHeight = 3
-	}
-	m.list.SetSize(width, listHeight)
+	m.table.width = width
+	m.table.height = height
+	m.table.apply(&m.list)
 }
 
 // SetError sets an error state for the list.
 func (m *mapListModel) SetError(err error) {
 	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *mapListModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.err = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// IsByProcess returns true while the list is grouped by owning process.
+func (m mapListModel) IsByProcess() bool {
+	return m.byProcess
+}
+
+// ToggleTable switches between the table layout and the two-line list.
+func (m *mapListModel) ToggleTable() {
+	m.table.on = !m.table.on
+	m.table.choosing = false
+	m.table.apply(&m.list)
+}
+
+// ChooseColumns opens the chooser of the table's columns. It does nothing
+// outside the table layout.
+func (m *mapListModel) ChooseColumns() {
+	m.table.choosing = m.table.on
+}
+
+// SetColumns sets the columns of the table layout, by name.
+func (m *mapListModel) SetColumns(names []string) error {
+	if err := m.table.SetColumns(names); err != nil {
+		return err
+	}
+	m.table.apply(&m.list)
+	return nil
+}
+
+// IsTable returns true while the list is laid out as a table.
+func (m mapListModel) IsTable() bool {
+	return m.table.on
+}
+
+// IsChoosingColumns returns true while the column chooser is open.
+func (m mapListModel) IsChoosingColumns() bool {
+	return m.table.choosing
+}
+
+// GetSort returns the column and direction the list is sorted by.
+func (m mapListModel) GetSort() listSort {
+	return m.sort
+}
+
+// IsLoading returns true if maps are being loaded.
+func (m mapListModel) IsLoading() bool {
+	return m.loading
 }
 
 // SelectedItem returns the currently selected map, if any.
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -315,6 +316,22 @@ func (m *mockMapsServiceForMapList) Dump(id uint32) ([]MapEntry, error) {
 	return nil, nil
 }
 
+func (m *mockMapsServiceForMapList) Lookup(id uint32, key []byte) ([]byte, error) {
+	return nil, ErrKeyNotFound
+}
+
+func (m *mockMapsServiceForMapList) Update(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsServiceForMapList) Insert(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsServiceForMapList) Delete(id uint32, key []byte) error {
+	return nil
+}
+
 // Integration test: Full navigation flow from menu to maps list and back
 func TestMapListIntegrationNavigateFromMenu(t *testing.T) {
 	m := NewModel(nil, nil)
@@ -390,12 +407,10 @@ func TestMapListIntegrationSelectMap(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Press Enter to select first map
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should now be at map detail
 	if m.state != ViewMapDetail {
@@ -662,8 +677,7 @@ func TestMapListIntegrationFuzzySearchDoesNotNavigateBack(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	if m.state != ViewMapList {
 		t.Fatalf("expected ViewMapList, got %v", m.state)
@@ -807,3 +821,109 @@ func TestMapListResetFilter(t *testing.T) {
 		t.Error("expected not to be in filtering mode after ResetFilter")
 	}
 }
+
+func TestMapListRefreshMapsMarksChanges(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{
+		{ID: 1, Name: "map1", Type: "hash"},
+		{ID: 2, Name: "map2", Type: "array"},
+	})
+
+	m.RefreshMaps([]MapInfo{
+		{ID: 2, Name: "map2", Type: "array"},
+		{ID: 3, Name: "map3", Type: "lru_hash"},
+	})
+
+	items := m.list.Items()
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items (2 live + 1 removed), got %d", len(items))
+	}
+
+	statuses := map[uint32]itemStatus{}
+	for _, item := range items {
+		mi := item.(mapItem)
+		statuses[mi.info.ID] = mi.status
+	}
+	if statuses[1] != itemRemoved {
+		t.Errorf("map 1 status = %v, want itemRemoved", statuses[1])
+	}
+	if statuses[2] != itemUnchanged {
+		t.Errorf("map 2 status = %v, want itemUnchanged", statuses[2])
+	}
+	if statuses[3] != itemAdded {
+		t.Errorf("map 3 status = %v, want itemAdded", statuses[3])
+	}
+
+	// Removed maps are dropped on the following refresh
+	m.RefreshMaps([]MapInfo{
+		{ID: 2, Name: "map2", Type: "array"},
+		{ID: 3, Name: "map3", Type: "lru_hash"},
+	})
+	if len(m.list.Items()) != 2 {
+		t.Errorf("expected 2 items after second refresh, got %d", len(m.list.Items()))
+	}
+	if m.list.Title != "BPF Maps" {
+		t.Errorf("title = %q, want unannotated title when nothing changed", m.list.Title)
+	}
+}
+
+func TestMapListEnterSkipsRemoved(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{{ID: 1, Name: "map1", Type: "hash"}, {ID: 2, Name: "map2", Type: "array"}})
+	m.RefreshMaps([]MapInfo{{ID: 2, Name: "map2", Type: "array"}})
+
+	for i, item := range m.list.Items() {
+		m.list.Select(i)
+		_, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		removed := item.(mapItem).status == itemRemoved
+		if removed && selected != nil {
+			t.Errorf("Enter on removed map %d should not open it", selected.ID)
+		}
+		if !removed && selected == nil {
+			t.Error("Enter on a live map should open it")
+		}
+	}
+}
+
+func TestMapListRefreshMapsKeepsFilter(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{
+		{ID: 1, Name: "hash_map", Type: "hash"},
+		{ID: 2, Name: "array_map", Type: "array"},
+	})
+
+	m.list.SetFilterText("hash")
+	m.RefreshMaps([]MapInfo{
+		{ID: 1, Name: "hash_map", Type: "hash"},
+		{ID: 2, Name: "array_map", Type: "array"},
+	})
+
+	if m.list.FilterState() != list.FilterApplied {
+		t.Errorf("filter state = %v, want FilterApplied", m.list.FilterState())
+	}
+	if m.list.FilterValue() != "hash" {
+		t.Errorf("filter value = %q, want %q", m.list.FilterValue(), "hash")
+	}
+}
+
+func TestMapListGroupByProcess(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{{ID: 1, Name: "unused"}, {ID: 2, Name: "events"}})
+
+	m.SetProcesses([]ProcessInfo{{PID: 20, Comm: "tetragon", MapIDs: []uint32{2}}})
+	if got := m.list.Items()[0].(mapItem).info.Name; got != "unused" {
+		t.Errorf("processes alone shouldn't reorder the list, first item = %s", got)
+	}
+
+	m.SetByProcess(true)
+	if !m.IsByProcess() || m.list.Title != "BPF Maps by Process" {
+		t.Errorf("expected grouping by process, title = %q", m.list.Title)
+	}
+	first := m.list.Items()[0].(mapItem)
+	if first.info.Name != "events" || !strings.Contains(first.Description(), "Held by: tetragon (20)") {
+		t.Errorf("expected events held by tetragon first, got %q", first.Description())
+	}
+	if got := m.list.Items()[1].(mapItem).Description(); !strings.Contains(got, "Held by: no process") {
+		t.Errorf("unowned maps should say so, got %q", got)
+	}
+}
@@ -0,0 +1,350 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchPlaceholder is shown in the empty search input.
+const searchPlaceholder = `hex 0a 0b, "text", 42 or be16:80`
+
+// dumpSearch is a search of the dump's keys and values for a byte pattern.
+type dumpSearch struct {
+	query   string // As typed
+	pattern []byte
+	text    string // The pattern as text, highlighted in BTF-decoded output; "" if not text
+	matches []int  // Indexes of the loaded entries whose key or value contains the pattern
+
+	// A jump waiting for further pages, as no loaded entry matches
+	seeking  bool
+	seekFrom int
+	seekDir  int
+}
+
+// searchIntRE matches a sized integer pattern, e.g. u32:1234 or be16:80,
+// capturing the signedness or byte order, the width and the value.
+var searchIntRE = regexp.MustCompile(`^(u|s|i|le|be)(8|16|32|64)(le|be)?:(.+)$`)
+
+// parseSearchPattern converts a search typed by the user into the bytes to
+// search for. Accepted forms, tried in order:
+//
+//   - text in double or single quotes, e.g. "eth0"
+//   - a sized integer, e.g. u16:80, s32:-1, u32be:0x0a000001 or be16:80;
+//     native byte order unless le or be is given
+//   - a decimal integer such as 42 or -1, as a native-endian 4-byte integer,
+//     or 8-byte if it doesn't fit
+//   - hex bytes such as 0a 0b 0c or 0x0a0b0c
+//   - anything else as unquoted text
+//
+// The second return value is the pattern as text, or "" if it isn't text.
+func parseSearchPattern(s string) ([]byte, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, "", fmt.Errorf("nothing to search for")
+	}
+
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		text := s[1 : len(s)-1]
+		if text == "" {
+			return nil, "", fmt.Errorf("nothing to search for")
+		}
+		return []byte(text), text, nil
+	}
+
+	if sm := searchIntRE.FindStringSubmatch(strings.ToLower(s)); sm != nil {
+		kind, order := sm[1], sm[3]
+		if kind == "le" || kind == "be" {
+			if order != "" {
+				return nil, "", fmt.Errorf("byte order given twice in %q", s)
+			}
+			kind, order = "u", kind
+		}
+		width, _ := strconv.Atoi(sm[2])
+		b, err := encodeSearchInt(sm[4], width/8, kind != "u", order)
+		return b, "", err
+	}
+
+	if isDecimal(s) {
+		signed := strings.HasPrefix(s, "-")
+		b, err := encodeSearchInt(s, 4, signed, "")
+		if err != nil {
+			b, err = encodeSearchInt(s, 8, signed, "")
+		}
+		return b, "", err
+	}
+
+	if b, err := parseHexBytes(s, 0); err == nil {
+		return b, "", nil
+	}
+	return []byte(s), s, nil
+}
+
+// encodeSearchInt encodes the integer s, decimal or 0x-prefixed hex, in
+// size bytes with the given byte order: "le", "be" or "" for native.
+func encodeSearchInt(s string, size int, signed bool, order string) ([]byte, error) {
+	bits := size * 8
+	var v uint64
+	if signed {
+		n, err := strconv.ParseInt(s, 0, bits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %d-bit integer %q", bits, s)
+		}
+		v = uint64(n)
+	} else {
+		n, err := strconv.ParseUint(s, 0, bits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %d-bit unsigned integer %q", bits, s)
+		}
+		v = n
+	}
+
+	var bo binary.ByteOrder = binary.NativeEndian
+	switch order {
+	case "le":
+		bo = binary.LittleEndian
+	case "be":
+		bo = binary.BigEndian
+	}
+	buf := make([]byte, 8)
+	bo.PutUint64(buf, v)
+	if bo.Uint16([]byte{0, 1}) == 1 {
+		// Big-endian: the low-order bytes are last
+		return buf[8-size:], nil
+	}
+	return buf[:size], nil
+}
+
+// matchesEntry returns true if the entry's key or value contains the pattern.
+func (s *dumpSearch) matchesEntry(e MapEntry) bool {
+	return bytes.Contains(e.Key, s.pattern) || bytes.Contains(e.Value, s.pattern)
+}
+
+// update finds the entries containing the pattern.
+func (s *dumpSearch) update(entries []MapEntry) {
+	s.matches = nil
+	s.extend(entries, 0)
+}
+
+// extend finds the entries containing the pattern among those appended at
+// index start, leaving the matches before them alone.
+func (s *dumpSearch) extend(entries []MapEntry, start int) {
+	if !s.active() {
+		return
+	}
+	for i, e := range entries {
+		if s.matchesEntry(e) {
+			s.matches = append(s.matches, start+i)
+		}
+	}
+}
+
+// find returns the first match after the entry at cursor, or before it if
+// dir is negative. ok is false if there is none.
+func (s *dumpSearch) find(cursor, dir int) (int, bool) {
+	if dir >= 0 {
+		for _, i := range s.matches {
+			if i > cursor {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	for k := len(s.matches) - 1; k >= 0; k-- {
+		if s.matches[k] < cursor {
+			return s.matches[k], true
+		}
+	}
+	return 0, false
+}
+
+// next is like find, but wraps around. ok is false if there are no matches.
+func (s *dumpSearch) next(cursor, dir int) (int, bool) {
+	if len(s.matches) == 0 {
+		return 0, false
+	}
+	if i, ok := s.find(cursor, dir); ok {
+		return i, true
+	}
+	if dir >= 0 {
+		return s.matches[0], true
+	}
+	return s.matches[len(s.matches)-1], true
+}
+
+// position returns the 1-based position of the entry at cursor among the
+// matches, or 0 if it doesn't match.
+func (s *dumpSearch) position(cursor int) int {
+	for k, i := range s.matches {
+		if i == cursor {
+			return k + 1
+		}
+	}
+	return 0
+}
+
+// highlightHex renders data as hex bytes in style, with the bytes of every
+// occurrence of pattern in matchStyle.
+func highlightHex(data, pattern []byte, style lipgloss.Style) string {
+	if len(data) == 0 || len(pattern) == 0 {
+		return style.Render(FormatHex(data))
+	}
+
+	matched := make([]bool, len(data))
+	for off := 0; off < len(data); {
+		i := bytes.Index(data[off:], pattern)
+		if i < 0 {
+			break
+		}
+		for j := off + i; j < off+i+len(pattern); j++ {
+			matched[j] = true
+		}
+		off += i + len(pattern)
+	}
+
+	// Render runs of matched and unmatched bytes, with the space between
+	// two matched bytes highlighted too
+	var b strings.Builder
+	for start := 0; start < len(data); {
+		end := start
+		for end < len(data) && matched[end] == matched[start] {
+			end++
+		}
+		run := FormatHex(data[start:end])
+		if start > 0 {
+			b.WriteString(" ")
+		}
+		if matched[start] {
+			b.WriteString(matchStyle.Render(run))
+		} else {
+			b.WriteString(style.Render(run))
+		}
+		start = end
+	}
+	return b.String()
+}
+
+// highlightText renders s in style, with every occurrence of text in
+// matchStyle. Lines are rendered separately so styles don't pad them.
+func highlightText(s, text string, style lipgloss.Style) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		for text != "" {
+			j := strings.Index(line, text)
+			if j < 0 {
+				break
+			}
+			if j > 0 {
+				b.WriteString(style.Render(line[:j]))
+			}
+			b.WriteString(matchStyle.Render(text))
+			line = line[j+len(text):]
+		}
+		if line != "" {
+			b.WriteString(style.Render(line))
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// active returns true if there is a pattern to search for.
+func (s dumpSearch) active() bool {
+	return len(s.pattern) > 0
+}
+
+// startSearch opens the search input.
+func (m *mapDumpModel) startSearch() tea.Cmd {
+	m.input.SetValue("")
+	return m.setEditMode(editSearch)
+}
+
+// submitSearch searches for the pattern entered and selects the first
+// match from the selected entry on. An empty search clears the search.
+func (m *mapDumpModel) submitSearch() tea.Cmd {
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.search = dumpSearch{}
+		m.setEditMode(editNone)
+		return nil
+	}
+	pattern, text, err := parseSearchPattern(query)
+	if err != nil {
+		m.inputErr = err.Error()
+		return nil
+	}
+	m.search = dumpSearch{query: query, pattern: pattern, text: text}
+	m.search.update(m.entries)
+	m.setEditMode(editNone)
+	return m.jumpToMatch(m.cursor-1, 1)
+}
+
+// jumpToMatch selects the next match after the entry at from, or the
+// previous one before it if dir is negative. While part of the map isn't
+// loaded, further pages are read until one matches before wrapping around.
+// Without a search, there's nothing to jump to.
+func (m *mapDumpModel) jumpToMatch(from, dir int) tea.Cmd {
+	m.search.seeking = false
+	if !m.search.active() {
+		return nil
+	}
+	i, ok := m.search.find(from, dir)
+	if !ok && m.iter != nil {
+		m.search.seeking, m.search.seekFrom, m.search.seekDir = true, from, dir
+		return m.fetchPage()
+	}
+	if !ok {
+		if i, ok = m.search.next(from, dir); !ok {
+			return nil
+		}
+	}
+	return m.moveCursor(i - m.cursor)
+}
+
+// searchLabel renders a field label, highlighted if the field's bytes
+// contain the search pattern.
+func (m mapDumpModel) searchLabel(label string, data []byte) string {
+	if m.search.active() && bytes.Contains(data, m.search.pattern) {
+		return matchStyle.Width(labelStyle.GetWidth()).Render(label)
+	}
+	return labelStyle.Render(label)
+}
+
+// formatMatches renders data like formatEntryBytes, in style, with the
+// search pattern highlighted: the matched bytes of hex output, or the
+// matched text of other output.
+func (m mapDumpModel) formatMatches(t *BTFType, data []byte, mode displayMode, style lipgloss.Style, pad string) string {
+	if t == nil && mode.kind == displayHex {
+		return highlightHex(data, m.search.pattern, style)
+	}
+	return highlightText(indentLines(formatEntryBytes(t, data, mode), pad), m.search.text, style)
+}
+
+// searchStatus describes the search results for the title, e.g.
+// "“eth0” match 2 of 5".
+func (m mapDumpModel) searchStatus() string {
+	found := fmt.Sprintf("%d matches", len(m.search.matches))
+	switch n := len(m.search.matches); {
+	case n == 0:
+		found = "no matches"
+	case m.search.position(m.cursor) > 0:
+		found = fmt.Sprintf("match %d of %d", m.search.position(m.cursor), n)
+	case n == 1:
+		found = "1 match"
+	}
+	if m.iter != nil {
+		// More entries are loaded as the cursor nears the end
+		found += " so far"
+		if m.search.seeking {
+			found += ", searching the rest of the map"
+		}
+	}
+	return fmt.Sprintf("“%s” %s", m.search.query, found)
+}
@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseSearchPattern(t *testing.T) {
+	native32 := binary.NativeEndian.AppendUint32(nil, 42)
+	tests := []struct {
+		name     string
+		input    string
+		want     []byte
+		wantText string
+		wantErr  bool
+	}{
+		{"hex bytes", "0a 0b 0c", []byte{0x0a, 0x0b, 0x0c}, "", false},
+		{"0x hex", "0x0a0b", []byte{0x0a, 0x0b}, "", false},
+		{"quoted text", `"eth0"`, []byte("eth0"), "eth0", false},
+		{"single-quoted text", `'a b'`, []byte("a b"), "a b", false},
+		{"bare text", "nginx", []byte("nginx"), "nginx", false},
+		{"decimal", "42", native32, "", false},
+		{"decimal beyond 32 bits", "4294967296", binary.NativeEndian.AppendUint64(nil, 1<<32), "", false},
+		{"negative decimal", "-1", []byte{0xff, 0xff, 0xff, 0xff}, "", false},
+		{"u8", "u8:255", []byte{0xff}, "", false},
+		{"u16 big-endian", "u16be:80", []byte{0x00, 0x50}, "", false},
+		{"u16 little-endian", "u16le:80", []byte{0x50, 0x00}, "", false},
+		{"be shorthand", "be32:0x0a000001", []byte{0x0a, 0, 0, 1}, "", false},
+		{"signed", "s16le:-2", []byte{0xfe, 0xff}, "", false},
+		{"i64 native", "i64:42", binary.NativeEndian.AppendUint64(nil, 42), "", false},
+		{"out of range", "u8:256", nil, "", true},
+		{"negative unsigned", "u32:-1", nil, "", true},
+		{"byte order twice", "be16le:1", nil, "", true},
+		{"empty", "  ", nil, "", true},
+		{"empty quotes", `""`, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, text, err := parseSearchPattern(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSearchPattern(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("parseSearchPattern(%q) = % x, want % x", tt.input, got, tt.want)
+			}
+			if text != tt.wantText {
+				t.Errorf("parseSearchPattern(%q) text = %q, want %q", tt.input, text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestDumpSearchNext(t *testing.T) {
+	s := dumpSearch{matches: []int{2, 5, 9}}
+	tests := []struct {
+		cursor, dir, want int
+	}{
+		{0, 1, 2},
+		{2, 1, 5},
+		{9, 1, 2}, // Wraps around
+		{5, -1, 2},
+		{2, -1, 9}, // Wraps around
+		{-1, 1, 2},
+	}
+	for _, tt := range tests {
+		if got, ok := s.next(tt.cursor, tt.dir); !ok || got != tt.want {
+			t.Errorf("next(%d, %d) = %d, %v, want %d", tt.cursor, tt.dir, got, ok, tt.want)
+		}
+	}
+	if _, ok := (&dumpSearch{}).next(0, 1); ok {
+		t.Error("next with no matches should fail")
+	}
+}
+
+func TestHighlightHex(t *testing.T) {
+	data := []byte{0x01, 0x0a, 0x0b, 0x02, 0x0a, 0x0b}
+	// Without colors, highlighting leaves the hex unchanged
+	got := highlightHex(data, []byte{0x0a, 0x0b}, lipgloss.NewStyle())
+	if got != FormatHex(data) {
+		t.Errorf("highlightHex() = %q, want %q", got, FormatHex(data))
+	}
+	if got := highlightText("name: eth0\n  eth0", "eth0", lipgloss.NewStyle()); got != "name: eth0\n  eth0" {
+		t.Errorf("highlightText() = %q", got)
+	}
+}
+
+// newSearchableDump returns a dump model with entries to search.
+func newSearchableDump() mapDumpModel {
+	m := newMapDumpModel(80, 40)
+	m.SetEntrySizes(4, 8)
+	m.SetMapDump(1, "test_map", []MapEntry{
+		{Key: []byte{1, 0, 0, 0}, Value: []byte("lo\x00\x00\x00\x00\x00\x00")},
+		{Key: []byte{2, 0, 0, 0}, Value: []byte("eth0\x00\x00\x00\x00")},
+		{Key: []byte{3, 0, 0, 0}, Value: []byte("wlan0\x00\x00\x00")},
+		{Key: []byte{0x2a, 0, 0, 0}, Value: []byte("eth1\x00\x00\x00\x00")},
+	})
+	return m
+}
+
+// search runs a search for query in the dump.
+func search(m mapDumpModel, query string) mapDumpModel {
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = typeText(m, query)
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return m
+}
+
+func TestMapDumpModel_Search(t *testing.T) {
+	m := newSearchableDump()
+
+	m = search(m, `"eth"`)
+	if m.IsEditing() {
+		t.Fatal("search input should close after enter")
+	}
+	if got := m.GetSearchMatches(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Fatalf("matches = %v, want [1 3]", got)
+	}
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want the first match 1", m.cursor)
+	}
+	if view := m.View(); !strings.Contains(view, "match 1 of 2") {
+		t.Errorf("title should show the match position, got:\n%s", view)
+	}
+
+	// n and N move between matches, wrapping around
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.cursor != 3 {
+		t.Errorf("after n cursor = %d, want 3", m.cursor)
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.cursor != 1 {
+		t.Errorf("after n at the last match cursor = %d, want 1", m.cursor)
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if m.cursor != 3 {
+		t.Errorf("after N at the first match cursor = %d, want 3", m.cursor)
+	}
+
+	// Integers match keys
+	m = search(m, "u32:42")
+	if got := m.GetSearchMatches(); len(got) != 1 || got[0] != 3 {
+		t.Errorf("matches for u32:42 = %v, want [3]", got)
+	}
+
+	// An empty search clears it, keeping the selection
+	m = search(m, "")
+	if m.GetSearchMatches() != nil || m.cursor != 3 {
+		t.Errorf("empty search should clear matches and keep the cursor, got %v at %d", m.GetSearchMatches(), m.cursor)
+	}
+}
+
+func TestMapDumpModel_SearchNoMatches(t *testing.T) {
+	m := newSearchableDump()
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	m = search(m, "ff ff")
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, should stay put without matches", m.cursor)
+	}
+	if view := m.View(); !strings.Contains(view, "no matches") {
+		t.Errorf("title should report no matches, got:\n%s", view)
+	}
+}
+
+func TestMapDumpModel_SearchInvalid(t *testing.T) {
+	m := newSearchableDump()
+	m = search(m, "u8:300")
+	if !m.IsEditing() {
+		t.Fatal("invalid search should keep the input open")
+	}
+	if !strings.Contains(m.View(), "invalid 8-bit unsigned integer") {
+		t.Errorf("view should show the parse error, got:\n%s", m.View())
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsEditing() || m.GetSearchMatches() != nil {
+		t.Error("esc should cancel the search")
+	}
+}
+
+func TestMapDumpModel_SearchPages(t *testing.T) {
+	m := newSearchableDump()
+	m = search(m, `"eth"`)
+
+	// Matches in entries loaded later are found too
+	iter := &entriesIterator{}
+	m.SetRemaining(iter, nil)
+	m.AppendPage(iter, []MapEntry{{Key: []byte{5, 0, 0, 0}, Value: []byte("eth2\x00\x00\x00\x00")}}, false, nil)
+	if got := m.GetSearchMatches(); len(got) != 3 || got[2] != 4 {
+		t.Errorf("matches = %v, want [1 3 4]", got)
+	}
+	if view := m.View(); !strings.Contains(view, "so far") {
+		t.Errorf("title should note more entries may match, got:\n%s", view)
+	}
+
+	// A new dump clears the search
+	m.StartLoading(2, "other")
+	if m.GetSearchMatches() != nil {
+		t.Error("StartLoading should clear the search")
+	}
+}
+
+// loadPages feeds m the pages cmd reads, and those the pages ask for, until
+// no more are read.
+func loadPages(m mapDumpModel, cmd tea.Cmd) mapDumpModel {
+	for cmd != nil {
+		var page *mapDumpPageMsg
+		for _, msg := range runCmd(cmd) {
+			if msg, ok := msg.(mapDumpPageMsg); ok {
+				page = &msg
+			}
+		}
+		if page == nil {
+			break
+		}
+		cmd = m.AppendPage(page.iter, page.entries, page.done, page.err)
+	}
+	return m
+}
+
+func TestMapDumpModel_NextMatchWithoutSearch(t *testing.T) {
+	iter := &entriesIterator{entries: countEntries(5000)}
+	first, _ := iter.Next(dumpPageSize)
+	m := newMapDumpModel(80, 40)
+	m.SetMapDump(1, "big_map", first)
+	m.SetRemaining(iter, nil)
+
+	for _, r := range []rune{'n', 'N'} {
+		var cmd tea.Cmd
+		m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		if cmd != nil {
+			t.Errorf("%c without a search shouldn't read more of the map", r)
+		}
+	}
+	if m.GetEntryCount() != dumpPageSize || m.cursor != 0 {
+		t.Errorf("n and N without a search should do nothing, got %d entries and cursor %d", m.GetEntryCount(), m.cursor)
+	}
+}
+
+func TestMapDumpModel_SearchUnloadedPages(t *testing.T) {
+	iter := &entriesIterator{entries: countEntries(5000)}
+	first, _ := iter.Next(dumpPageSize)
+	m := newMapDumpModel(80, 40)
+	m.SetMapDump(1, "big_map", first)
+	m.SetRemaining(iter, nil)
+
+	// The match is beyond the loaded entries, so pages are read up to it
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = typeText(m, "u32le:2100")
+	m, cmd, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.View(), "searching the rest of the map") {
+		t.Errorf("title should say the search continues:\n%s", m.View())
+	}
+	m = loadPages(m, cmd)
+	if entry := m.SelectedEntry(); entry == nil || binary.LittleEndian.Uint32(entry.Key) != 2100 {
+		t.Fatalf("expected the entry with key 2100 to be selected, got %v", entry)
+	}
+	if m.IsComplete() {
+		t.Error("only the pages up to the match should be read")
+	}
+
+	// With no later match, n reads the rest of the map before wrapping around
+	m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = loadPages(m, cmd)
+	if !m.IsComplete() || m.SelectedEntry() == nil || binary.LittleEndian.Uint32(m.SelectedEntry().Key) != 2100 {
+		t.Errorf("n should wrap around once the map is loaded, got %v (complete %v)", m.SelectedEntry(), m.IsComplete())
+	}
+	if got := m.GetSearchMatches(); len(got) != 1 || got[0] != 2100 {
+		t.Errorf("matches = %v, want [2100]", got)
+	}
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -21,6 +23,9 @@ func (i menuItem) Title() string { return i.title }
 // Description returns the menu item description for display.
 func (i menuItem) Description() string { return i.description }
 
+// menuTitle is the title of the main menu.
+const menuTitle = "BPF TUI Explorer"
+
 // menuModel manages the main menu state.
 type menuModel struct {
 	list list.Model
@@ -39,6 +44,21 @@ func newMenuModel(width, height int) menuModel {
 			description: "Browse loaded BPF maps",
 			target:      ViewMapList,
 		},
+		menuItem{
+			title:       "Links",
+			description: "Browse BPF links and what they attach to",
+			target:      ViewLinkList,
+		},
+		menuItem{
+			title:       "BTF",
+			description: "Browse BTF objects and search their types",
+			target:      ViewBTFList,
+		},
+		menuItem{
+			title:       "Pinned",
+			description: "Browse objects pinned in bpffs",
+			target:      ViewPinTree,
+		},
 	}
 
 	// Create delegate for custom item rendering
@@ -53,7 +73,7 @@ func newMenuModel(width, height int) menuModel {
 	}
 
 	l := list.New(items, delegate, width, listHeight)
-	l.Title = "BPF TUI Explorer"
+	l.Title = menuTitle
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
@@ -100,6 +120,16 @@ func (m *menuModel) SetSize(width, height int) {
 	m.list.SetSize(width, listHeight)
 }
 
+// SetSource shows where the data comes from in the title, e.g. a snapshot.
+// An empty source means the live system.
+func (m *menuModel) SetSource(source string) {
+	if source == "" {
+		m.list.Title = menuTitle
+		return
+	}
+	m.list.Title = fmt.Sprintf("%s (%s)", menuTitle, source)
+}
+
 // SelectedItem returns the currently selected menu item, if any.
 func (m menuModel) SelectedItem() *menuItem {
 	if item, ok := m.list.SelectedItem().(menuItem); ok {
@@ -15,8 +15,8 @@ func TestNewMenuModel(t *testing.T) {
 	}
 
 	items := m.list.Items()
-	if len(items) != 2 {
-		t.Errorf("expected 2 menu items, got %d", len(items))
+	if len(items) != 5 {
+		t.Errorf("expected 5 menu items, got %d", len(items))
 	}
 
 	// Verify first item is Programs
@@ -42,6 +42,42 @@ func TestNewMenuModel(t *testing.T) {
 	} else {
 		t.Error("second item is not a menuItem")
 	}
+
+	// Verify third item is Links
+	if item, ok := items[2].(menuItem); ok {
+		if item.title != "Links" {
+			t.Errorf("expected third item title 'Links', got '%s'", item.title)
+		}
+		if item.target != ViewLinkList {
+			t.Errorf("expected third item target ViewLinkList, got %v", item.target)
+		}
+	} else {
+		t.Error("third item is not a menuItem")
+	}
+
+	// Verify fourth item is BTF
+	if item, ok := items[3].(menuItem); ok {
+		if item.title != "BTF" {
+			t.Errorf("expected fourth item title 'BTF', got '%s'", item.title)
+		}
+		if item.target != ViewBTFList {
+			t.Errorf("expected fourth item target ViewBTFList, got %v", item.target)
+		}
+	} else {
+		t.Error("fourth item is not a menuItem")
+	}
+
+	// Verify fifth item is Pinned
+	if item, ok := items[4].(menuItem); ok {
+		if item.title != "Pinned" {
+			t.Errorf("expected fifth item title 'Pinned', got '%s'", item.title)
+		}
+		if item.target != ViewPinTree {
+			t.Errorf("expected fifth item target ViewPinTree, got %v", item.target)
+		}
+	} else {
+		t.Error("fifth item is not a menuItem")
+	}
 }
 
 func TestMenuItemInterface(t *testing.T) {
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// heldProgs returns the programs a process holds.
+func heldProgs(p ProcessInfo) []uint32 { return p.ProgIDs }
+
+// heldMaps returns the maps a process holds.
+func heldMaps(p ProcessInfo) []uint32 { return p.MapIDs }
+
+// ownersOf returns the processes holding the object id, where held returns
+// the objects of the relevant kind a process holds.
+func ownersOf(procs []ProcessInfo, id uint32, held func(ProcessInfo) []uint32) []ProcessInfo {
+	var owners []ProcessInfo
+	for _, p := range procs {
+		if slices.Contains(held(p), id) {
+			owners = append(owners, p)
+		}
+	}
+	return owners
+}
+
+// ownerIndex maps object IDs to the processes holding them.
+func ownerIndex(procs []ProcessInfo, held func(ProcessInfo) []uint32) map[uint32][]ProcessInfo {
+	index := make(map[uint32][]ProcessInfo)
+	for _, p := range procs {
+		for _, id := range held(p) {
+			index[id] = append(index[id], p)
+		}
+	}
+	return index
+}
+
+// formatOwners renders processes as a short list, e.g. "cilium-agent (1234)".
+func formatOwners(owners []ProcessInfo) string {
+	if len(owners) == 0 {
+		return "no process"
+	}
+	parts := make([]string, len(owners))
+	for i, p := range owners {
+		parts[i] = fmt.Sprintf("%s (%d)", p.Comm, p.PID)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortByOwner stably sorts list items so that items held by the same
+// process are adjacent, ordered by command name and PID. Items no process
+// holds go last.
+func sortByOwner(items []list.Item, owners func(list.Item) []ProcessInfo) {
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		oa, ob := owners(a), owners(b)
+		switch {
+		case len(oa) == 0 || len(ob) == 0:
+			return cmp.Compare(len(ob), len(oa))
+		default:
+			return cmp.Or(cmp.Compare(oa[0].Comm, ob[0].Comm), cmp.Compare(oa[0].PID, ob[0].PID))
+		}
+	})
+}
+
+// processOwners holds the processes holding the object shown in a detail
+// view. Until they're known, the view doesn't show the section at all.
+type processOwners struct {
+	known bool
+	procs []ProcessInfo
+	err   error
+}
+
+// render renders the processes section of a detail view.
+func (o processOwners) render(what string) string {
+	if !o.known {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("Processes"))
+	b.WriteString("\n")
+
+	if o.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", o.err)))
+		b.WriteString("\n")
+		return b.String()
+	}
+	if len(o.procs) == 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("No process holds this %s open (it may be pinned or attached)", what)))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, p := range o.procs {
+		b.WriteString(labelStyle.Render("PID:         "))
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%d (%s)", p.PID, p.Comm)))
+		b.WriteString("\n")
+		if p.Cmdline != "" {
+			b.WriteString(labelStyle.Render("  Cmdline:   "))
+			b.WriteString(valueStyle.Render(p.Cmdline))
+			b.WriteString("\n")
+		}
+		if p.Cgroup != "" {
+			b.WriteString(labelStyle.Render("  Cgroup:    "))
+			b.WriteString(valueStyle.Render(p.Cgroup))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
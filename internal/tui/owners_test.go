@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+var testProcesses = []ProcessInfo{
+	{PID: 1234, Comm: "cilium-agent", Cmdline: "/usr/bin/cilium-agent", Cgroup: "/system.slice/cilium.service", ProgIDs: []uint32{1, 2}, MapIDs: []uint32{10}},
+	{PID: 55, Comm: "bpftool", ProgIDs: []uint32{2}},
+}
+
+func TestOwnersOf(t *testing.T) {
+	if got := ownersOf(testProcesses, 2, heldProgs); len(got) != 2 {
+		t.Errorf("expected 2 owners of prog 2, got %d", len(got))
+	}
+	if got := ownersOf(testProcesses, 10, heldMaps); len(got) != 1 || got[0].PID != 1234 {
+		t.Errorf("expected cilium-agent to own map 10, got %+v", got)
+	}
+	if got := ownersOf(testProcesses, 10, heldProgs); len(got) != 0 {
+		t.Errorf("map IDs shouldn't match programs, got %+v", got)
+	}
+
+	index := ownerIndex(testProcesses, heldProgs)
+	if got := formatOwners(index[2]); got != "cilium-agent (1234), bpftool (55)" {
+		t.Errorf("formatOwners() = %q", got)
+	}
+	if got := formatOwners(index[3]); got != "no process" {
+		t.Errorf("formatOwners(nil) = %q, want %q", got, "no process")
+	}
+}
+
+func TestSortByOwner(t *testing.T) {
+	index := ownerIndex(testProcesses, heldProgs)
+	items := []list.Item{
+		progItem{info: ProgramInfo{ID: 3}},
+		progItem{info: ProgramInfo{ID: 1}},
+		progItem{info: ProgramInfo{ID: 4}},
+		progItem{info: ProgramInfo{ID: 2}},
+	}
+	sortByOwner(items, func(item list.Item) []ProcessInfo { return index[item.(progItem).info.ID] })
+
+	var got []uint32
+	for _, item := range items {
+		got = append(got, item.(progItem).info.ID)
+	}
+	// Prog 2's first owner is cilium-agent too; unowned programs keep their order
+	want := []uint32{1, 2, 3, 4}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sorted IDs = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestProcessOwnersRender(t *testing.T) {
+	if got := (processOwners{}).render("program"); got != "" {
+		t.Errorf("unknown owners should render nothing, got %q", got)
+	}
+
+	got := processOwners{known: true, procs: testProcesses[:1]}.render("program")
+	for _, want := range []string{"Processes", "1234 (cilium-agent)", "/usr/bin/cilium-agent", "/system.slice/cilium.service"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render should contain %q, got %q", want, got)
+		}
+	}
+
+	got = processOwners{known: true}.render("map")
+	if !strings.Contains(got, "No process holds this map open") {
+		t.Errorf("render should say no process holds the map, got %q", got)
+	}
+
+	got = processOwners{known: true, err: errors.New("boom")}.render("map")
+	if !strings.Contains(got, "Error: boom") {
+		t.Errorf("render should show the error, got %q", got)
+	}
+}
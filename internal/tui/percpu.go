@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// perCPUAggregates names the aggregation rows of the per-CPU table.
+var perCPUAggregates = []string{"sum", "min", "max", "avg"}
+
+// isPerCPUMapType returns true if maps of type t store a value per possible
+// CPU. Both bpftool's names (e.g. "lru_percpu_hash") and cilium/ebpf's
+// (e.g. "lrucpuhash") are recognized.
+func isPerCPUMapType(t string) bool {
+	switch strings.ReplaceAll(strings.ToLower(t), "_", "") {
+	case "percpuhash", "percpuarray", "lrupercpuhash", "lrucpuhash", "percpucgroupstorage":
+		return true
+	default:
+		return false
+	}
+}
+
+// splitPerCPU splits a per-CPU value, the values of all possible CPUs back
+// to back, into the value of each CPU. It returns nil if data isn't a whole
+// number of size-byte values.
+func splitPerCPU(data []byte, size uint32) [][]byte {
+	if size == 0 || len(data) == 0 || uint32(len(data))%size != 0 {
+		return nil
+	}
+	values := make([][]byte, 0, uint32(len(data))/size)
+	for len(data) > 0 {
+		values = append(values, data[:size:size])
+		data = data[size:]
+	}
+	return values
+}
+
+// perCPUColumn is a column of the per-CPU table: the whole value, or one
+// member of a struct value.
+type perCPUColumn struct {
+	name     string
+	t        *BTFType    // nil shows the value in mode
+	mode     displayMode // How values are shown without a type
+	offset   uint32      // Offset of the member, in bits
+	bitfield uint32      // Size of the member in bits, or 0 if not a bitfield
+}
+
+// perCPUColumns returns the table columns for values of type t: a column
+// per member for structs, and a single column otherwise. Without BTF, values
+// of integer size are read as native-endian unsigned integers, like the
+// counters per-CPU maps usually hold.
+func perCPUColumns(t *BTFType, size uint32) []perCPUColumn {
+	if t == nil {
+		switch size {
+		case 1, 2, 4, 8:
+			t = &BTFType{Kind: BTFKindInt, Size: size}
+		}
+		return []perCPUColumn{{name: "value", t: t}}
+	}
+	if t.Kind != BTFKindStruct {
+		return []perCPUColumn{{name: "value", t: t}}
+	}
+
+	var cols []perCPUColumn
+	for _, mem := range t.Members {
+		if mem.Type == nil {
+			continue
+		}
+		name := mem.Name
+		if name == "" {
+			name = "(anon)"
+		}
+		cols = append(cols, perCPUColumn{name: name, t: mem.Type, offset: mem.Offset, bitfield: mem.BitfieldSize})
+	}
+	if len(cols) == 0 {
+		return []perCPUColumn{{name: "value", t: t}}
+	}
+	return cols
+}
+
+// cell formats the column's part of one CPU's value. Integers and floats
+// are also returned as numbers, which the aggregation rows are computed
+// from; other kinds return a nil number.
+func (c perCPUColumn) cell(data []byte) (string, *big.Rat) {
+	t := c.t
+	if t == nil {
+		return c.mode.format(data), nil
+	}
+	if c.bitfield > 0 {
+		v := readBits(data, c.offset, c.bitfield)
+		if t.Kind == BTFKindEnum {
+			return formatBTFEnum(t, v), nil
+		}
+		if t.Signed {
+			v = signExtend(v, c.bitfield)
+		}
+		return intCell(t, v)
+	}
+
+	if c.offset/8 > uint32(len(data)) {
+		return "", nil
+	}
+	data = data[c.offset/8:]
+	if uint32(len(data)) < t.Size {
+		return FormatHex(data), nil
+	}
+	switch t.Kind {
+	case BTFKindInt:
+		if t.Size <= 8 {
+			return intCell(t, readUint(data, t.Size))
+		}
+	case BTFKindFloat:
+		var f float64
+		switch t.Size {
+		case 4:
+			f = float64(math.Float32frombits(uint32(readUint(data, 4))))
+		case 8:
+			f = math.Float64frombits(readUint(data, 8))
+		default:
+			return FormatHex(data[:t.Size]), nil
+		}
+		// SetFloat64 returns nil for NaN and infinities
+		return strconv.FormatFloat(f, 'g', -1, 64), new(big.Rat).SetFloat64(f)
+	}
+	// Nested values on a single line
+	return strings.Join(strings.Fields(formatBTF(t, data)), " "), nil
+}
+
+// intCell formats an integer cell. Booleans aren't aggregated.
+func intCell(t *BTFType, v uint64) (string, *big.Rat) {
+	s := formatBTFInt(t, v)
+	switch {
+	case t.Bool:
+		return s, nil
+	case t.Signed:
+		return s, new(big.Rat).SetInt64(int64(signExtend(v, t.Size*8)))
+	default:
+		return s, new(big.Rat).SetUint64(v)
+	}
+}
+
+// aggregatePerCPU returns the sum, minimum, maximum and average of a
+// column's numbers, in the order of perCPUAggregates.
+func aggregatePerCPU(nums []*big.Rat) []string {
+	if len(nums) == 0 {
+		return make([]string, len(perCPUAggregates))
+	}
+	sum := new(big.Rat)
+	lo, hi := nums[0], nums[0]
+	for _, n := range nums {
+		sum.Add(sum, n)
+		if n.Cmp(lo) < 0 {
+			lo = n
+		}
+		if n.Cmp(hi) > 0 {
+			hi = n
+		}
+	}
+	avg := new(big.Rat).Quo(sum, big.NewRat(int64(len(nums)), 1))
+	return []string{formatRat(sum), formatRat(lo), formatRat(hi), formatRat(avg)}
+}
+
+// formatRat formats an aggregate exactly if it's a whole number, and with
+// two decimals otherwise.
+func formatRat(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return r.FloatString(2)
+}
+
+// perCPUTable renders per-CPU values as a table with a row per CPU,
+// followed by sum/min/max/avg rows if any column is numeric. With
+// totalsOnly, only the aggregation rows are shown when there are some.
+// Cells are rendered with style.
+func perCPUTable(values [][]byte, cols []perCPUColumn, totalsOnly bool, style lipgloss.Style) string {
+	header := []string{"CPU"}
+	for _, c := range cols {
+		header = append(header, c.name)
+	}
+
+	// A column is aggregated if it's numeric on every CPU
+	rows := make([][]string, len(values))
+	nums := make([][]*big.Rat, len(cols))
+	numeric := make([]bool, len(cols))
+	for j := range cols {
+		numeric[j] = true
+	}
+	for cpu, v := range values {
+		rows[cpu] = []string{strconv.Itoa(cpu)}
+		for j, c := range cols {
+			s, n := c.cell(v)
+			rows[cpu] = append(rows[cpu], s)
+			if n == nil {
+				numeric[j] = false
+			} else {
+				nums[j] = append(nums[j], n)
+			}
+		}
+	}
+
+	if slices.Contains(numeric, true) {
+		totals := make([][]string, len(perCPUAggregates))
+		for k, name := range perCPUAggregates {
+			totals[k] = []string{name}
+		}
+		for j := range cols {
+			agg := make([]string, len(perCPUAggregates))
+			if numeric[j] {
+				agg = aggregatePerCPU(nums[j])
+			}
+			for k := range totals {
+				totals[k] = append(totals[k], agg[k])
+			}
+		}
+		if totalsOnly {
+			rows = nil
+		}
+		rows = append(rows, totals...)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for j, s := range row {
+			widths[j] = max(widths[j], lipgloss.Width(s))
+		}
+	}
+
+	// The CPU column is left-aligned, values right-aligned
+	render := func(row []string, isHeader bool) string {
+		var b strings.Builder
+		for j, s := range row {
+			if j == 0 {
+				b.WriteString(dimStyle.Render(s + strings.Repeat(" ", widths[0]-lipgloss.Width(s))))
+				continue
+			}
+			s = strings.Repeat(" ", widths[j]-lipgloss.Width(s)) + s
+			b.WriteString("  ")
+			if isHeader {
+				b.WriteString(dimStyle.Render(s))
+			} else {
+				b.WriteString(style.Render(s))
+			}
+		}
+		return b.String()
+	}
+	lines := []string{render(header, true)}
+	for _, row := range rows {
+		lines = append(lines, render(row, false))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// perCPUValue returns a per-CPU value of 8-byte counters, one per CPU.
+func perCPUValue(counts ...uint64) []byte {
+	var data []byte
+	for _, c := range counts {
+		data = binary.LittleEndian.AppendUint64(data, c)
+	}
+	return data
+}
+
+func TestIsPerCPUMapType(t *testing.T) {
+	for _, typ := range []string{"percpu_hash", "percpu_array", "lru_percpu_hash", "percpuhash", "lrucpuhash"} {
+		if !isPerCPUMapType(typ) {
+			t.Errorf("%q should be per-CPU", typ)
+		}
+	}
+	for _, typ := range []string{"hash", "array", "lru_hash", "ringbuf"} {
+		if isPerCPUMapType(typ) {
+			t.Errorf("%q should not be per-CPU", typ)
+		}
+	}
+}
+
+func TestSplitPerCPU(t *testing.T) {
+	values := splitPerCPU(perCPUValue(1, 2, 3), 8)
+	if len(values) != 3 || values[2][0] != 3 {
+		t.Errorf("splitPerCPU() = %v", values)
+	}
+	if values := splitPerCPU(make([]byte, 12), 8); values != nil {
+		t.Errorf("a partial value should not be split, got %v", values)
+	}
+}
+
+func TestPerCPUTable(t *testing.T) {
+	u64 := &BTFType{Kind: BTFKindInt, Name: "__u64", Size: 8}
+	value := &BTFType{Kind: BTFKindStruct, Name: "stats", Size: 16, Members: []BTFMember{
+		{Name: "packets", Offset: 0, Type: u64},
+		{Name: "bytes", Offset: 64, Type: u64},
+	}}
+	values := [][]byte{perCPUValue(1, 100), perCPUValue(4, 300)}
+
+	table := perCPUTable(values, perCPUColumns(value, 16), false, valueStyle)
+	lines := strings.Split(table, "\n")
+	want := [][]string{
+		{"CPU", "packets", "bytes"},
+		{"0", "1", "100"},
+		{"1", "4", "300"},
+		{"sum", "5", "400"},
+		{"min", "1", "100"},
+		{"max", "4", "300"},
+		{"avg", "2.50", "200"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got:\n%s", len(want), table)
+	}
+	for i, fields := range want {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(fields, " ") {
+			t.Errorf("line %d = %q, want %q", i, got, fields)
+		}
+	}
+
+	// Totals only
+	table = perCPUTable(values, perCPUColumns(value, 16), true, valueStyle)
+	if lines := strings.Split(table, "\n"); len(lines) != 5 || !strings.HasPrefix(lines[1], "sum") {
+		t.Errorf("expected only the header and aggregation rows:\n%s", table)
+	}
+
+	// Raw hex can't be aggregated
+	table = perCPUTable(values, []perCPUColumn{{name: "value"}}, true, valueStyle)
+	if strings.Contains(table, "sum") || !strings.Contains(table, "04 00 00 00") {
+		t.Errorf("raw values should be listed per CPU:\n%s", table)
+	}
+}
+
+func TestPerCPUTableSigned(t *testing.T) {
+	s32 := &BTFType{Kind: BTFKindInt, Name: "int", Size: 4, Signed: true}
+	values := [][]byte{{0xff, 0xff, 0xff, 0xff}, {2, 0, 0, 0}}
+
+	table := perCPUTable(values, perCPUColumns(s32, 4), true, lipgloss.NewStyle())
+	fields := strings.Join(strings.Fields(table), " ")
+	if want := "CPU value sum 1 min -1 max 2 avg 0.50"; fields != want {
+		t.Errorf("table = %q, want %q", fields, want)
+	}
+}
+
+func TestMapDumpModel_PerCPU(t *testing.T) {
+	m := newMapDumpModel(80, 40)
+	m.SetEntrySizes(4, 8)
+	m.SetPerCPU(true)
+	m.SetMapDump(1, "pkt_count", []MapEntry{
+		{Key: []byte{0, 0, 0, 0}, Value: perCPUValue(10, 20, 30, 40)},
+	})
+
+	view := m.View()
+	for _, want := range []string{"per-CPU values, 4 CPUs", "CPU", "sum", "100", "avg"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Only the aggregation rows
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	view = m.View()
+	if !containsString(view, "totals only") || containsString(view, "30") {
+		t.Errorf("a should hide the per-CPU rows:\n%s", view)
+	}
+
+	// Edits take the values of all CPUs
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !containsString(m.View(), "32 bytes") {
+		t.Errorf("editor should expect a value per CPU:\n%s", m.View())
+	}
+}
+
+// TestIntegrationMapDumpPerCPU tests that per-CPU maps are recognized by
+// their type when dumped from the map detail view.
+func TestIntegrationMapDumpPerCPU(t *testing.T) {
+	svc := &mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 1, Name: "pkt_count", Type: "percpu_array", KeySize: 4, ValueSize: 8, MaxEntries: 1}},
+		entries: []MapEntry{{Key: []byte{0, 0, 0, 0}, Value: perCPUValue(1, 2)}},
+	}
+	m := openMapDump(NewModel(&mockProgService{}, svc))
+
+	if view := m.View(); !containsString(view, "per-CPU values, 2 CPUs") || !containsString(view, "sum") {
+		t.Errorf("expected a per-CPU table:\n%s", view)
+	}
+}
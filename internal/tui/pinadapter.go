@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/link"
+)
+
+// PinServiceAdapter implements PinService by walking every bpffs mounted in
+// bpftui's mount namespace.
+type PinServiceAdapter struct {
+	mounts string // Mount table, in /proc/<pid>/mounts format
+}
+
+// NewPinServiceAdapter creates a new pin service adapter.
+func NewPinServiceAdapter() *PinServiceAdapter {
+	return &PinServiceAdapter{mounts: "/proc/self/mounts"}
+}
+
+// List returns every file and directory in the mounted bpffs instances.
+// Entries that can't be read are skipped, and pinned files that can't be
+// opened are returned without a kind.
+func (a *PinServiceAdapter) List() ([]PinInfo, error) {
+	f, err := os.Open(a.mounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	roots := parseBPFFSMounts(f)
+	f.Close()
+
+	targets := newLinkTargets()
+	var pins []PinInfo
+	for _, root := range roots {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Skip entries we can't access
+			}
+			if d.IsDir() {
+				pins = append(pins, PinInfo{Path: path, Dir: true})
+				return nil
+			}
+			pins = append(pins, resolvePin(path, targets))
+			return nil
+		})
+	}
+	return pins, nil
+}
+
+// parseBPFFSMounts returns the mount points of bpffs instances in a mount
+// table, without duplicates.
+func parseBPFFSMounts(r io.Reader) []string {
+	var roots []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 3 || fields[2] != "bpf" {
+			continue
+		}
+		root := unescapeMountPath(fields[1])
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// unescapeMountPath decodes the octal escapes (e.g. "\040" for a space) the
+// kernel uses for whitespace and backslashes in mount table paths.
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if c, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// resolvePin identifies the object pinned at path by trying to open it as
+// each kind of object in turn.
+func resolvePin(path string, targets linkTargets) PinInfo {
+	pin := PinInfo{Path: path}
+
+	if prog, err := ebpf.LoadPinnedProgram(path, nil); err == nil {
+		defer prog.Close()
+		if info, err := prog.Info(); err == nil {
+			id, _ := info.ID()
+			pin.Kind, pin.ID, pin.Name = "prog", uint32(id), info.Name
+		}
+		return pin
+	}
+
+	if m, err := ebpf.LoadPinnedMap(path, nil); err == nil {
+		defer m.Close()
+		if info, err := m.Info(); err == nil {
+			id, _ := info.ID()
+			pin.Kind, pin.ID, pin.Name = "map", uint32(id), info.Name
+		}
+		return pin
+	}
+
+	if l, err := link.LoadPinnedLink(path, nil); err == nil {
+		defer l.Close()
+		if info, err := readLinkInfo(l, targets); err == nil {
+			pin.Kind, pin.ID, pin.Name = "link", info.ID, info.Type
+		}
+	}
+	return pin
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBPFFSMounts(t *testing.T) {
+	mounts := `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+bpf /sys/fs/bpf bpf rw,nosuid,nodev,noexec,relatime,mode=700 0 0
+tmpfs /run tmpfs rw,nosuid,nodev 0 0
+bpf /run/my\040pins bpf rw,relatime 0 0
+bpf /sys/fs/bpf bpf rw,relatime 0 0
+`
+	got := parseBPFFSMounts(strings.NewReader(mounts))
+	want := []string{"/sys/fs/bpf", "/run/my pins"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBPFFSMounts() = %q, want %q", got, want)
+	}
+}
+
+func TestPinServiceAdapterList(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "tc", "globals"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Not a pinned object, so it can't be resolved
+	if err := os.WriteFile(filepath.Join(root, "tc", "globals", "stale"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mounts := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(mounts, []byte("bpf "+root+" bpf rw 0 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pins, err := (&PinServiceAdapter{mounts: mounts}).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []PinInfo{
+		{Path: root, Dir: true},
+		{Path: filepath.Join(root, "tc"), Dir: true},
+		{Path: filepath.Join(root, "tc", "globals"), Dir: true},
+		{Path: filepath.Join(root, "tc", "globals", "stale")},
+	}
+	if !reflect.DeepEqual(pins, want) {
+		t.Errorf("List() = %+v, want %+v", pins, want)
+	}
+}
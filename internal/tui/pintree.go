@@ -0,0 +1,352 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pinNode is a file or directory in the pinned objects tree.
+type pinNode struct {
+	info     PinInfo
+	parent   *pinNode
+	children []*pinNode
+}
+
+// pinRow is a visible row of the tree.
+type pinRow struct {
+	node  *pinNode
+	depth int
+}
+
+// buildPinTree arranges pins into trees, one per mount point. Directories
+// are listed before files, each sorted by name.
+func buildPinTree(pins []PinInfo) []*pinNode {
+	nodes := make(map[string]*pinNode, len(pins))
+	for _, pin := range pins {
+		nodes[pin.Path] = &pinNode{info: pin}
+	}
+
+	var roots []*pinNode
+	for _, pin := range pins {
+		node := nodes[pin.Path]
+		if parent, ok := nodes[filepath.Dir(pin.Path)]; ok && parent != node && parent.info.Dir {
+			node.parent = parent
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	byName := func(a, b *pinNode) int {
+		if a.info.Dir != b.info.Dir {
+			if a.info.Dir {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.info.Path, b.info.Path)
+	}
+	for _, node := range nodes {
+		slices.SortFunc(node.children, byName)
+	}
+	slices.SortFunc(roots, byName)
+	return roots
+}
+
+// pinTreeModel manages the pinned objects view: a collapsible tree of the
+// files in every mounted bpffs.
+type pinTreeModel struct {
+	roots    []*pinNode
+	expanded map[string]bool // Expanded directories, by path
+	rows     []pinRow        // Visible rows
+	cursor   int             // Index into rows
+	offset   int             // First visible row
+	objects  int             // Number of pinned files
+	width    int
+	height   int
+	loading  bool
+	spinner  spinner.Model
+	err      error
+}
+
+// newPinTreeModel creates a new pinned objects tree model.
+func newPinTreeModel(width, height int) pinTreeModel {
+	return pinTreeModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+	}
+}
+
+// SetPins sets the contents of the tree. Mount points start out expanded;
+// on later loads, expanded directories and the cursor are kept.
+func (m *pinTreeModel) SetPins(pins []PinInfo) {
+	var selected string
+	if node := m.selectedNode(); node != nil {
+		selected = node.info.Path
+	}
+
+	m.roots = buildPinTree(pins)
+	m.loading = false
+	m.err = nil
+	m.objects = 0
+	for _, pin := range pins {
+		if !pin.Dir {
+			m.objects++
+		}
+	}
+	if m.expanded == nil {
+		m.expanded = make(map[string]bool)
+		for _, root := range m.roots {
+			m.expanded[root.info.Path] = true
+		}
+	}
+
+	m.rebuildRows()
+	m.cursor = 0
+	for i, row := range m.rows {
+		if row.node.info.Path == selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.scrollToCursor()
+}
+
+// SetError sets an error state for the tree.
+func (m *pinTreeModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *pinTreeModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.err = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the tree dimensions.
+func (m *pinTreeModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.scrollToCursor()
+}
+
+// rebuildRows recomputes the visible rows from the expanded directories.
+func (m *pinTreeModel) rebuildRows() {
+	m.rows = nil
+	var walk func(nodes []*pinNode, depth int)
+	walk = func(nodes []*pinNode, depth int) {
+		for _, node := range nodes {
+			m.rows = append(m.rows, pinRow{node: node, depth: depth})
+			if node.info.Dir && m.expanded[node.info.Path] {
+				walk(node.children, depth+1)
+			}
+		}
+	}
+	walk(m.roots, 0)
+	m.cursor = min(m.cursor, max(len(m.rows)-1, 0))
+}
+
+// visibleRows returns how many rows fit on screen.
+func (m pinTreeModel) visibleRows() int {
+	// Title, status line, help bar and spacing
+	return max(m.height-7, 1)
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the tree.
+func (m *pinTreeModel) moveCursor(delta int) {
+	m.cursor = max(min(m.cursor+delta, len(m.rows)-1), 0)
+	m.scrollToCursor()
+}
+
+// scrollToCursor adjusts the offset so that the cursor is visible.
+func (m *pinTreeModel) scrollToCursor() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// setExpanded expands or collapses the directory under the cursor.
+func (m *pinTreeModel) setExpanded(node *pinNode, expanded bool) {
+	m.expanded[node.info.Path] = expanded
+	m.rebuildRows()
+	m.scrollToCursor()
+}
+
+// moveTo moves the cursor to the row showing node, if it's visible.
+func (m *pinTreeModel) moveTo(node *pinNode) {
+	for i, row := range m.rows {
+		if row.node == node {
+			m.cursor = i
+			m.scrollToCursor()
+			return
+		}
+	}
+}
+
+// Init implements tea.Model for pinTreeModel.
+func (m pinTreeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the tree.
+// Returns the updated model, an optional command, and the selected pinned
+// object if Enter was pressed on one.
+func (m pinTreeModel) Update(msg tea.Msg) (pinTreeModel, tea.Cmd, *PinInfo) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
+	case tea.KeyMsg:
+		node := m.selectedNode()
+		switch msg.String() {
+		case "up", "k":
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "pgup":
+			m.moveCursor(-m.visibleRows())
+		case "pgdown":
+			m.moveCursor(m.visibleRows())
+		case "home", "g":
+			m.moveCursor(-len(m.rows))
+		case "end", "G":
+			m.moveCursor(len(m.rows))
+
+		case "enter":
+			switch {
+			case node == nil:
+			case node.info.Dir:
+				m.setExpanded(node, !m.expanded[node.info.Path])
+			case node.info.Kind != "":
+				pin := node.info
+				return m, nil, &pin
+			}
+
+		case "right", "l":
+			// Expand a directory, or step into it if it already is
+			if node != nil && node.info.Dir {
+				if !m.expanded[node.info.Path] {
+					m.setExpanded(node, true)
+				} else if len(node.children) > 0 {
+					m.moveCursor(1)
+				}
+			}
+
+		case "left", "h":
+			// Collapse a directory, or step out to the parent
+			if node == nil {
+				break
+			}
+			if node.info.Dir && m.expanded[node.info.Path] {
+				m.setExpanded(node, false)
+			} else if node.parent != nil {
+				m.moveTo(node.parent)
+			}
+		}
+	}
+	return m, nil, nil
+}
+
+// View renders the tree.
+func (m pinTreeModel) View() string {
+	title := titleStyle.Render("Pinned Objects")
+
+	if m.loading {
+		return title + "\n\n" + m.spinner.View() + dimStyle.Render(" Loading pinned objects...")
+	}
+
+	if m.err != nil {
+		return title + "\n\n" + errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	if len(m.roots) == 0 {
+		return title + "\n\n" + dimStyle.Render("No bpffs mounted")
+	}
+
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	end := min(m.offset+m.visibleRows(), len(m.rows))
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+		line := strings.Repeat("  ", row.depth) + m.renderNode(row)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			b.WriteString(normalStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("%d pinned files in %d bpffs mounts", m.objects, len(m.roots))))
+	return b.String()
+}
+
+// renderNode renders a row's directory marker, name and pinned object.
+func (m pinTreeModel) renderNode(row pinRow) string {
+	info := row.node.info
+	name := filepath.Base(info.Path)
+	if row.depth == 0 {
+		name = info.Path
+	}
+
+	if info.Dir {
+		marker := "▸ "
+		if m.expanded[info.Path] {
+			marker = "▾ "
+		}
+		return marker + name + "/"
+	}
+
+	if info.Kind == "" {
+		return "  " + name + dimStyle.Render("  (unknown)")
+	}
+	return "  " + name + dimStyle.Render(fmt.Sprintf("  %s [%d] %s", info.Kind, info.ID, info.Name))
+}
+
+// selectedNode returns the node under the cursor, if any.
+func (m pinTreeModel) selectedNode() *pinNode {
+	if m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].node
+}
+
+// SelectedPin returns the file or directory under the cursor, if any.
+func (m pinTreeModel) SelectedPin() *PinInfo {
+	node := m.selectedNode()
+	if node == nil {
+		return nil
+	}
+	pin := node.info
+	return &pin
+}
+
+// IsLoading returns true if the tree is loading.
+func (m pinTreeModel) IsLoading() bool {
+	return m.loading
+}
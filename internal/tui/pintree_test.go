@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pinRowPaths returns the paths of the tree's visible rows.
+func pinRowPaths(m pinTreeModel) []string {
+	var paths []string
+	for _, row := range m.rows {
+		paths = append(paths, row.node.info.Path)
+	}
+	return paths
+}
+
+func TestBuildPinTree(t *testing.T) {
+	roots := buildPinTree([]PinInfo{
+		{Path: "/sys/fs/bpf/zeta", Kind: "map", ID: 1},
+		{Path: "/run/bpf", Dir: true},
+		{Path: "/sys/fs/bpf", Dir: true},
+		{Path: "/sys/fs/bpf/alpha", Kind: "prog", ID: 2},
+		{Path: "/sys/fs/bpf/tc", Dir: true},
+		{Path: "/sys/fs/bpf/tc/globals", Kind: "map", ID: 3},
+	})
+
+	if len(roots) != 2 || roots[0].info.Path != "/run/bpf" || roots[1].info.Path != "/sys/fs/bpf" {
+		t.Fatalf("expected two mount points, got %d", len(roots))
+	}
+
+	var children []string
+	for _, child := range roots[1].children {
+		children = append(children, child.info.Path)
+	}
+	// Directories first, then files by name
+	if got, want := strings.Join(children, ","), "/sys/fs/bpf/tc,/sys/fs/bpf/alpha,/sys/fs/bpf/zeta"; got != want {
+		t.Errorf("children = %s, want %s", got, want)
+	}
+	if roots[1].children[0].children[0].parent != roots[1].children[0] {
+		t.Error("children should link to their parent")
+	}
+}
+
+func TestPinTreeExpandCollapse(t *testing.T) {
+	m := newPinTreeModel(80, 24)
+	m.SetPins([]PinInfo{
+		{Path: "/sys/fs/bpf", Dir: true},
+		{Path: "/sys/fs/bpf/tc", Dir: true},
+		{Path: "/sys/fs/bpf/tc/globals", Kind: "map", ID: 3, Name: "globals"},
+		{Path: "/sys/fs/bpf/prog", Kind: "prog", ID: 2, Name: "prog"},
+	})
+
+	// Mount points start out expanded
+	if got, want := strings.Join(pinRowPaths(m), ","), "/sys/fs/bpf,/sys/fs/bpf/tc,/sys/fs/bpf/prog"; got != want {
+		t.Fatalf("rows = %s, want %s", got, want)
+	}
+
+	// Enter toggles a directory; l expands then steps in
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if len(m.rows) != 4 {
+		t.Fatalf("expected tc to expand, got rows %v", pinRowPaths(m))
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if got := m.SelectedPin(); got == nil || got.Path != "/sys/fs/bpf/tc/globals" {
+		t.Fatalf("l on an expanded directory should step in, got %+v", got)
+	}
+
+	// h steps out to the parent, then collapses it
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if got := m.SelectedPin(); got == nil || got.Path != "/sys/fs/bpf/tc" {
+		t.Fatalf("h should step out to the parent, got %+v", got)
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if len(m.rows) != 3 {
+		t.Errorf("h should collapse tc, got rows %v", pinRowPaths(m))
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.rows) != 4 {
+		t.Errorf("enter should expand tc, got rows %v", pinRowPaths(m))
+	}
+	if !strings.Contains(m.View(), "▾ tc/") {
+		t.Error("view should mark tc as expanded")
+	}
+
+	// Reloading keeps expanded directories and the cursor
+	m.SetPins([]PinInfo{
+		{Path: "/sys/fs/bpf", Dir: true},
+		{Path: "/sys/fs/bpf/tc", Dir: true},
+		{Path: "/sys/fs/bpf/tc/globals", Kind: "map", ID: 3, Name: "globals"},
+	})
+	if len(m.rows) != 3 || m.SelectedPin().Path != "/sys/fs/bpf/tc" {
+		t.Errorf("reload should keep the tree state, got rows %v", pinRowPaths(m))
+	}
+}
+
+func TestPinTreeSelect(t *testing.T) {
+	m := newPinTreeModel(80, 24)
+	m.SetPins([]PinInfo{
+		{Path: "/sys/fs/bpf", Dir: true},
+		{Path: "/sys/fs/bpf/prog", Kind: "prog", ID: 2, Name: "xdp"},
+		{Path: "/sys/fs/bpf/stale", Name: ""},
+	})
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if selected == nil || selected.Kind != "prog" || selected.ID != 2 {
+		t.Fatalf("expected the program to be selected, got %+v", selected)
+	}
+
+	// Files that aren't BPF objects can't be opened
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if _, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); selected != nil {
+		t.Errorf("expected no selection for an unknown file, got %+v", selected)
+	}
+	if !strings.Contains(m.View(), "(unknown)") {
+		t.Error("view should mark unknown files")
+	}
+}
+
+func TestPinTreeView(t *testing.T) {
+	m := newPinTreeModel(80, 24)
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading pinned objects...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetPins(nil)
+	if !strings.Contains(m.View(), "No bpffs mounted") {
+		t.Error("view should say no bpffs is mounted")
+	}
+
+	m.SetError(errors.New("boom"))
+	if !strings.Contains(m.View(), "Error: boom") {
+		t.Error("view should show the error")
+	}
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ProcessServiceAdapter implements ProcessService by scanning the file
+// descriptors of every process under /proc.
+type ProcessServiceAdapter struct {
+	root string // procfs mount point
+}
+
+// NewProcessServiceAdapter creates a new process service adapter.
+func NewProcessServiceAdapter() *ProcessServiceAdapter {
+	return &ProcessServiceAdapter{root: "/proc"}
+}
+
+// List returns the processes holding BPF programs, maps or links open,
+// sorted by PID. Processes that exit while being scanned, or whose file
+// descriptors can't be read (other users' processes, without root), are
+// skipped.
+func (a *ProcessServiceAdapter) List() ([]ProcessInfo, error) {
+	entries, err := os.ReadDir(a.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", a.root, err)
+	}
+
+	var procs []ProcessInfo
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		proc, ok := readProcess(filepath.Join(a.root, e.Name()), pid)
+		if ok {
+			procs = append(procs, proc)
+		}
+	}
+
+	slices.SortFunc(procs, func(a, b ProcessInfo) int { return a.PID - b.PID })
+	return procs, nil
+}
+
+// readProcess reads the BPF objects held by the process at dir.
+// Returns false if the process holds none.
+func readProcess(dir string, pid int) (ProcessInfo, bool) {
+	fds, err := os.ReadDir(filepath.Join(dir, "fd"))
+	if err != nil {
+		return ProcessInfo{}, false
+	}
+
+	proc := ProcessInfo{PID: pid}
+	for _, fd := range fds {
+		// Only BPF objects are anonymous inodes named after their kind,
+		// so avoid reading fdinfo for every open file
+		target, err := os.Readlink(filepath.Join(dir, "fd", fd.Name()))
+		if err != nil || !strings.HasPrefix(target, "anon_inode:bpf") {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, "fdinfo", fd.Name()))
+		if err != nil {
+			continue
+		}
+		progID, mapID := parseFDInfo(f)
+		f.Close()
+
+		if progID != 0 && !slices.Contains(proc.ProgIDs, progID) {
+			proc.ProgIDs = append(proc.ProgIDs, progID)
+		}
+		if mapID != 0 && !slices.Contains(proc.MapIDs, mapID) {
+			proc.MapIDs = append(proc.MapIDs, mapID)
+		}
+	}
+	if len(proc.ProgIDs) == 0 && len(proc.MapIDs) == 0 {
+		return ProcessInfo{}, false
+	}
+
+	slices.Sort(proc.ProgIDs)
+	slices.Sort(proc.MapIDs)
+	if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+		proc.Comm = strings.TrimSpace(string(comm))
+	}
+	if cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil {
+		proc.Cmdline = strings.Join(strings.FieldsFunc(string(cmdline), func(r rune) bool { return r == 0 }), " ")
+	}
+	if cgroup, err := os.ReadFile(filepath.Join(dir, "cgroup")); err == nil {
+		proc.Cgroup = parseCgroup(cgroup)
+	}
+	return proc, true
+}
+
+// parseFDInfo returns the program and map IDs from the fdinfo of a BPF file
+// descriptor. Link file descriptors report the ID of the program attached
+// through them, since holding a link keeps its program loaded.
+func parseFDInfo(r io.Reader) (progID, mapID uint32) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		key, value, ok := strings.Cut(s.Text(), ":")
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "prog_id":
+			progID = uint32(id)
+		case "map_id":
+			mapID = uint32(id)
+		}
+	}
+	return progID, mapID
+}
+
+// parseCgroup returns the cgroup path from /proc/<pid>/cgroup: the cgroup v2
+// path if there is one, otherwise the first hierarchy's.
+func parseCgroup(data []byte) string {
+	var first string
+	for line := range bytes.Lines(data) {
+		fields := strings.SplitN(strings.TrimSpace(string(line)), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			return fields[2]
+		}
+		if first == "" {
+			first = fields[2]
+		}
+	}
+	return first
+}
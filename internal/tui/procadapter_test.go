@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFakeProcess creates /proc/<pid> under root with the given fds, each
+// an fd symlink target and its fdinfo.
+func writeFakeProcess(t *testing.T, root, pid, comm, cmdline, cgroup string, fds map[string][2]string) {
+	t.Helper()
+	dir := filepath.Join(root, pid)
+	for _, sub := range []string{"fd", "fdinfo"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{"comm": comm + "\n", "cmdline": cmdline, "cgroup": cgroup}
+	for fd, f := range fds {
+		if err := os.Symlink(f[0], filepath.Join(dir, "fd", fd)); err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Join("fdinfo", fd)] = f[1]
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProcessServiceAdapterList(t *testing.T) {
+	root := t.TempDir()
+	writeFakeProcess(t, root, "1234", "cilium-agent", "/usr/bin/cilium-agent\x00--debug\x00",
+		"0::/system.slice/cilium.service\n", map[string][2]string{
+			"3": {"anon_inode:bpf-prog", "pos:\t0\nflags:\t02000002\nprog_type:\t6\nprog_id:\t42\n"},
+			"4": {"anon_inode:bpf-map", "map_type:\t1\nmap_id:\t7\n"},
+			"5": {"anon_inode:bpf-map", "map_type:\t1\nmap_id:\t7\n"}, // Same map twice
+			"6": {"anon_inode:bpf_link", "link_type:\txdp\nlink_id:\t3\nprog_id:\t43\n"},
+			"7": {"/dev/null", "prog_id:\t99\n"}, // Not a BPF object
+		})
+	writeFakeProcess(t, root, "99", "sshd", "sshd\x00", "0::/\n", map[string][2]string{
+		"0": {"/dev/null", ""},
+	})
+	writeFakeProcess(t, root, "55", "bpftool", "bpftool\x00", "12:cpu:/user.slice\n", map[string][2]string{
+		"3": {"anon_inode:bpf-map", "map_id:\t8\n"},
+	})
+
+	procs, err := (&ProcessServiceAdapter{root: root}).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := []ProcessInfo{
+		{PID: 55, Comm: "bpftool", Cmdline: "bpftool", Cgroup: "/user.slice", MapIDs: []uint32{8}},
+		{
+			PID: 1234, Comm: "cilium-agent", Cmdline: "/usr/bin/cilium-agent --debug",
+			Cgroup: "/system.slice/cilium.service", ProgIDs: []uint32{42, 43}, MapIDs: []uint32{7},
+		},
+	}
+	if !reflect.DeepEqual(procs, want) {
+		t.Errorf("List() = %+v, want %+v", procs, want)
+	}
+}
+
+func TestParseFDInfo(t *testing.T) {
+	progID, mapID := parseFDInfo(strings.NewReader("prog_type:\t6\nprog_jited:\t1\nprog_tag:\tabc\nprog_id:\t42\n"))
+	if progID != 42 || mapID != 0 {
+		t.Errorf("parseFDInfo(prog) = %d, %d, want 42, 0", progID, mapID)
+	}
+
+	progID, mapID = parseFDInfo(strings.NewReader("map_type:\t2\nkey_size:\t4\nmap_id:\t7\n"))
+	if progID != 0 || mapID != 7 {
+		t.Errorf("parseFDInfo(map) = %d, %d, want 0, 7", progID, mapID)
+	}
+}
+
+func TestParseCgroup(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"v2", "0::/system.slice/foo.service\n", "/system.slice/foo.service"},
+		{"hybrid prefers v2", "12:cpu,cpuacct:/user.slice\n0::/init.scope\n", "/init.scope"},
+		{"v1 only", "12:cpu,cpuacct:/user.slice\n11:memory:/other\n", "/user.slice"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCgroup([]byte(tt.data)); got != tt.want {
+				t.Errorf("parseCgroup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
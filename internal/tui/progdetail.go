@@ -3,7 +3,9 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,26 +13,40 @@ import (
 // progDetailModel manages the program detail view state.
 type progDetailModel struct {
 	program  *ProgramInfo
+	sample   progSample // Run counters as of the last update
+	rate     progRate   // Activity between the last two updates
+	statsOff bool       // Kernel isn't collecting run statistics
+	statsErr error      // Error from the last attempt to enable them
+	owners   processOwners
 	viewport viewport.Model
 	mapIDs   []uint32 // For navigation to maps
 	cursor   int      // Selected map ID index (-1 means no map selected)
 	width    int
 	height   int
 	ready    bool
+	loading  bool // Fetching the program by ID, e.g. when navigating from a link
+	spinner  spinner.Model
 }
 
 // newProgDetailModel creates a new program detail model.
 func newProgDetailModel(width, height int) progDetailModel {
 	return progDetailModel{
-		width:  width,
-		height: height,
-		cursor: -1,
+		width:   width,
+		height:  height,
+		cursor:  -1,
+		spinner: newSpinner(),
 	}
 }
 
 // SetProgram sets the program to display.
 func (m *progDetailModel) SetProgram(prog *ProgramInfo) {
 	m.program = prog
+	m.loading = false
+	m.rate = progRate{}
+	m.owners = processOwners{}
+	if prog != nil {
+		m.sample = newProgSample(*prog, time.Now())
+	}
 	m.cursor = -1
 	if prog != nil && len(prog.MapIDs) > 0 {
 		m.mapIDs = prog.MapIDs
@@ -41,6 +57,62 @@ func (m *progDetailModel) SetProgram(prog *ProgramInfo) {
 	m.updateViewport()
 }
 
+// SetLoading sets the loading state.
+// When loading starts, the previous program is cleared and the returned
+// command starts the spinner.
+func (m *progDetailModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.program = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// RefreshProgram updates the displayed program with data polled at the given
+// time, keeping the selected map and computing run rates against the previous update.
+func (m *progDetailModel) RefreshProgram(prog *ProgramInfo, at time.Time) {
+	if m.program == nil || prog == nil || prog.ID != m.program.ID {
+		return
+	}
+
+	sample := newProgSample(*prog, at)
+	m.rate = newProgRate(m.sample, sample)
+	m.sample = sample
+	m.program = prog
+	m.mapIDs = prog.MapIDs
+	if len(m.mapIDs) == 0 {
+		m.cursor = -1
+	} else {
+		m.cursor = min(max(m.cursor, 0), len(m.mapIDs)-1)
+	}
+	m.updateViewport()
+}
+
+// SetProcesses shows which of procs hold the displayed program, or why
+// they couldn't be found.
+func (m *progDetailModel) SetProcesses(procs []ProcessInfo, err error) {
+	if m.program == nil {
+		return
+	}
+	m.owners = processOwners{known: true, procs: ownersOf(procs, m.program.ID, heldProgs), err: err}
+	m.updateViewport()
+}
+
+// SetStatsOff records whether the kernel is collecting run statistics.
+// While it isn't, the view prompts the user to enable them.
+func (m *progDetailModel) SetStatsOff(off bool) {
+	m.statsOff = off
+	m.statsErr = nil
+	m.updateViewport()
+}
+
+// SetStatsError shows why enabling run statistics failed.
+func (m *progDetailModel) SetStatsError(err error) {
+	m.statsErr = err
+	m.updateViewport()
+}
+
 // SetSize updates the viewport dimensions.
 func (m *progDetailModel) SetSize(width, height int) {
 	m.width = width
@@ -123,6 +195,29 @@ func (m *progDetailModel) renderContent() string {
 	b.WriteString(valueStyle.Render(fmt.Sprintf("%d", p.MemLock)))
 	b.WriteString("\n")
 
+	// Run statistics section
+	b.WriteString(labelStyle.Render("Run Count:   "))
+	b.WriteString(valueStyle.Render(fmt.Sprintf("%d", p.RunCount)))
+	b.WriteString("\n")
+
+	b.WriteString(labelStyle.Render("Run Time:    "))
+	b.WriteString(valueStyle.Render(fmt.Sprintf("%d ns", p.RunTime.Nanoseconds())))
+	b.WriteString("\n")
+
+	b.WriteString(labelStyle.Render("Avg Run Time:"))
+	b.WriteString(valueStyle.Render(fmt.Sprintf("%d ns", avgRunTime(*p).Nanoseconds())))
+	b.WriteString("\n")
+
+	if m.rate.valid {
+		b.WriteString(labelStyle.Render("Recent:      "))
+		b.WriteString(valueStyle.Render(formatStats(*p, m.rate)))
+		b.WriteString("\n")
+	}
+	if m.statsOff {
+		b.WriteString(renderStatsPrompt(m.statsErr))
+		b.WriteString("\n")
+	}
+
 	// Pinned status section
 	b.WriteString(labelStyle.Render("Pinned:      "))
 	if p.Pinned {
@@ -138,6 +233,9 @@ func (m *progDetailModel) renderContent() string {
 		b.WriteString("\n")
 	}
 
+	// Owning processes section
+	b.WriteString(m.owners.render("program"))
+
 	// Associated Maps section
 	b.WriteString("\n")
 	b.WriteString(titleStyle.Render("Associated Maps"))
@@ -157,8 +255,12 @@ func (m *progDetailModel) renderContent() string {
 		}
 		b.WriteString("\n")
 		b.WriteString(dimStyle.Render("Press Enter to view map details"))
+		b.WriteString("\n")
 	}
 
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Press d to disassemble the program, J for its JIT-compiled code, B for its BTF functions"))
+
 	return b.String()
 }
 
@@ -171,6 +273,15 @@ func (m progDetailModel) Init() tea.Cmd {
 // Returns the updated model, an optional command, and the selected map ID if Enter was pressed on a map.
 func (m progDetailModel) Update(msg tea.Msg) (progDetailModel, tea.Cmd, *uint32) {
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "up", "k":
@@ -205,6 +316,11 @@ func (m progDetailModel) Update(msg tea.Msg) (progDetailModel, tea.Cmd, *uint32)
 
 // View renders the program detail view.
 func (m progDetailModel) View() string {
+	if m.loading {
+		return titleStyle.Render("Program Details") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading program...")
+	}
+
 	if m.program == nil {
 		return titleStyle.Render("Program Details") + "\n\n" +
 			dimStyle.Render("No program selected")
@@ -219,6 +335,21 @@ func (m progDetailModel) View() string {
 	return title + "\n\n" + m.viewport.View()
 }
 
+// IsLoading returns true if the program is being loaded.
+func (m progDetailModel) IsLoading() bool {
+	return m.loading
+}
+
+// GetProgram returns the currently displayed program.
+func (m progDetailModel) GetProgram() *ProgramInfo {
+	return m.program
+}
+
+// GetOwners returns the processes holding the program, once they're known.
+func (m progDetailModel) GetOwners() []ProcessInfo {
+	return m.owners.procs
+}
+
 // HasMaps returns true if the program has associated maps.
 func (m progDetailModel) HasMaps() bool {
 	return len(m.mapIDs) > 0
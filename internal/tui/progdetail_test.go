@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -363,3 +364,99 @@ func TestProgDetailModel_SelectedMapHighlighted(t *testing.T) {
 		t.Error("expected second map to not be highlighted")
 	}
 }
+
+func TestProgDetailModel_RunStats(t *testing.T) {
+	m := newProgDetailModel(80, 40)
+	m.SetProgram(&ProgramInfo{ID: 1, Name: "prog", RunCount: 4, RunTime: 2 * time.Microsecond})
+
+	view := m.View()
+	for _, want := range []string{"Run Count:", "4", "Run Time:", "2000 ns", "Avg Run Time:", "500 ns"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	if strings.Contains(view, "Recent:") {
+		t.Error("recent rate should not be shown before a refresh")
+	}
+}
+
+func TestProgDetailModel_RefreshProgram(t *testing.T) {
+	m := newProgDetailModel(80, 40)
+	m.SetProgram(&ProgramInfo{ID: 1, Name: "prog", MapIDs: []uint32{10, 20}, RunCount: 4, RunTime: 2 * time.Microsecond})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	at := m.sample.at.Add(time.Second)
+	m.RefreshProgram(&ProgramInfo{ID: 1, Name: "prog", MapIDs: []uint32{10, 20}, RunCount: 54, RunTime: 12 * time.Microsecond}, at)
+
+	if !strings.Contains(m.View(), "50 runs/s, 200 ns/run") {
+		t.Errorf("view should show the recent rate, got:\n%s", m.View())
+	}
+	if id := m.SelectedMapID(); id == nil || *id != 20 {
+		t.Errorf("refresh should keep the selected map, got %v", id)
+	}
+
+	// Refreshes for another program are ignored
+	m.RefreshProgram(&ProgramInfo{ID: 2, Name: "other"}, at.Add(time.Second))
+	if m.GetProgram().ID != 1 {
+		t.Error("refresh for another program should be ignored")
+	}
+}
+
+func TestProgDetailModel_StatsPrompt(t *testing.T) {
+	m := newProgDetailModel(80, 40)
+	m.SetProgram(&ProgramInfo{ID: 1, Name: "prog"})
+	m.SetStatsOff(true)
+
+	if !strings.Contains(m.View(), "Press S to enable") {
+		t.Error("view should prompt to enable stats")
+	}
+}
+
+func TestProgDetailModel_Loading(t *testing.T) {
+	m := newProgDetailModel(80, 24)
+	m.SetProgram(&ProgramInfo{ID: 1, Name: "old"})
+
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("expected spinner command when loading starts")
+	}
+	if m.GetProgram() != nil {
+		t.Error("loading should clear the previous program")
+	}
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading program...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetProgram(&ProgramInfo{ID: 2, Name: "new"})
+	if m.IsLoading() {
+		t.Error("SetProgram should clear the loading state")
+	}
+}
+
+func TestProgDetailModel_Processes(t *testing.T) {
+	m := newProgDetailModel(80, 40)
+	m.SetProgram(&ProgramInfo{ID: 42, Name: "xdp_lb"})
+
+	if strings.Contains(m.viewport.View(), "Processes") {
+		t.Error("processes section should be hidden until they're known")
+	}
+
+	m.SetProcesses([]ProcessInfo{
+		{PID: 1234, Comm: "cilium-agent", Cmdline: "cilium-agent --debug", ProgIDs: []uint32{42}},
+		{PID: 55, Comm: "bpftool", ProgIDs: []uint32{7}},
+	}, nil)
+	if owners := m.GetOwners(); len(owners) != 1 || owners[0].PID != 1234 {
+		t.Fatalf("expected cilium-agent as the only owner, got %+v", owners)
+	}
+	view := m.viewport.View()
+	for _, want := range []string{"Processes", "1234 (cilium-agent)", "cilium-agent --debug"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	// A new program forgets the previous one's processes
+	m.SetProgram(&ProgramInfo{ID: 7, Name: "other"})
+	if m.GetOwners() != nil || strings.Contains(m.viewport.View(), "Processes") {
+		t.Error("processes should be reset with the program")
+	}
+}
@@ -0,0 +1,295 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// progDisasmModel manages the program disassembly view state.
+type progDisasmModel struct {
+	progID    uint32
+	progName  string
+	insns     []Instruction
+	cursor    int   // Index of the selected instruction
+	insnLines []int // Viewport line of each rendered instruction
+	viewport  viewport.Model
+	width     int
+	height    int
+	ready     bool
+	loading   bool
+	spinner   spinner.Model
+	err       error
+}
+
+// newProgDisasmModel creates a new program disassembly model.
+func newProgDisasmModel(width, height int) progDisasmModel {
+	return progDisasmModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+	}
+}
+
+// StartLoading prepares the view for the disassembly of the given program,
+// clearing any previous instructions or error.
+func (m *progDisasmModel) StartLoading(progID uint32, progName string) tea.Cmd {
+	m.progID = progID
+	m.progName = progName
+	m.insns = nil
+	m.cursor = 0
+	m.err = nil
+	return m.SetLoading(true)
+}
+
+// SetInstructions sets the instructions to display.
+func (m *progDisasmModel) SetInstructions(insns []Instruction) {
+	m.insns = insns
+	m.cursor = 0
+	m.loading = false
+	m.err = nil
+	m.updateViewport()
+	m.viewport.GotoTop()
+}
+
+// SetError sets an error state for the disassembly view.
+func (m *progDisasmModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.updateViewport()
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *progDisasmModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	m.updateViewport()
+	if loading {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the viewport dimensions.
+func (m *progDisasmModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if m.ready {
+		m.viewport.Width = width
+		m.viewport.Height = height - 4 // Leave room for title and help bar
+	}
+	m.updateViewport()
+	m.ensureCursorVisible()
+}
+
+// updateViewport refreshes the viewport content.
+func (m *progDisasmModel) updateViewport() {
+	content := m.renderContent()
+
+	if !m.ready {
+		m.viewport = viewport.New(m.width, m.height-4)
+		m.viewport.SetContent(content)
+		m.ready = true
+	} else {
+		m.viewport.SetContent(content)
+	}
+}
+
+// renderContent generates the disassembly listing.
+func (m *progDisasmModel) renderContent() string {
+	m.insnLines = m.insnLines[:0]
+
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	if m.loading {
+		return m.spinner.View() + dimStyle.Render(" Loading instructions...")
+	}
+
+	if len(m.insns) == 0 {
+		return dimStyle.Render("No instructions available")
+	}
+
+	var b strings.Builder
+	gutter := strings.Repeat(" ", lipgloss.Width(selectedMarker))
+	line := 0
+
+	for i, ins := range m.insns {
+		if ins.Func != "" {
+			if i > 0 {
+				b.WriteString("\n")
+				line++
+			}
+			b.WriteString(titleStyle.Render(ins.Func + ":"))
+			b.WriteString("\n")
+			line++
+		}
+		if ins.Source != "" {
+			b.WriteString(gutter)
+			b.WriteString(dimStyle.Render("; " + ins.Source))
+			b.WriteString("\n")
+			line++
+		}
+
+		m.insnLines = append(m.insnLines, line)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(selectedMarker))
+		} else {
+			b.WriteString(gutter)
+		}
+		text := fmt.Sprintf("%4d: %s", ins.Offset, formatInstruction(ins))
+		switch {
+		case i == m.cursor:
+			b.WriteString(selectedStyle.Render(text))
+		case ins.MapID != 0:
+			b.WriteString(linkStyle.Render(text))
+		default:
+			b.WriteString(valueStyle.Render(text))
+		}
+		b.WriteString("\n")
+		line++
+	}
+
+	return b.String()
+}
+
+// ensureCursorVisible scrolls the viewport so the selected instruction is shown.
+func (m *progDisasmModel) ensureCursorVisible() {
+	if !m.ready || m.cursor >= len(m.insnLines) {
+		return
+	}
+	line := m.insnLines[m.cursor]
+	switch {
+	case line < m.viewport.YOffset:
+		m.viewport.SetYOffset(line)
+	case line >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+// moveCursor selects the instruction at index i, staying within bounds.
+func (m *progDisasmModel) moveCursor(i int) {
+	if len(m.insns) == 0 {
+		return
+	}
+	m.cursor = min(max(i, 0), len(m.insns)-1)
+	m.updateViewport()
+	m.ensureCursorVisible()
+}
+
+// nextMapRef returns the index of the next instruction after (dir > 0) or
+// before (dir < 0) the cursor that references a map, or -1 if there is none.
+func (m progDisasmModel) nextMapRef(dir int) int {
+	for i := m.cursor + dir; i >= 0 && i < len(m.insns); i += dir {
+		if m.insns[i].MapID != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Init implements tea.Model for progDisasmModel.
+func (m progDisasmModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the disassembly view.
+// Returns the updated model, an optional command, and the ID of the map
+// referenced by the selected instruction if Enter was pressed on one.
+func (m progDisasmModel) Update(msg tea.Msg) (progDisasmModel, tea.Cmd, *uint32) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		m.updateViewport()
+		return m, cmd, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "k":
+			m.moveCursor(m.cursor - 1)
+			return m, nil, nil
+
+		case "down", "j":
+			m.moveCursor(m.cursor + 1)
+			return m, nil, nil
+
+		case "n":
+			if i := m.nextMapRef(1); i >= 0 {
+				m.moveCursor(i)
+			}
+			return m, nil, nil
+
+		case "N":
+			if i := m.nextMapRef(-1); i >= 0 {
+				m.moveCursor(i)
+			}
+			return m, nil, nil
+
+		case "enter":
+			if ins := m.SelectedInstruction(); ins != nil && ins.MapID != 0 {
+				mapID := ins.MapID
+				return m, nil, &mapID
+			}
+			return m, nil, nil
+		}
+	}
+
+	// Handle viewport scrolling for other keys
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd, nil
+}
+
+// View renders the disassembly view.
+func (m progDisasmModel) View() string {
+	var title string
+	if m.progName != "" {
+		title = titleStyle.Render(fmt.Sprintf("Xlated: %s (ID: %d)", m.progName, m.progID))
+	} else {
+		title = titleStyle.Render(fmt.Sprintf("Xlated: ID %d", m.progID))
+	}
+
+	if !m.ready {
+		return title + "\n\nLoading..."
+	}
+
+	return title + "\n\n" + m.viewport.View()
+}
+
+// SelectedInstruction returns the selected instruction, or nil if there are none.
+func (m progDisasmModel) SelectedInstruction() *Instruction {
+	if m.cursor < 0 || m.cursor >= len(m.insns) {
+		return nil
+	}
+	return &m.insns[m.cursor]
+}
+
+// GetProgID returns the ID of the program being disassembled.
+func (m progDisasmModel) GetProgID() uint32 {
+	return m.progID
+}
+
+// GetInstructionCount returns the number of instructions.
+func (m progDisasmModel) GetInstructionCount() int {
+	return len(m.insns)
+}
+
+// HasError returns true if there's an error state.
+func (m progDisasmModel) HasError() bool {
+	return m.err != nil
+}
+
+// IsLoading returns true if the instructions are loading.
+func (m progDisasmModel) IsLoading() bool {
+	return m.loading
+}
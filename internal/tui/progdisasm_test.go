@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testInstructions is a small program with two map references.
+var testInstructions = []Instruction{
+	{Offset: 0, OpCode: 0x18, Dst: 1, Src: bpfPseudoMapFD, Imm: 3, MapID: 3, Func: "prog", Source: "int key = 0;"},
+	{Offset: 2, OpCode: 0xb7, Dst: 0, Imm: 0},
+	{Offset: 3, OpCode: 0x18, Dst: 2, Src: bpfPseudoMapFD, Imm: 4, MapID: 4},
+	{Offset: 5, OpCode: 0x95},
+}
+
+func newLoadedDisasm() progDisasmModel {
+	m := newProgDisasmModel(80, 24)
+	m.StartLoading(1, "prog")
+	m.SetInstructions(testInstructions)
+	return m
+}
+
+func TestProgDisasmModel_Render(t *testing.T) {
+	m := newLoadedDisasm()
+	view := m.View()
+
+	for _, want := range []string{"Xlated: prog (ID: 1)", "prog:", "; int key = 0;", "0: (18) r1 = map[id:3]", "5: (95) exit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestProgDisasmModel_Loading(t *testing.T) {
+	m := newProgDisasmModel(80, 24)
+	m.StartLoading(1, "prog")
+
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading instructions...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetError(errors.New("insufficient permissions"))
+	if m.IsLoading() || !strings.Contains(m.View(), "insufficient permissions") {
+		t.Error("view should show the error")
+	}
+}
+
+func TestProgDisasmModel_CursorAndMapRefs(t *testing.T) {
+	m := newLoadedDisasm()
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.SelectedInstruction().Offset != 2 {
+		t.Errorf("down should select offset 2, got %d", m.SelectedInstruction().Offset)
+	}
+
+	// Enter on an instruction without a map does nothing
+	var mapID *uint32
+	m, _, mapID = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if mapID != nil {
+		t.Error("enter should not follow a non-map instruction")
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.SelectedInstruction().MapID != 4 {
+		t.Errorf("n should jump to the next map reference, got %+v", m.SelectedInstruction())
+	}
+
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if m.SelectedInstruction().MapID != 3 {
+		t.Errorf("N should jump to the previous map reference, got %+v", m.SelectedInstruction())
+	}
+
+	m, _, mapID = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if mapID == nil || *mapID != 3 {
+		t.Errorf("enter should follow map 3, got %v", mapID)
+	}
+}
@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// progJITModel manages the JIT-compiled native code view state.
+type progJITModel struct {
+	progID    uint32
+	progName  string
+	arch      string
+	lines     []jitLine
+	funcLines []int // Viewport line of each function header
+	viewport  viewport.Model
+	width     int
+	height    int
+	ready     bool
+	loading   bool
+	spinner   spinner.Model
+	err       error
+}
+
+// newProgJITModel creates a new JIT code model.
+func newProgJITModel(width, height int) progJITModel {
+	return progJITModel{
+		width:   width,
+		height:  height,
+		spinner: newSpinner(),
+	}
+}
+
+// StartLoading prepares the view for the native code of the given program,
+// clearing any previous listing or error.
+func (m *progJITModel) StartLoading(progID uint32, progName string) tea.Cmd {
+	m.progID = progID
+	m.progName = progName
+	m.arch = ""
+	m.lines = nil
+	m.err = nil
+	return m.SetLoading(true)
+}
+
+// SetImage disassembles and displays a JIT image.
+func (m *progJITModel) SetImage(img *JITImage) {
+	m.loading = false
+	if img == nil {
+		m.lines = nil
+		m.updateViewport()
+		return
+	}
+
+	lines, err := disassembleJIT(img)
+	if err != nil {
+		m.SetError(err)
+		return
+	}
+	m.arch = img.Arch
+	m.lines = lines
+	m.err = nil
+	m.updateViewport()
+	m.viewport.GotoTop()
+}
+
+// SetError sets an error state for the JIT view.
+func (m *progJITModel) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.updateViewport()
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *progJITModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	m.updateViewport()
+	if loading {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetSize updates the viewport dimensions.
+func (m *progJITModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if m.ready {
+		m.viewport.Width = width
+		m.viewport.Height = height - 4 // Leave room for title and help bar
+	}
+	m.updateViewport()
+}
+
+// updateViewport refreshes the viewport content.
+func (m *progJITModel) updateViewport() {
+	content := m.renderContent()
+
+	if !m.ready {
+		m.viewport = viewport.New(m.width, m.height-4)
+		m.viewport.SetContent(content)
+		m.ready = true
+	} else {
+		m.viewport.SetContent(content)
+	}
+}
+
+// renderContent generates the native code listing.
+func (m *progJITModel) renderContent() string {
+	m.funcLines = m.funcLines[:0]
+
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	}
+
+	if m.loading {
+		return m.spinner.View() + dimStyle.Render(" Loading JIT image...")
+	}
+
+	if len(m.lines) == 0 {
+		return dimStyle.Render("No JIT image available")
+	}
+
+	var b strings.Builder
+	line := 0
+	for i, l := range m.lines {
+		if l.funcName != "" {
+			if i > 0 {
+				b.WriteString("\n")
+				line++
+			}
+			m.funcLines = append(m.funcLines, line)
+			b.WriteString(titleStyle.Render(l.funcName + ":"))
+		} else {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("%6x:  %s", l.offset, l.text)))
+		}
+		b.WriteString("\n")
+		line++
+	}
+
+	return b.String()
+}
+
+// jumpToFunc scrolls to the next (dir > 0) or previous (dir < 0) function
+// header relative to the top of the viewport.
+func (m *progJITModel) jumpToFunc(dir int) {
+	top := m.viewport.YOffset
+	if dir > 0 {
+		for _, line := range m.funcLines {
+			if line > top {
+				m.viewport.SetYOffset(line)
+				return
+			}
+		}
+		return
+	}
+	for i := len(m.funcLines) - 1; i >= 0; i-- {
+		if m.funcLines[i] < top {
+			m.viewport.SetYOffset(m.funcLines[i])
+			return
+		}
+	}
+}
+
+// Init implements tea.Model for progJITModel.
+func (m progJITModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the JIT view.
+func (m progJITModel) Update(msg tea.Msg) (progJITModel, tea.Cmd) {
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		m.updateViewport()
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "n":
+			m.jumpToFunc(1)
+			return m, nil
+		case "N":
+			m.jumpToFunc(-1)
+			return m, nil
+		}
+	}
+
+	// Handle viewport scrolling for other keys
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View renders the JIT view.
+func (m progJITModel) View() string {
+	var title string
+	if m.progName != "" {
+		title = fmt.Sprintf("JIT: %s (ID: %d)", m.progName, m.progID)
+	} else {
+		title = fmt.Sprintf("JIT: ID %d", m.progID)
+	}
+	if m.arch != "" {
+		title += " [" + m.arch + "]"
+	}
+
+	if !m.ready {
+		return titleStyle.Render(title) + "\n\nLoading..."
+	}
+
+	return titleStyle.Render(title) + "\n\n" + m.viewport.View()
+}
+
+// GetProgID returns the ID of the program being shown.
+func (m progJITModel) GetProgID() uint32 {
+	return m.progID
+}
+
+// GetFuncCount returns the number of functions in the listing.
+func (m progJITModel) GetFuncCount() int {
+	return len(m.funcLines)
+}
+
+// HasError returns true if there's an error state.
+func (m progJITModel) HasError() bool {
+	return m.err != nil
+}
+
+// IsLoading returns true if the JIT image is loading.
+func (m progJITModel) IsLoading() bool {
+	return m.loading
+}
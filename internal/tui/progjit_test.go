@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testJITImage is a two-function x86-64 image.
+var testJITImage = &JITImage{
+	Arch: "amd64",
+	Code: []byte{0x55, 0x48, 0x89, 0xe5, 0xc9, 0xc3, 0xc3},
+	Funcs: []JITFunc{
+		{Name: "prog", Len: 6},
+		{Name: "subprog", Len: 1},
+	},
+}
+
+func TestProgJITModel_Render(t *testing.T) {
+	m := newProgJITModel(80, 24)
+	m.StartLoading(1, "prog")
+	m.SetImage(testJITImage)
+
+	if m.GetFuncCount() != 2 {
+		t.Errorf("expected 2 functions, got %d", m.GetFuncCount())
+	}
+	view := m.View()
+	for _, want := range []string{"JIT: prog (ID: 1) [amd64]", "prog:", "subprog:", "0:  push %rbp", "1:  mov %rsp,%rbp", "5:  retq"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestProgJITModel_LoadingAndErrors(t *testing.T) {
+	m := newProgJITModel(80, 24)
+	m.StartLoading(1, "prog")
+	if !m.IsLoading() || !strings.Contains(m.View(), "Loading JIT image...") {
+		t.Error("view should show the loading state")
+	}
+
+	m.SetError(errors.New("JIT disabled"))
+	if m.IsLoading() || !strings.Contains(m.View(), "JIT disabled") {
+		t.Error("view should show the error")
+	}
+
+	// Images for other architectures can't be decoded
+	m.StartLoading(1, "prog")
+	m.SetImage(&JITImage{Arch: "s390x", Code: []byte{0x07}})
+	if !m.HasError() || !strings.Contains(m.View(), "not supported on s390x") {
+		t.Errorf("view should show the unsupported arch, got:\n%s", m.View())
+	}
+}
+
+func TestProgJITModel_JumpToFunc(t *testing.T) {
+	m := newProgJITModel(80, 6) // Two visible lines
+	m.StartLoading(1, "prog")
+	m.SetImage(testJITImage)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.viewport.YOffset != m.funcLines[1] {
+		t.Errorf("n should scroll to the second function, got offset %d", m.viewport.YOffset)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if m.viewport.YOffset != 0 {
+		t.Errorf("N should scroll back to the first function, got offset %d", m.viewport.YOffset)
+	}
+}
@@ -2,42 +2,124 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // progItem represents a BPF program in the list.
 type progItem struct {
-	info ProgramInfo
+	info    ProgramInfo
+	status  itemStatus // Change since the previous refresh or the baseline
+	changes []string   // Fields that differ from the baseline
+	rate    progRate   // Activity since the previous refresh
+	// Processes holding the program, shown while grouping by process
+	owners    []ProcessInfo
+	byProcess bool
 }
 
 // FilterValue implements list.Item interface for fuzzy filtering.
-func (i progItem) FilterValue() string { return i.info.Name }
+// While grouping by process, programs also match their processes' names.
+func (i progItem) FilterValue() string {
+	if i.byProcess {
+		return i.info.Name + " " + formatOwners(i.owners)
+	}
+	return i.info.Name
+}
 
-// Title returns the program title for display (ID and Name).
+// Title returns the program title for display (ID and Name),
+// prefixed with a marker if it appeared, disappeared or changed.
 func (i progItem) Title() string {
-	return fmt.Sprintf("[%d] %s", i.info.ID, i.info.Name)
+	return i.status.marker() + fmt.Sprintf("[%d] %s", i.info.ID, i.info.Name)
 }
 
-// Description returns the program description for display (Type and Tag).
+// Description returns the program description for display (Type and Tag),
+// followed by run statistics once the program has run.
 func (i progItem) Description() string {
-	return fmt.Sprintf("Type: %s | Tag: %s", i.info.Type, i.info.Tag)
+	desc := fmt.Sprintf("Type: %s | Tag: %s", i.info.Type, i.info.Tag)
+	if i.info.RunCount > 0 {
+		desc += " | " + formatStats(i.info, i.rate)
+	}
+	if len(i.changes) > 0 {
+		desc += " | Changed: " + strings.Join(i.changes, ", ")
+	}
+	if i.byProcess {
+		desc += " | Held by: " + formatOwners(i.owners)
+	}
+	return desc
+}
+
+// progColumns are the columns of the programs table.
+var progColumns = []tableColumn{
+	{name: "id", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(progItem).info.ID) }},
+	{name: "name", flex: true, cell: func(i list.Item) string { return i.(progItem).info.Name }},
+	{name: "process", auto: true, cell: func(i list.Item) string { return i.(progItem).ownersCell() }},
+	{name: "type", cell: func(i list.Item) string { return i.(progItem).info.Type }},
+	{name: "tag", cell: func(i list.Item) string { return i.(progItem).info.Tag }},
+	{name: "memlock", right: true, cell: func(i list.Item) string { return formatSize(i.(progItem).info.MemLock) }},
+	{name: "loaded", cell: func(i list.Item) string { return i.(progItem).info.LoadedAt }},
+	{name: "uid", right: true, cell: func(i list.Item) string { return fmt.Sprint(i.(progItem).info.UID) }},
+	{name: "xlated", right: true, cell: func(i list.Item) string { return formatSize(i.(progItem).info.BytesXlated) }},
+	{name: "jited", right: true, cell: func(i list.Item) string { return formatSize(i.(progItem).info.BytesJIT) }},
+	{name: "maps", right: true, cell: func(i list.Item) string { return fmt.Sprint(len(i.(progItem).info.MapIDs)) }},
+	{name: "pinned", cell: func(i list.Item) string { return formatPinned(i.(progItem).info.Pinned) }},
+	{name: "runs", cell: func(i list.Item) string { return i.(progItem).statsCell() }},
+	{name: "changed", auto: true, cell: func(i list.Item) string { return strings.Join(i.(progItem).changes, ", ") }},
+}
+
+// defaultProgColumns are the columns of the programs table until the user
+// chooses others.
+var defaultProgColumns = []string{"id", "name", "type", "tag", "memlock", "loaded", "uid", "runs"}
+
+// changeStatus implements tableItem.
+func (i progItem) changeStatus() itemStatus {
+	return i.status
+}
+
+// ownersCell returns the processes holding the program while grouping by
+// process, and "" otherwise.
+func (i progItem) ownersCell() string {
+	if !i.byProcess {
+		return ""
+	}
+	return formatOwners(i.owners)
+}
+
+// statsCell returns the program's run statistics once it has run.
+func (i progItem) statsCell() string {
+	if i.info.RunCount == 0 {
+		return ""
+	}
+	return formatStats(i.info, i.rate)
 }
 
 // progListModel manages the programs list state.
 type progListModel struct {
 	list     list.Model
 	programs []ProgramInfo
-	err      error
+	items    []list.Item           // Items in load order, before grouping
+	samples  map[uint32]progSample // Run counters from the previous poll
+	// Grouping by owning process
+	byProcess bool
+	sort      listSort // Column and direction to sort by
+	table     listTable
+	owners    map[uint32][]ProcessInfo
+	baseline  []ProgramInfo // Programs to compare against, or nil
+	statsOff  bool          // Kernel isn't collecting run statistics
+	statsErr  error         // Error from the last attempt to enable them
+	err       error
+	loading   bool
+	spinner   spinner.Model
 }
 
 // newProgListModel creates a new programs list model.
 func newProgListModel(width, height int) progListModel {
 	// Create delegate for custom item rendering
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = selectedStyle
-	delegate.Styles.SelectedDesc = selectedStyle.Foreground(dimStyle.GetForeground())
+	delegate := newItemDelegate()
 
 	// Calculate list dimensions (leave room for title and help bar)
 	listHeight := height - 6
@@ -52,9 +134,14 @@ func newProgListModel(width, height int) progListModel {
 	l.SetShowHelp(false)
 	l.Styles.Title = titleStyle
 
+	table := newListTable(progColumns, defaultProgColumns...)
+	table.width, table.height = width, height
+
 	return progListModel{
 		list:     l,
 		programs: []ProgramInfo{},
+		table:    table,
+		spinner:  newSpinner(),
 	}
 }
 
@@ -66,18 +153,228 @@ func (m progListModel) Init() tea.Cmd {
 // SetPrograms updates the list with new program data.
 func (m *progListModel) SetPrograms(programs []ProgramInfo) {
 	m.programs = programs
+	m.samples = sampleAll(programs, time.Now())
+	m.loading = false
+	m.err = nil
 	items := make([]list.Item, len(programs))
 	for i, prog := range programs {
 		items[i] = progItem{info: prog}
 	}
+	m.items = m.compare(items)
+	m.list.Title = m.listTitle()
+	items = m.arrange(m.items)
+	m.list.Filter = queryFilter(items, progQueryFields)
 	m.list.SetItems(items)
+	m.table.apply(&m.list)
+}
+
+// RefreshPrograms replaces the list with data polled at the given time while
+// preserving the cursor and any active filter. Items that appeared since the
+// previous poll are marked as added; items that disappeared are kept until the
+// next poll and marked as removed. Run rates are computed against the previous poll.
+func (m *progListModel) RefreshPrograms(programs []ProgramInfo, at time.Time) tea.Cmd {
+	var selectedID *uint32
+	if item, ok := m.list.SelectedItem().(progItem); ok {
+		selectedID = &item.info.ID
+	}
+
+	prevIDs := make([]uint32, len(m.programs))
+	prev := make(map[uint32]ProgramInfo, len(m.programs))
+	for i, p := range m.programs {
+		prevIDs[i] = p.ID
+		prev[p.ID] = p
+	}
+	currIDs := make([]uint32, len(programs))
+	for i, p := range programs {
+		currIDs[i] = p.ID
+	}
+	added, removed := diffIDs(prevIDs, currIDs)
+
+	samples := sampleAll(programs, at)
+	m.programs = programs
+	m.loading = false
+	m.err = nil
+	newItems := make([]list.Item, 0, len(programs)+len(removed))
+	for _, p := range programs {
+		item := progItem{info: p}
+		if added[p.ID] {
+			item.status = itemAdded
+		}
+		if prevSample, ok := m.samples[p.ID]; ok {
+			item.rate = newProgRate(prevSample, samples[p.ID])
+		}
+		newItems = append(newItems, item)
+	}
+	m.samples = samples
+	for _, id := range removed {
+		newItems = append(newItems, progItem{info: prev[id], status: itemRemoved})
+	}
+
+	if m.baseline != nil {
+		// Compare against the baseline instead of the previous poll
+		newItems = m.compare(newItems[:len(programs)])
+		m.list.Title = m.listTitle()
+	} else {
+		m.list.Title = changeTitle(m.title(), len(added), len(removed))
+	}
+	m.items = newItems
+	newItems = m.arrange(newItems)
+	m.list.Filter = queryFilter(newItems, progQueryFields)
+	cmd := m.list.SetItems(newItems)
+	m.table.apply(&m.list)
+
+	// Keep the cursor on the same prog when the list isn't filtered.
+	// While filtered, the list keeps its cursor index across re-filtering.
+	if selectedID != nil && m.list.FilterState() == list.Unfiltered {
+		for i, item := range newItems {
+			if item.(progItem).info.ID == *selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return cmd
+}
+
+// SetBaseline sets programs to compare against, e.g. from a snapshot.
+// Items are then marked as added, removed or changed relative to the
+// baseline rather than the previous poll. Pass nil to stop comparing.
+func (m *progListModel) SetBaseline(programs []ProgramInfo) {
+	m.baseline = programs
+}
+
+// compare marks items for the current programs against the baseline and
+// appends the baseline programs that are gone. Without a baseline, items
+// are returned unchanged.
+func (m progListModel) compare(items []list.Item) []list.Item {
+	if m.baseline == nil {
+		return items
+	}
+	d := diffPrograms(m.baseline, m.programs)
+	for i, item := range items {
+		pi := item.(progItem)
+		pi.status = d.status[pi.info.ID]
+		pi.changes = d.changes[pi.info.ID]
+		items[i] = pi
+	}
+	for _, p := range d.removed {
+		items = append(items, progItem{info: p, status: itemRemoved})
+	}
+	return items
+}
+
+// listTitle returns the list title, with counts of the differences from the
+// baseline if there is one.
+func (m progListModel) listTitle() string {
+	if m.baseline == nil {
+		return m.title()
+	}
+	d := diffPrograms(m.baseline, m.programs)
+	return diffTitle(m.title(), d.added, len(d.removed), d.changed)
+}
+
+// SetByProcess turns grouping by owning process on or off.
+func (m *progListModel) SetByProcess(on bool) tea.Cmd {
+	m.byProcess = on
+	return m.regroup()
+}
+
+// SetProcesses records which processes hold each program.
+func (m *progListModel) SetProcesses(procs []ProcessInfo) tea.Cmd {
+	m.owners = ownerIndex(procs, heldProgs)
+	return m.regroup()
+}
+
+// title returns the list title with the sort indicator, before change
+// counts.
+func (m progListModel) title() string {
+	if m.byProcess {
+		return "BPF Programs by Process" + m.sort.title()
+	}
+	return "BPF Programs" + m.sort.title()
+}
+
+// SetSort sets the column and direction to sort the list by.
+func (m *progListModel) SetSort(s listSort) tea.Cmd {
+	m.sort = s
+	return m.regroup()
+}
+
+// CycleSort sorts the list by the next column, ascending.
+func (m *progListModel) CycleSort() tea.Cmd {
+	return m.SetSort(m.sort.next(progSortKeys))
+}
+
+// ReverseSort flips the sort direction. In kernel order, it does nothing.
+func (m *progListModel) ReverseSort() tea.Cmd {
+	if m.sort.key == sortNone {
+		return nil
+	}
+	return m.SetSort(m.sort.reversed())
+}
+
+// arrange returns items annotated with their owning processes, sorted by
+// the sort column and, while grouping by process, by the processes first.
+func (m progListModel) arrange(items []list.Item) []list.Item {
+	items = slices.Clone(items)
+	for i, item := range items {
+		pi := item.(progItem)
+		pi.owners = m.owners[pi.info.ID]
+		pi.byProcess = m.byProcess
+		items[i] = pi
+	}
+	sortItems(items, m.sort, progSortField)
+	if m.byProcess {
+		sortByOwner(items, func(item list.Item) []ProcessInfo { return item.(progItem).owners })
+	}
+	return items
+}
+
+// regroup re-arranges the list after a change in grouping or order,
+// keeping the cursor on the same program when the list isn't filtered.
+func (m *progListModel) regroup() tea.Cmd {
+	var selectedID *uint32
+	if item, ok := m.list.SelectedItem().(progItem); ok {
+		selectedID = &item.info.ID
+	}
+
+	items := m.arrange(m.items)
+	m.list.Title = m.listTitle()
+	m.list.Filter = queryFilter(items, progQueryFields)
+	cmd := m.list.SetItems(items)
+	m.table.apply(&m.list)
+
+	if selectedID != nil && m.list.FilterState() == list.Unfiltered {
+		for i, item := range items {
+			if item.(progItem).info.ID == *selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+	return cmd
 }
 
 // Update handles messages for the programs list.
 // Returns the updated model, an optional command, and the selected program if Enter was pressed.
 func (m progListModel) Update(msg tea.Msg) (progListModel, tea.Cmd, *ProgramInfo) {
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep ticking only while a load is in flight
+		if !m.loading {
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd, nil
+
 	case tea.KeyMsg:
+		if m.table.choosing {
+			m.table = m.table.Update(msg)
+			m.table.apply(&m.list)
+			return m, nil, nil
+		}
+
 		// Don't handle enter if we're filtering
 		if m.list.FilterState() == list.Filtering {
 			break
@@ -86,7 +383,8 @@ func (m progListModel) Update(msg tea.Msg) (progListModel, tea.Cmd, *ProgramInfo
 		switch msg.String() {
 		case "enter":
 			// Get selected item and return its program info
-			if item, ok := m.list.SelectedItem().(progItem); ok {
+			// Removed items no longer exist, so there's nothing to open
+			if item, ok := m.list.SelectedItem().(progItem); ok && item.status != itemRemoved {
 				return m, nil, &item.info
 			}
 		}
@@ -99,7 +397,12 @@ func (m progListModel) Update(msg tea.Msg) (progListModel, tea.Cmd, *ProgramInfo
 
 // View renders the programs list.
 func (m progListModel) View() string {
-	if len(m.programs) == 0 && m.err == nil {
+	if m.loading {
+		return titleStyle.Render("BPF Programs") + "\n\n" +
+			m.spinner.View() + dimStyle.Render(" Loading BPF programs...")
+	}
+
+	if len(m.items) == 0 && m.err == nil {
 		return titleStyle.Render("BPF Programs") + "\n\n" +
 			dimStyle.Render("No BPF programs loaded")
 	}
@@ -109,21 +412,100 @@ func (m progListModel) View() string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
 	}
 
-	return m.list.View()
+	view := m.list.View()
+	if m.table.on {
+		view = m.table.render(m.list, "programs")
+	}
+	if errView := queryError(m.list, progQueryFields); errView != "" {
+		view += "\n" + errView
+	}
+	if m.statsOff {
+		return view + "\n" + renderStatsPrompt(m.statsErr)
+	}
+	return view
 }
 
 // SetSize updates the list dimensions.
 func (m *progListModel) SetSize(width, height int) {
-	listHeight := height - 6
-	if listHeight < 3 {
-		listHeight = 3
-	}
-	m.list.SetSize(width, listHeight)
+	m.table.width = width
+	m.table.height = height
+	m.table.apply(&m.list)
 }
 
 // SetError sets an error state for the list.
 func (m *progListModel) SetError(err error) {
 	m.err = err
+	m.loading = false
+}
+
+// SetLoading sets the loading state.
+// When loading starts, the returned command starts the spinner.
+func (m *progListModel) SetLoading(loading bool) tea.Cmd {
+	m.loading = loading
+	if loading {
+		m.err = nil
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// SetStatsOff records whether the kernel is collecting run statistics.
+// While it isn't, the list prompts the user to enable them.
+func (m *progListModel) SetStatsOff(off bool) {
+	m.statsOff = off
+	m.statsErr = nil
+}
+
+// SetStatsError shows why enabling run statistics failed.
+func (m *progListModel) SetStatsError(err error) {
+	m.statsErr = err
+}
+
+// IsByProcess returns true while the list is grouped by owning process.
+func (m progListModel) IsByProcess() bool {
+	return m.byProcess
+}
+
+// ToggleTable switches between the table layout and the two-line list.
+func (m *progListModel) ToggleTable() {
+	m.table.on = !m.table.on
+	m.table.choosing = false
+	m.table.apply(&m.list)
+}
+
+// ChooseColumns opens the chooser of the table's columns. It does nothing
+// outside the table layout.
+func (m *progListModel) ChooseColumns() {
+	m.table.choosing = m.table.on
+}
+
+// SetColumns sets the columns of the table layout, by name.
+func (m *progListModel) SetColumns(names []string) error {
+	if err := m.table.SetColumns(names); err != nil {
+		return err
+	}
+	m.table.apply(&m.list)
+	return nil
+}
+
+// IsTable returns true while the list is laid out as a table.
+func (m progListModel) IsTable() bool {
+	return m.table.on
+}
+
+// IsChoosingColumns returns true while the column chooser is open.
+func (m progListModel) IsChoosingColumns() bool {
+	return m.table.choosing
+}
+
+// GetSort returns the column and direction the list is sorted by.
+func (m progListModel) GetSort() listSort {
+	return m.sort
+}
+
+// IsLoading returns true if programs are being loaded.
+func (m progListModel) IsLoading() bool {
+	return m.loading
 }
 
 // SelectedItem returns the currently selected program, if any.
@@ -143,3 +525,12 @@ func (m progListModel) IsFiltering() bool {
 func (m *progListModel) ResetFilter() {
 	m.list.ResetFilter()
 }
+
+// sampleAll records the run counters of programs as of at.
+func sampleAll(programs []ProgramInfo, at time.Time) map[uint32]progSample {
+	samples := make(map[uint32]progSample, len(programs))
+	for _, p := range programs {
+		samples[p.ID] = newProgSample(p, at)
+	}
+	return samples
+}
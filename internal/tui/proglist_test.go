@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -346,12 +347,10 @@ func TestProgListIntegrationSelectProgram(t *testing.T) {
 
 	// Navigate to programs list
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Press Enter to select first program
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should now be at program detail
 	if m.state != ViewProgDetail {
@@ -571,8 +570,7 @@ func TestProgListIntegrationFuzzySearchDoesNotNavigateBack(t *testing.T) {
 
 	// Navigate to programs list
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	if m.state != ViewProgList {
 		t.Fatalf("expected ViewProgList, got %v", m.state)
@@ -580,7 +578,7 @@ func TestProgListIntegrationFuzzySearchDoesNotNavigateBack(t *testing.T) {
 
 	// Activate filtering
 	filterMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}}
-	result, _ = m.Update(filterMsg)
+	result, _ := m.Update(filterMsg)
 	m = result.(Model)
 
 	// Press Escape while filtering - should exit filter mode, not navigate back
@@ -671,3 +669,108 @@ func TestProgListResetFilter(t *testing.T) {
 		t.Error("expected not to be in filtering mode after ResetFilter")
 	}
 }
+
+func TestProgListRefreshComputesRates(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetPrograms([]ProgramInfo{
+		{ID: 1, Name: "busy", Type: "xdp", RunCount: 100, RunTime: 10 * time.Microsecond},
+		{ID: 2, Name: "never_ran", Type: "kprobe"},
+	})
+
+	// Before a second sample, lifetime totals are shown
+	if got := m.list.Items()[0].(progItem).Description(); !strings.Contains(got, "100 runs, 100 ns/run") {
+		t.Errorf("description should show lifetime stats, got %q", got)
+	}
+
+	at := m.samples[1].at.Add(2 * time.Second)
+	m.RefreshPrograms([]ProgramInfo{
+		{ID: 1, Name: "busy", Type: "xdp", RunCount: 300, RunTime: 30 * time.Microsecond},
+		{ID: 2, Name: "never_ran", Type: "kprobe"},
+	}, at)
+
+	if got := m.list.Items()[0].(progItem).Description(); !strings.Contains(got, "100 runs/s, 100 ns/run") {
+		t.Errorf("description should show the rate since the last poll, got %q", got)
+	}
+	if got := m.list.Items()[1].(progItem).Description(); got != "Type: kprobe | Tag: " {
+		t.Errorf("programs that never ran should show no stats, got %q", got)
+	}
+}
+
+func TestProgListEnterSkipsRemoved(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetPrograms([]ProgramInfo{{ID: 1, Name: "prog1", Type: "xdp"}, {ID: 2, Name: "prog2", Type: "kprobe"}})
+	m.RefreshPrograms([]ProgramInfo{{ID: 2, Name: "prog2", Type: "kprobe"}}, time.Now())
+
+	for i, item := range m.list.Items() {
+		m.list.Select(i)
+		_, _, selected := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		removed := item.(progItem).status == itemRemoved
+		if removed && selected != nil {
+			t.Errorf("Enter on removed program %d should not open it", selected.ID)
+		}
+		if !removed && selected == nil {
+			t.Error("Enter on a live program should open it")
+		}
+	}
+}
+
+func TestProgListStatsPrompt(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetPrograms([]ProgramInfo{{ID: 1, Name: "prog"}})
+
+	if strings.Contains(m.View(), statsPrompt) {
+		t.Error("prompt should be hidden by default")
+	}
+
+	m.SetStatsOff(true)
+	if !strings.Contains(m.View(), statsPrompt) {
+		t.Error("prompt should be shown while stats are off")
+	}
+}
+
+func TestProgListGroupByProcess(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetPrograms([]ProgramInfo{
+		{ID: 1, Name: "orphan"},
+		{ID: 2, Name: "tracer"},
+		{ID: 3, Name: "xdp_lb"},
+	})
+	m.list.Select(2) // xdp_lb
+
+	m.SetByProcess(true)
+	m.SetProcesses([]ProcessInfo{
+		{PID: 10, Comm: "zebra", ProgIDs: []uint32{2}},
+		{PID: 20, Comm: "cilium-agent", ProgIDs: []uint32{3}},
+	})
+
+	var names []string
+	for _, item := range m.list.Items() {
+		names = append(names, item.(progItem).info.Name)
+	}
+	if got, want := strings.Join(names, ","), "xdp_lb,tracer,orphan"; got != want {
+		t.Errorf("grouped order = %s, want %s", got, want)
+	}
+	if m.list.Title != "BPF Programs by Process" {
+		t.Errorf("title = %q", m.list.Title)
+	}
+	if got := m.SelectedItem(); got == nil || got.ID != 3 {
+		t.Errorf("cursor should stay on xdp_lb, got %+v", got)
+	}
+	if got := m.list.Items()[0].(progItem).Description(); !strings.Contains(got, "Held by: cilium-agent (20)") {
+		t.Errorf("description should show the owner, got %q", got)
+	}
+	if got := m.list.Items()[0].(progItem).FilterValue(); got != "xdp_lb cilium-agent (20)" {
+		t.Errorf("filter value should include the owner, got %q", got)
+	}
+
+	// Refreshes keep the grouping
+	m.RefreshPrograms([]ProgramInfo{{ID: 1, Name: "orphan"}, {ID: 2, Name: "tracer"}, {ID: 3, Name: "xdp_lb"}}, time.Now())
+	if got := m.list.Items()[0].(progItem).info.Name; got != "xdp_lb" {
+		t.Errorf("refresh should keep the grouping, first item = %s", got)
+	}
+
+	m.SetByProcess(false)
+	if got := m.list.Items()[0].(progItem); got.info.Name != "orphan" || strings.Contains(got.Description(), "Held by") {
+		t.Errorf("ungrouping should restore the load order, first item = %+v", got)
+	}
+}
@@ -0,0 +1,325 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// queryKind is the kind of value a query field holds, which decides the
+// operators it supports and how values are parsed.
+type queryKind int
+
+const (
+	queryText   queryKind = iota // Compared case-insensitively; ~ matches a regexp
+	queryNumber                  // Decimal or 0x-prefixed hex
+	querySize                    // Bytes, with an optional K, M or G suffix
+	queryBool                    // true/false, yes/no or 1/0
+)
+
+// queryField is a field list items can be filtered by.
+type queryField struct {
+	kind queryKind
+	text func(list.Item) string // Text fields
+	num  func(list.Item) uint64 // Other fields; booleans are 0 or 1
+	// Underscores are ignored, as type names are spelled both with and
+	// without them (bpftool's "lru_hash", cilium/ebpf's "lruhash")
+	loose bool
+}
+
+// fold normalizes a text value for comparison.
+func (f queryField) fold(s string) string {
+	s = strings.ToLower(s)
+	if f.loose {
+		s = strings.ReplaceAll(s, "_", "")
+	}
+	return s
+}
+
+// queryPred is a predicate of a query, e.g. memlock>1M.
+type queryPred struct {
+	field  queryField
+	op     string // ":", ">", ">=", "<", "<=" or "~"
+	negate bool   // Prefixed with -
+	text   string
+	num    uint64
+	re     *regexp.Regexp
+}
+
+// query is a parsed list filter: predicates that all have to hold, and
+// bare words that are fuzzy-matched like a plain search.
+type query struct {
+	preds []queryPred
+	words []string
+}
+
+// queryPredRE matches a predicate, capturing the negation, field name,
+// operator and value.
+var queryPredRE = regexp.MustCompile(`^(-?)([a-z]+)(:|>=|<=|>|<|~)(.*)$`)
+
+// parseQuery parses a filter like `type:xdp uid:0 memlock>1M name~conntrack
+// pinned:true` over fields. Words without an operator are kept for fuzzy
+// matching. Values with spaces can be quoted.
+func parseQuery(s string, fields map[string]queryField) (query, error) {
+	var q query
+	for _, tok := range splitQuery(s) {
+		sm := queryPredRE.FindStringSubmatch(tok)
+		if sm == nil {
+			q.words = append(q.words, tok)
+			continue
+		}
+		name, op, value := sm[2], sm[3], strings.Trim(sm[4], `"`)
+		field, ok := fields[name]
+		if !ok {
+			return query{}, fmt.Errorf("unknown field %q (fields: %s)", name,
+				strings.Join(slices.Sorted(maps.Keys(fields)), ", "))
+		}
+		if value == "" {
+			return query{}, fmt.Errorf("%s%s needs a value", name, op)
+		}
+
+		p := queryPred{field: field, op: op, negate: sm[1] == "-"}
+		var err error
+		switch field.kind {
+		case queryText:
+			if op == "~" {
+				p.re, err = regexp.Compile("(?i)" + value)
+			}
+			p.text = field.fold(value)
+		case queryNumber:
+			p.num, err = parseQueryNumber(value)
+		case querySize:
+			p.num, err = parseSize(value)
+		case queryBool:
+			if op != ":" {
+				return query{}, fmt.Errorf("%s only supports %s:true or %s:false", name, name, name)
+			}
+			p.num, err = parseQueryBool(value)
+		}
+		if field.kind != queryText && op == "~" {
+			return query{}, fmt.Errorf("%s isn't text and can't be matched with ~", name)
+		}
+		if err != nil {
+			return query{}, fmt.Errorf("%s%s%s: %w", name, op, value, err)
+		}
+		q.preds = append(q.preds, p)
+	}
+	return q, nil
+}
+
+// splitQuery splits a query on spaces outside double quotes.
+func splitQuery(s string) []string {
+	var toks []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case r == ' ' && !quoted:
+			if cur.Len() > 0 {
+				toks = append(toks, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		toks = append(toks, cur.String())
+	}
+	return toks
+}
+
+// parseQueryNumber parses a decimal or 0x-prefixed hex number. Leading
+// zeros don't make it octal, so id:010 is ID 10.
+func parseQueryNumber(s string) (uint64, error) {
+	digits, base := s, 10
+	if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		digits, base = hex, 16
+	}
+	n, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// parseSize parses a size in bytes, with an optional binary K, M or G
+// suffix, e.g. "4096", "64K", "1.5M" or "2GiB".
+func parseSize(s string) (uint64, error) {
+	u := strings.ToUpper(s)
+	i := strings.IndexAny(u, "KMG")
+	if i < 0 {
+		n, err := parseQueryNumber(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q", s)
+		}
+		return n, nil
+	}
+
+	var mult float64
+	switch u[i:] {
+	case "K", "KB", "KIB":
+		mult = 1 << 10
+	case "M", "MB", "MIB":
+		mult = 1 << 20
+	case "G", "GB", "GIB":
+		mult = 1 << 30
+	}
+	v, err := strconv.ParseFloat(u[:i], 64)
+	if mult == 0 || err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return uint64(v * mult), nil
+}
+
+// parseQueryBool parses a boolean value as 0 or 1.
+func parseQueryBool(s string) (uint64, error) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "1":
+		return 1, nil
+	case "false", "no", "0":
+		return 0, nil
+	}
+	return 0, fmt.Errorf("expected true or false")
+}
+
+// matches returns true if the predicate holds for item.
+func (p queryPred) matches(item list.Item) bool {
+	var c int
+	if p.field.kind == queryText {
+		v := p.field.text(item)
+		if p.re != nil {
+			return p.re.MatchString(v) != p.negate
+		}
+		c = strings.Compare(p.field.fold(v), p.text)
+	} else {
+		v := p.field.num(item)
+		switch {
+		case v < p.num:
+			c = -1
+		case v > p.num:
+			c = 1
+		}
+	}
+
+	var ok bool
+	switch p.op {
+	case ":":
+		ok = c == 0
+	case ">":
+		ok = c > 0
+	case ">=":
+		ok = c >= 0
+	case "<":
+		ok = c < 0
+	case "<=":
+		ok = c <= 0
+	}
+	return ok != p.negate
+}
+
+// matches returns true if all predicates hold for item.
+func (q query) matches(item list.Item) bool {
+	for _, p := range q.preds {
+		if !p.matches(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// queryFilter returns the list filter for queries over fields. items are
+// the list's items, in the order the filter gets their filter values.
+// Items matching the predicates are kept in list order, or ranked by fuzzy
+// matching if the query has bare words. Invalid queries match nothing.
+func queryFilter(items []list.Item, fields map[string]queryField) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		q, err := parseQuery(term, fields)
+		if err != nil {
+			return nil
+		}
+
+		var idx []int
+		for i := range targets {
+			if i < len(items) && q.matches(items[i]) {
+				idx = append(idx, i)
+			}
+		}
+		if len(q.words) == 0 {
+			ranks := make([]list.Rank, len(idx))
+			for k, i := range idx {
+				ranks[k] = list.Rank{Index: i}
+			}
+			return ranks
+		}
+
+		sub := make([]string, len(idx))
+		for k, i := range idx {
+			sub[k] = targets[i]
+		}
+		ranks := list.DefaultFilter(strings.Join(q.words, " "), sub)
+		for k := range ranks {
+			ranks[k].Index = idx[ranks[k].Index]
+		}
+		return ranks
+	}
+}
+
+// queryError renders the error in l's filter query, or "" if it's valid.
+func queryError(l list.Model, fields map[string]queryField) string {
+	if l.FilterState() == list.Unfiltered {
+		return ""
+	}
+	if _, err := parseQuery(l.FilterValue(), fields); err != nil {
+		return errorStyle.Render("Query: " + err.Error())
+	}
+	return ""
+}
+
+// boolNum returns 1 for true and 0 for false.
+func boolNum(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// progQueryFields are the fields the programs list can be filtered by.
+var progQueryFields = map[string]queryField{
+	"id":      {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(progItem).info.ID) }},
+	"name":    {kind: queryText, text: func(i list.Item) string { return i.(progItem).info.Name }},
+	"type":    {kind: queryText, loose: true, text: func(i list.Item) string { return i.(progItem).info.Type }},
+	"tag":     {kind: queryText, text: func(i list.Item) string { return i.(progItem).info.Tag }},
+	"uid":     {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(progItem).info.UID) }},
+	"memlock": {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(progItem).info.MemLock) }},
+	"loaded":  {kind: queryText, text: func(i list.Item) string { return i.(progItem).info.LoadedAt }},
+	"xlated":  {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(progItem).info.BytesXlated) }},
+	"jited":   {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(progItem).info.BytesJIT) }},
+	"maps":    {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(len(i.(progItem).info.MapIDs)) }},
+	"runs":    {kind: queryNumber, num: func(i list.Item) uint64 { return i.(progItem).info.RunCount }},
+	"gpl":     {kind: queryBool, num: func(i list.Item) uint64 { return boolNum(i.(progItem).info.GPL) }},
+	"pinned":  {kind: queryBool, num: func(i list.Item) uint64 { return boolNum(i.(progItem).info.Pinned) }},
+}
+
+// mapQueryFields are the fields the maps list can be filtered by.
+var mapQueryFields = map[string]queryField{
+	"id":      {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.ID) }},
+	"name":    {kind: queryText, text: func(i list.Item) string { return i.(mapItem).info.Name }},
+	"type":    {kind: queryText, loose: true, text: func(i list.Item) string { return i.(mapItem).info.Type }},
+	"uid":     {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.UID) }},
+	"memlock": {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.MemLock) }},
+	"loaded":  {kind: queryText, text: func(i list.Item) string { return i.(mapItem).info.LoadedAt }},
+	"key":     {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.KeySize) }},
+	"value":   {kind: querySize, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.ValueSize) }},
+	"max":     {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.MaxEntries) }},
+	"flags":   {kind: queryNumber, num: func(i list.Item) uint64 { return uint64(i.(mapItem).info.Flags) }},
+	"pinned":  {kind: queryBool, num: func(i list.Item) uint64 { return boolNum(i.(mapItem).info.Pinned) }},
+}
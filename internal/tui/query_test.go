@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]uint64{
+		"4096":  4096,
+		"0x100": 256,
+		"010":   10,
+		"64K":   64 << 10,
+		"1.5M":  3 << 19,
+		"2GiB":  2 << 30,
+		"1mb":   1 << 20,
+	}
+	for s, want := range tests {
+		if got, err := parseSize(s); err != nil || got != want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"1T", "M", "-1K", "abc"} {
+		if _, err := parseSize(s); err == nil {
+			t.Errorf("parseSize(%q) should fail", s)
+		}
+	}
+}
+
+func TestParseQueryNumber(t *testing.T) {
+	tests := map[string]uint64{"42": 42, "010": 10, "0x10": 16, "0XfF": 255}
+	for s, want := range tests {
+		if got, err := parseQueryNumber(s); err != nil || got != want {
+			t.Errorf("parseQueryNumber(%q) = %d, %v; want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "0x", "0b11", "0o17", "1_000", "-1", "12a"} {
+		if _, err := parseQueryNumber(s); err == nil {
+			t.Errorf("parseQueryNumber(%q) should fail", s)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := parseQuery(`type:xdp uid:0 memlock>1M name~conntrack pinned:true ct "some word"`, progQueryFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.preds) != 5 || strings.Join(q.words, "|") != `ct|"some word"` {
+		t.Errorf("parseQuery() = %d predicates, words %q", len(q.preds), q.words)
+	}
+	if q.preds[2].op != ">" || q.preds[2].num != 1<<20 {
+		t.Errorf("memlock predicate = %+v", q.preds[2])
+	}
+
+	for query, want := range map[string]string{
+		"color:red":    "unknown field",
+		"memlock>":     "needs a value",
+		"memlock>lots": "invalid size",
+		"uid~0":        "can't be matched with ~",
+		"pinned>true":  "only supports",
+		"pinned:maybe": "expected true or false",
+		"name~conn(":   "name~conn(",
+		"id:0x1g":      "id:0x1g",
+	} {
+		if _, err := parseQuery(query, progQueryFields); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseQuery(%q) error = %v, want %q", query, err, want)
+		}
+	}
+}
+
+func TestQueryMatches(t *testing.T) {
+	maps := []MapInfo{
+		{ID: 1, Name: "conntrack_v4", Type: "lru_hash", MemLock: 40 << 20, Pinned: true},
+		{ID: 2, Name: "conntrack_v6", Type: "lruhash", MemLock: 512 << 10, UID: 1000},
+		{ID: 3, Name: "events", Type: "ringbuf", MemLock: 2 << 20},
+	}
+	tests := map[string][]uint32{
+		"type:lru_hash":           {1, 2},
+		"type:LRUHASH memlock>1M": {1},
+		"memlock<=512K":           {2},
+		"name~^conn.*6$":          {2},
+		"-name~conntrack":         {3},
+		"pinned:true":             {1},
+		"pinned:no uid:1000":      {2},
+		"-type:ringbuf id>=2":     {2},
+		"loaded<2000":             {1, 2, 3}, // Empty load times sort first
+	}
+	for query, want := range tests {
+		q, err := parseQuery(query, mapQueryFields)
+		if err != nil {
+			t.Fatalf("parseQuery(%q): %v", query, err)
+		}
+		var got []uint32
+		for _, mi := range maps {
+			if q.matches(mapItem{info: mi}) {
+				got = append(got, mi.ID)
+			}
+		}
+		if len(got) != len(want) || (len(got) > 0 && got[0] != want[0]) {
+			t.Errorf("%q matched %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestMapListQueryFilter(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{
+		{ID: 1, Name: "conntrack", Type: "lru_hash", MemLock: 40 << 20},
+		{ID: 2, Name: "counters", Type: "percpu_array", MemLock: 8192},
+		{ID: 3, Name: "cgroup_counters", Type: "hash", MemLock: 2 << 20},
+	})
+
+	// Predicates, then fuzzy matching of the bare words
+	m.list.SetFilterText("memlock>1M cnt")
+	items := m.list.VisibleItems()
+	if len(items) != 2 || items[0].(mapItem).info.ID != 1 {
+		t.Errorf("expected conntrack and cgroup_counters, got %v", items)
+	}
+
+	// Plain fuzzy search still works
+	m.list.SetFilterText("cntrs")
+	if items := m.list.VisibleItems(); len(items) != 2 {
+		t.Errorf("expected both counters maps, got %v", items)
+	}
+
+	// Errors are shown under the list
+	m.list.SetFilterText("memlock>big")
+	if len(m.list.VisibleItems()) != 0 || !strings.Contains(m.View(), `Query: memlock>big: invalid size "big"`) {
+		t.Errorf("expected the query error:\n%s", m.View())
+	}
+
+	// Refreshes filter the new items
+	m.list.SetFilterText("type:hash")
+	cmd := m.RefreshMaps([]MapInfo{{ID: 3, Name: "cgroup_counters", Type: "hash"}, {ID: 4, Name: "new", Type: "hash"}})
+	for _, msg := range runCmd(cmd) {
+		m, _, _ = m.Update(msg)
+	}
+	if items := m.list.VisibleItems(); len(items) != 2 {
+		t.Errorf("expected both hash maps after refresh, got %v", items)
+	}
+}
+
+// TestIntegrationProgListQueryError tests that mistakes in a query typed
+// into the programs list are shown as it's typed.
+func TestIntegrationProgListQueryError(t *testing.T) {
+	svc := &mockProgService{programs: []ProgramInfo{{ID: 1, Name: "xdp_lb", Type: "xdp"}}}
+	m := updateAndRun(NewModel(svc, nil), tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("color:red")})
+	m = result.(Model)
+
+	if view := m.View(); !containsString(view, `Query: unknown field "color"`) || !containsString(view, "memlock") {
+		t.Errorf("expected the error and the valid fields:\n%s", view)
+	}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultRefreshInterval is how often the program and map lists are re-queried
+// when no interval is configured.
+const DefaultRefreshInterval = 2 * time.Second
+
+// refreshTickMsg is sent periodically to trigger a list refresh.
+type refreshTickMsg struct{}
+
+// refreshTickCmd schedules the next refresh tick.
+func refreshTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// itemStatus describes how a list item changed since the previous poll, or
+// since the baseline when comparing against one.
+type itemStatus int
+
+const (
+	itemUnchanged itemStatus = iota
+	itemAdded                // Appeared since the previous poll
+	itemRemoved              // Disappeared since the previous poll
+	itemChanged              // Differs from the baseline
+)
+
+// marker returns the prefix rendered before an item's title.
+func (s itemStatus) marker() string {
+	switch s {
+	case itemAdded:
+		return addedStyle.Render("+ ")
+	case itemRemoved:
+		return removedStyle.Render("- ")
+	case itemChanged:
+		return changedStyle.Render("~ ")
+	default:
+		return ""
+	}
+}
+
+// style returns the style highlighting items with the status.
+func (s itemStatus) style() lipgloss.Style {
+	switch s {
+	case itemAdded:
+		return addedStyle
+	case itemRemoved:
+		return removedStyle
+	case itemChanged:
+		return changedStyle
+	default:
+		return valueStyle
+	}
+}
+
+// diffIDs compares the IDs of two consecutive polls and returns the set of IDs
+// that appeared and the IDs (in their previous order) that disappeared.
+func diffIDs(prev, curr []uint32) (added map[uint32]bool, removed []uint32) {
+	prevSet := make(map[uint32]bool, len(prev))
+	for _, id := range prev {
+		prevSet[id] = true
+	}
+	currSet := make(map[uint32]bool, len(curr))
+	added = make(map[uint32]bool)
+	for _, id := range curr {
+		currSet[id] = true
+		if !prevSet[id] {
+			added[id] = true
+		}
+	}
+	for _, id := range prev {
+		if !currSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// changeTitle annotates a list title with the number of added and removed items.
+func changeTitle(base string, added, removed int) string {
+	if added == 0 && removed == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s (+%d -%d)", base, added, removed)
+}
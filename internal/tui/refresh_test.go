@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDiffIDs(t *testing.T) {
+	added, removed := diffIDs([]uint32{1, 2, 3}, []uint32{2, 3, 4, 5})
+
+	if len(added) != 2 || !added[4] || !added[5] {
+		t.Errorf("added = %v, want {4, 5}", added)
+	}
+	if len(removed) != 1 || removed[0] != 1 {
+		t.Errorf("removed = %v, want [1]", removed)
+	}
+}
+
+func TestDiffIDsNoChanges(t *testing.T) {
+	added, removed := diffIDs([]uint32{1, 2}, []uint32{2, 1})
+
+	if len(added) != 0 {
+		t.Errorf("added = %v, want empty", added)
+	}
+	if len(removed) != 0 {
+		t.Errorf("removed = %v, want empty", removed)
+	}
+}
+
+func TestChangeTitle(t *testing.T) {
+	tests := []struct {
+		added, removed int
+		want           string
+	}{
+		{0, 0, "BPF Programs"},
+		{2, 0, "BPF Programs (+2 -0)"},
+		{1, 3, "BPF Programs (+1 -3)"},
+	}
+
+	for _, tt := range tests {
+		if got := changeTitle("BPF Programs", tt.added, tt.removed); got != tt.want {
+			t.Errorf("changeTitle(%d, %d) = %q, want %q", tt.added, tt.removed, got, tt.want)
+		}
+	}
+}
+
+func TestItemStatusMarker(t *testing.T) {
+	if itemUnchanged.marker() != "" {
+		t.Error("unchanged items should have no marker")
+	}
+	if !containsString(itemAdded.marker(), "+") {
+		t.Error("added items should be marked with '+'")
+	}
+	if !containsString(itemRemoved.marker(), "-") {
+		t.Error("removed items should be marked with '-'")
+	}
+	if !containsString(itemChanged.marker(), "~") {
+		t.Error("changed items should be marked with '~'")
+	}
+}
+
+func TestRefreshTickDisabled(t *testing.T) {
+	m := NewModel(&mockProgService{}, nil)
+
+	if cmd := m.Init(); cmd != nil {
+		t.Error("Init should not schedule a refresh when the interval is zero")
+	}
+
+	_, cmd := m.Update(refreshTickMsg{})
+	if cmd != nil {
+		t.Error("refresh tick should not reschedule when the interval is zero")
+	}
+}
+
+func TestRefreshTickReloadsProgList(t *testing.T) {
+	mockSvc := &mockProgService{
+		programs: []ProgramInfo{
+			{ID: 1, Name: "prog1", Type: "kprobe", Tag: "tag1"},
+			{ID: 2, Name: "prog2", Type: "kprobe", Tag: "tag2"},
+		},
+	}
+
+	m := NewModel(mockSvc, nil)
+	m.SetRefreshInterval(DefaultRefreshInterval)
+
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("Init should schedule a refresh tick")
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+
+	// Move the cursor to prog2, then swap prog1 out for prog3
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	mockSvc.programs = []ProgramInfo{
+		{ID: 2, Name: "prog2", Type: "kprobe", Tag: "tag2"},
+		{ID: 3, Name: "prog3", Type: "xdp", Tag: "tag3"},
+	}
+
+	// Deliver only the refresh result, not the next (delayed) tick
+	result, cmd := m.Update(refreshTickMsg{})
+	m = result.(Model)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected batch of next tick and refresh, got %T", cmd())
+	}
+	result, _ = m.Update(batch[1]())
+	m = result.(Model)
+
+	if got := m.progList.SelectedItem(); got == nil || got.ID != 2 {
+		t.Errorf("cursor should stay on prog2 after refresh, got %v", got)
+	}
+
+	view := m.View()
+	if !containsString(view, "+ [3] prog3") {
+		t.Error("new program should be marked as added")
+	}
+	if !containsString(view, "- [1] prog1") {
+		t.Error("disappeared program should be marked as removed")
+	}
+	if !containsString(view, "(+1 -1)") {
+		t.Error("title should summarize the changes")
+	}
+}
+
+func TestRefreshTickReloadsProgDetail(t *testing.T) {
+	mockSvc := &mockProgService{
+		programs: []ProgramInfo{{ID: 1, Name: "prog1", RunCount: 10, RunTime: time.Microsecond}},
+	}
+
+	m := NewModel(mockSvc, nil)
+	m.SetRefreshInterval(DefaultRefreshInterval)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // ProgList → ProgDetail
+
+	mockSvc.programs = []ProgramInfo{{ID: 1, Name: "prog1", RunCount: 30, RunTime: 3 * time.Microsecond}}
+
+	result, cmd := m.Update(refreshTickMsg{})
+	m = result.(Model)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected batch of next tick and refresh, got %T", cmd())
+	}
+	result, _ = m.Update(batch[1]())
+	m = result.(Model)
+
+	if got := m.progDetail.GetProgram().RunCount; got != 30 {
+		t.Errorf("detail should show the refreshed run count, got %d", got)
+	}
+	if !containsString(m.View(), "ns/run") {
+		t.Error("detail should show the recent rate")
+	}
+}
+
+func TestRefreshTickIgnoredOutsideLists(t *testing.T) {
+	m := NewModel(&mockProgService{}, nil)
+	m.SetRefreshInterval(DefaultRefreshInterval)
+	seq := m.loadSeq
+
+	result, cmd := m.Update(refreshTickMsg{})
+	m = result.(Model)
+
+	if cmd == nil {
+		t.Error("refresh tick should reschedule itself")
+	}
+	if m.loadSeq != seq {
+		t.Error("refresh tick on the menu should not start a load")
+	}
+}
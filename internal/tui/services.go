@@ -1,6 +1,9 @@
 package tui
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // ProgramInfo represents information about a BPF program.
 // This mirrors the structure from gobpftool's prog.ProgramInfo.
@@ -18,6 +21,21 @@ type ProgramInfo struct {
 	MapIDs      []uint32
 	Pinned      bool     // Whether the program is pinned
 	PinnedPaths []string // Paths where the program is pinned
+	// Run statistics; only collected while kernel.bpf_stats_enabled is on
+	RunCount uint64        // Number of times the program has run
+	RunTime  time.Duration // Total time spent running the program
+}
+
+// LinkInfo represents information about a BPF link.
+type LinkInfo struct {
+	ID         uint32
+	Type       string // Link type, e.g. "cgroup", "xdp", "tracing" or "perf_event"
+	ProgID     uint32 // Program attached through the link
+	AttachType string // Attach type, e.g. "cgroup_inet_ingress"; for perf_event links the event type, e.g. "kprobe"
+	// Target is what the link attaches to: a cgroup path, netdev name,
+	// tracing target or kprobe function, uprobe path, tracepoint name, etc.
+	// Empty if the kernel doesn't report it.
+	Target string
 }
 
 // MapInfo represents information about a BPF map.
@@ -37,12 +55,134 @@ type MapInfo struct {
 	PinnedPaths []string // Paths where the map is pinned
 }
 
+// ProcessInfo represents a process holding BPF objects open through file
+// descriptors.
+type ProcessInfo struct {
+	PID     int
+	Comm    string
+	Cmdline string   // Arguments separated by spaces
+	Cgroup  string   // cgroup v2 path, e.g. "/system.slice/cilium.service"
+	ProgIDs []uint32 // Programs held directly or through links
+	MapIDs  []uint32
+}
+
+// PinInfo represents a file or directory in a mounted bpffs.
+type PinInfo struct {
+	Path string
+	Dir  bool
+	// Kind is "prog", "map" or "link" for pinned objects, or empty for
+	// directories and files that couldn't be opened.
+	Kind string
+	ID   uint32
+	Name string // Program or map name, or link type
+}
+
 // MapEntry represents a key-value entry in a BPF map.
 type MapEntry struct {
 	Key   []byte
 	Value []byte
 }
 
+// BTFKind identifies the kind of a BTF type.
+type BTFKind int
+
+const (
+	BTFKindUnknown BTFKind = iota
+	BTFKindInt
+	BTFKindFloat
+	BTFKindPointer
+	BTFKindArray
+	BTFKindStruct
+	BTFKindUnion
+	BTFKindEnum
+)
+
+// BTFType is a simplified BTF type used to decode map keys and values.
+// Typedefs and qualifiers (const, volatile, restrict) are resolved away.
+type BTFType struct {
+	Kind    BTFKind
+	Name    string
+	Size    uint32         // Size in bytes
+	Signed  bool           // Int and Enum
+	Char    bool           // Int encoded as a character
+	Bool    bool           // Int encoded as a boolean
+	Elem    *BTFType       // Array element type
+	Len     uint32         // Array length
+	Members []BTFMember    // Struct and Union members
+	Values  []BTFEnumValue // Enum values
+}
+
+// BTFMember is a member of a BTF struct or union.
+type BTFMember struct {
+	Name         string
+	Offset       uint32 // Offset from the start of the struct, in bits
+	BitfieldSize uint32 // Size in bits, or 0 if not a bitfield
+	Type         *BTFType
+}
+
+// BTFEnumValue is a named value of a BTF enum.
+type BTFEnumValue struct {
+	Name  string
+	Value uint64
+}
+
+// MapBTF holds the BTF types of a map's key and value.
+// Either may be nil if the map only annotates one of them.
+type MapBTF struct {
+	Key   *BTFType
+	Value *BTFType
+}
+
+// BTFObjectInfo represents a BTF object loaded into the kernel.
+type BTFObjectInfo struct {
+	ID     uint32
+	Name   string   // "vmlinux", the module name, or empty for BTF loaded from userspace
+	Kind   string   // "vmlinux", "module" or "program"
+	Owners []string // Programs and maps using userspace BTF, e.g. "prog xdp_main"
+}
+
+// BTFTypeInfo identifies a named type in a loaded BTF object.
+type BTFTypeInfo struct {
+	ObjID uint32 // BTF object the type belongs to
+	ID    uint32 // Type ID within the object
+	Kind  string // BTF kind, e.g. "struct", "enum", "typedef" or "func"
+	Name  string
+	// Label is the role of the type for the map or program it was looked up
+	// from, e.g. "key"; empty when browsing a BTF object.
+	Label string
+}
+
+// Instruction is a single eBPF instruction of a loaded program.
+type Instruction struct {
+	Offset int    // Offset from the start of the program, in 8-byte instruction units
+	OpCode uint8  // Raw opcode
+	Dst    uint8  // Destination register
+	Src    uint8  // Source register, or pseudo source for wide loads and calls
+	Off    int16  // Signed offset for memory accesses and jumps
+	Imm    int64  // Immediate; the full 64-bit constant for wide loads
+	MapID  uint32 // Map referenced by a map load, or 0
+	Call   string // Resolved helper or kfunc name for calls, if known
+	Func   string // Name of the function starting at this instruction, if any
+	Source string // Source line from BTF line info, if any
+}
+
+// JITImage is the native code of a JIT-compiled program.
+type JITImage struct {
+	Arch  string    // GOARCH the code was compiled for, e.g. "amd64" or "arm64"
+	Code  []byte    // Native code of all functions, back to back
+	Funcs []JITFunc // Functions in the order they appear in Code
+	// Symbol resolves a kernel address to a symbol name, or "" if unknown.
+	// May be nil.
+	Symbol func(addr uint64) string
+}
+
+// JITFunc is a single function within a JIT image.
+type JITFunc struct {
+	Name string
+	Addr uint64 // Kernel address the function was loaded at
+	Len  uint32 // Length of the function's code in bytes
+}
+
 // ProgService defines the interface for BPF program operations.
 type ProgService interface {
 	List() ([]ProgramInfo, error)
@@ -54,8 +194,126 @@ type MapsService interface {
 	List() ([]MapInfo, error)
 	Get(id uint32) (*MapInfo, error)
 	Dump(id uint32) ([]MapEntry, error)
+	// Lookup returns the value stored under key, or ErrKeyNotFound.
+	Lookup(id uint32, key []byte) ([]byte, error)
+	// Update creates or replaces the entry for key.
+	Update(id uint32, key, value []byte) error
+	// Insert creates the entry for key, or returns ErrKeyExists.
+	Insert(id uint32, key, value []byte) error
+	// Delete removes the entry for key, or returns ErrKeyNotFound.
+	Delete(id uint32, key []byte) error
 }
 
+// LinkService defines the interface for BPF link operations.
+type LinkService interface {
+	List() ([]LinkInfo, error)
+	Get(id uint32) (*LinkInfo, error)
+}
+
+// ProcessService defines the interface for finding the processes holding
+// BPF objects.
+type ProcessService interface {
+	List() ([]ProcessInfo, error)
+}
+
+// PinService defines the interface for browsing pinned BPF objects.
+type PinService interface {
+	// List returns every file and directory in the mounted bpffs instances,
+	// their mount points included.
+	List() ([]PinInfo, error)
+}
+
+// BTFService defines the interface for browsing BTF objects and types.
+type BTFService interface {
+	List() ([]BTFObjectInfo, error)
+	// Types returns the named types of a BTF object, sorted by name.
+	Types(id uint32) ([]BTFTypeInfo, error)
+	// Definition returns a C-like definition of a type.
+	Definition(id, typeID uint32) (string, error)
+	// MapTypes returns a map's key and value types, if the map has BTF.
+	MapTypes(mapID uint32) ([]BTFTypeInfo, error)
+	// ProgFuncs returns a program's functions from its BTF func info.
+	ProgFuncs(progID uint32) ([]BTFTypeInfo, error)
+}
+
+// ProgDisasmService is an optional interface a ProgService may implement to
+// provide a program's instructions for disassembly.
+type ProgDisasmService interface {
+	// Xlated returns the program's instructions as translated by the verifier.
+	Xlated(id uint32) ([]Instruction, error)
+	// JITed returns the program's JIT-compiled native code.
+	JITed(id uint32) (*JITImage, error)
+}
+
+// ProgStatsService is an optional interface a ProgService may implement to
+// control the kernel's collection of program run statistics.
+type ProgStatsService interface {
+	// StatsEnabled reports whether the kernel is collecting run statistics.
+	StatsEnabled() bool
+	// EnableStats turns on run statistics for as long as the service is open.
+	EnableStats() error
+}
+
+// MapBTFService is an optional interface a MapsService may implement to
+// provide BTF type information for decoding map keys and values.
+type MapBTFService interface {
+	// MapBTF returns the key and value types of a map, or nil if the map
+	// has no BTF.
+	MapBTF(id uint32) (*MapBTF, error)
+}
+
+// MapPageService is an optional interface a MapsService may implement to
+// read a map's entries a page at a time instead of all at once.
+type MapPageService interface {
+	// Iterate starts reading the map's entries from the beginning.
+	Iterate(id uint32) (MapIterator, error)
+}
+
+// MapIterator reads a map's entries incrementally.
+type MapIterator interface {
+	// Next returns up to n further entries. It may return fewer than n while
+	// entries remain, and returns none once all have been read.
+	Next(n int) ([]MapEntry, error)
+	// Close releases the iterator. It's safe to call more than once.
+	Close() error
+}
+
+// MapEvent is a record read from a ring buffer or perf event array.
+type MapEvent struct {
+	Time time.Time // When the record was read; the kernel doesn't timestamp them
+	CPU  int       // CPU the record was written on, or -1 for ring buffers
+	Data []byte    // Payload, or nil for a loss record
+	Lost uint64    // Samples the kernel dropped because the buffer was full
+}
+
+// MapEventService is an optional interface a MapsService may implement to
+// read the records BPF programs write to ring buffers and perf event arrays.
+type MapEventService interface {
+	// Tail starts reading the records written to the map from now on.
+	// Records read are consumed, so the map's other readers miss them.
+	Tail(id uint32) (MapEventReader, error)
+	// EventType looks up the type named name in the BTF of the map or the
+	// programs using it, to decode the map's records with.
+	EventType(id uint32, name string) (*BTFType, error)
+}
+
+// MapEventReader reads the records written to a ring buffer or perf event
+// array.
+type MapEventReader interface {
+	// Read waits up to timeout for records and returns those read, at most
+	// n. It returns early once n records are read, and with none if no
+	// record arrived in time.
+	Read(n int, timeout time.Duration) ([]MapEvent, error)
+	// Close stops reading. It's safe to call more than once.
+	Close() error
+}
+
+// ErrKeyNotFound is returned when a map has no entry for the requested key.
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrKeyExists is returned when inserting a key the map already has.
+var ErrKeyExists = errors.New("key already exists")
+
 // PermissionError indicates insufficient permissions for BPF operations.
 type PermissionError struct {
 	Err error
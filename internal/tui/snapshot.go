@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot file format.
+const snapshotVersion = 1
+
+// Snapshot is the state of a system's BPF programs and maps at a point in
+// time, including the contents of every map that could be dumped.
+type Snapshot struct {
+	Version  int
+	Taken    time.Time
+	Hostname string
+	Programs []ProgramInfo
+	Maps     []MapInfo
+	// Dumps holds the entries of each map, keyed by map ID.
+	Dumps map[uint32][]MapEntry
+	// DumpErrors holds the error for each map that couldn't be dumped,
+	// e.g. ring buffers.
+	DumpErrors map[uint32]string
+	// BTF holds the key and value types of each map that has BTF.
+	BTF map[uint32]*MapBTF
+}
+
+// CaptureSnapshot records all programs and maps from the services, dumping
+// every map. Failing to dump a map doesn't fail the capture; the error is
+// recorded in the snapshot instead.
+func CaptureSnapshot(progSvc ProgService, mapsSvc MapsService) (*Snapshot, error) {
+	progs, err := progSvc.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	maps, err := mapsSvc.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maps: %w", err)
+	}
+
+	snap := &Snapshot{
+		Version:    snapshotVersion,
+		Taken:      time.Now(),
+		Programs:   progs,
+		Maps:       maps,
+		Dumps:      make(map[uint32][]MapEntry),
+		DumpErrors: make(map[uint32]string),
+		BTF:        make(map[uint32]*MapBTF),
+	}
+	snap.Hostname, _ = os.Hostname()
+
+	btfSvc, _ := mapsSvc.(MapBTFService)
+	for _, m := range maps {
+		entries, err := mapsSvc.Dump(m.ID)
+		if err != nil {
+			snap.DumpErrors[m.ID] = err.Error()
+		} else {
+			snap.Dumps[m.ID] = entries
+		}
+
+		// BTF is best effort, as in the dump view
+		if btfSvc != nil {
+			if mapBTF, err := btfSvc.MapBTF(m.ID); err == nil && mapBTF != nil {
+				snap.BTF[m.ID] = mapBTF
+			}
+		}
+	}
+	return snap, nil
+}
+
+// Description identifies the snapshot by host and time, e.g. for titles.
+func (s *Snapshot) Description() string {
+	taken := s.Taken.Format("2006-01-02 15:04:05")
+	if s.Hostname == "" {
+		return "snapshot at " + taken
+	}
+	return fmt.Sprintf("snapshot of %s at %s", s.Hostname, taken)
+}
+
+// WriteSnapshot writes snap to w as JSON.
+func WriteSnapshot(w io.Writer, snap *Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+// WriteSnapshotFile writes snap to the file at path, replacing it if it exists.
+func WriteSnapshotFile(path string, snap *Snapshot) error {
+	var b bytes.Buffer
+	if err := WriteSnapshot(&b, snap); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	return &snap, nil
+}
+
+// ReadSnapshotFile reads a snapshot from the file at path.
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSnapshot(f)
+}
+
+// ErrReadOnly is returned when modifying data that can't be changed, such as
+// the maps of a snapshot.
+var ErrReadOnly = errors.New("read-only snapshot")
+
+// SnapshotProgService serves the programs of a snapshot as a ProgService.
+type SnapshotProgService struct {
+	snap *Snapshot
+}
+
+// NewSnapshotProgService creates a program service backed by snap.
+func NewSnapshotProgService(snap *Snapshot) *SnapshotProgService {
+	return &SnapshotProgService{snap: snap}
+}
+
+// List returns the snapshot's programs.
+func (s *SnapshotProgService) List() ([]ProgramInfo, error) {
+	return s.snap.Programs, nil
+}
+
+// Get returns a program of the snapshot by ID.
+func (s *SnapshotProgService) Get(id uint32) (*ProgramInfo, error) {
+	for i := range s.snap.Programs {
+		if s.snap.Programs[i].ID == id {
+			p := s.snap.Programs[i]
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("program %d not in snapshot", id)
+}
+
+// SnapshotMapsService serves the maps of a snapshot as a MapsService and
+// MapBTFService. Its maps can't be modified.
+type SnapshotMapsService struct {
+	snap *Snapshot
+}
+
+// NewSnapshotMapsService creates a maps service backed by snap.
+func NewSnapshotMapsService(snap *Snapshot) *SnapshotMapsService {
+	return &SnapshotMapsService{snap: snap}
+}
+
+// List returns the snapshot's maps.
+func (s *SnapshotMapsService) List() ([]MapInfo, error) {
+	return s.snap.Maps, nil
+}
+
+// Get returns a map of the snapshot by ID.
+func (s *SnapshotMapsService) Get(id uint32) (*MapInfo, error) {
+	for i := range s.snap.Maps {
+		if s.snap.Maps[i].ID == id {
+			m := s.snap.Maps[i]
+			return &m, nil
+		}
+	}
+	return nil, fmt.Errorf("map %d not in snapshot", id)
+}
+
+// Dump returns the entries the map had when the snapshot was taken.
+func (s *SnapshotMapsService) Dump(id uint32) ([]MapEntry, error) {
+	if msg, ok := s.snap.DumpErrors[id]; ok {
+		return nil, errors.New(msg)
+	}
+	entries, ok := s.snap.Dumps[id]
+	if !ok {
+		return nil, fmt.Errorf("map %d not in snapshot", id)
+	}
+	return entries, nil
+}
+
+// Lookup returns the value stored under key when the snapshot was taken.
+func (s *SnapshotMapsService) Lookup(id uint32, key []byte) ([]byte, error) {
+	entries, err := s.Dump(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if bytes.Equal(e.Key, key) {
+			return e.Value, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// Update always fails; snapshots are read-only.
+func (s *SnapshotMapsService) Update(id uint32, key, value []byte) error {
+	return ErrReadOnly
+}
+
+// Insert always fails; snapshots are read-only.
+func (s *SnapshotMapsService) Insert(id uint32, key, value []byte) error {
+	return ErrReadOnly
+}
+
+// Delete always fails; snapshots are read-only.
+func (s *SnapshotMapsService) Delete(id uint32, key []byte) error {
+	return ErrReadOnly
+}
+
+// MapBTF returns the map's key and value types, or nil if it has no BTF.
+func (s *SnapshotMapsService) MapBTF(id uint32) (*MapBTF, error) {
+	return s.snap.BTF[id], nil
+}
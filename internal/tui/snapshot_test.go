@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestSnapshot captures a snapshot of one program and two maps, the
+// second of which can't be dumped.
+func newTestSnapshot(t *testing.T) *Snapshot {
+	t.Helper()
+	progSvc := &mockProgService{programs: []ProgramInfo{
+		{ID: 1, Name: "xdp_main", Type: "xdp", MapIDs: []uint32{10}, RunTime: time.Millisecond},
+	}}
+	mapsSvc := &mockMapsServiceWithBTF{
+		mockMapsServiceWithDump: mockMapsServiceWithDump{
+			maps: []MapInfo{
+				{ID: 10, Name: "counts", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 16},
+			},
+			entries: []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{42, 0, 0, 0}}},
+		},
+		btf: &MapBTF{Key: btfU32, Value: btfU32},
+	}
+
+	snap, err := CaptureSnapshot(progSvc, mapsSvc)
+	if err != nil {
+		t.Fatalf("CaptureSnapshot() error = %v", err)
+	}
+
+	// Add a map that failed to dump, as a ring buffer would
+	snap.Maps = append(snap.Maps, MapInfo{ID: 11, Name: "events", Type: "ringbuf"})
+	snap.DumpErrors[11] = "operation not supported"
+	return snap
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	snap := newTestSnapshot(t)
+
+	if snap.Version != snapshotVersion || snap.Taken.IsZero() {
+		t.Errorf("snapshot header = %d, %v", snap.Version, snap.Taken)
+	}
+	if len(snap.Programs) != 1 || len(snap.Maps) != 2 {
+		t.Fatalf("captured %d programs and %d maps", len(snap.Programs), len(snap.Maps))
+	}
+	if len(snap.Dumps[10]) != 1 || snap.BTF[10] == nil {
+		t.Error("map contents and BTF should be captured")
+	}
+}
+
+func TestCaptureSnapshotRecordsDumpErrors(t *testing.T) {
+	mapsSvc := &mockMapsServiceWithDump{
+		maps:    []MapInfo{{ID: 3, Name: "events", Type: "ringbuf"}},
+		dumpErr: errors.New("operation not supported"),
+	}
+	snap, err := CaptureSnapshot(&mockProgService{}, mapsSvc)
+	if err != nil {
+		t.Fatalf("a map that can't be dumped should not fail the capture: %v", err)
+	}
+	if snap.DumpErrors[3] != "operation not supported" {
+		t.Errorf("DumpErrors = %v", snap.DumpErrors)
+	}
+
+	if _, err := CaptureSnapshot(&mockProgService{err: errors.New("permission denied")}, mapsSvc); err == nil {
+		t.Error("failing to list programs should fail the capture")
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snap := newTestSnapshot(t)
+	path := filepath.Join(t.TempDir(), "snap.json")
+	if err := WriteSnapshotFile(path, snap); err != nil {
+		t.Fatalf("WriteSnapshotFile() error = %v", err)
+	}
+
+	got, err := ReadSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshotFile() error = %v", err)
+	}
+	if !got.Taken.Equal(snap.Taken) {
+		t.Errorf("Taken = %v, want %v", got.Taken, snap.Taken)
+	}
+	got.Taken = snap.Taken
+	if !reflect.DeepEqual(got, snap) {
+		t.Errorf("round trip changed the snapshot:\n%+v\nwant\n%+v", got, snap)
+	}
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	if _, err := ReadSnapshot(strings.NewReader("not json")); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+	if _, err := ReadSnapshot(strings.NewReader(`{"Version": 99}`)); err == nil {
+		t.Error("unknown version should fail")
+	}
+}
+
+func TestSnapshotServices(t *testing.T) {
+	snap := newTestSnapshot(t)
+	progSvc := NewSnapshotProgService(snap)
+	mapsSvc := NewSnapshotMapsService(snap)
+
+	if p, err := progSvc.Get(1); err != nil || p.Name != "xdp_main" {
+		t.Errorf("Get(1) = %v, %v", p, err)
+	}
+	if _, err := progSvc.Get(2); err == nil {
+		t.Error("Get of a missing program should fail")
+	}
+
+	if entries, err := mapsSvc.Dump(10); err != nil || len(entries) != 1 {
+		t.Errorf("Dump(10) = %v, %v", entries, err)
+	}
+	if _, err := mapsSvc.Dump(11); err == nil || err.Error() != "operation not supported" {
+		t.Errorf("Dump(11) error = %v, want the recorded error", err)
+	}
+
+	if v, err := mapsSvc.Lookup(10, []byte{1, 0, 0, 0}); err != nil || !bytes.Equal(v, []byte{42, 0, 0, 0}) {
+		t.Errorf("Lookup() = %v, %v", v, err)
+	}
+	if _, err := mapsSvc.Lookup(10, []byte{2, 0, 0, 0}); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Lookup of a missing key error = %v", err)
+	}
+
+	if err := mapsSvc.Update(10, []byte{1, 0, 0, 0}, []byte{0, 0, 0, 0}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Update() error = %v, want ErrReadOnly", err)
+	}
+	if err := mapsSvc.Delete(10, []byte{1, 0, 0, 0}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Delete() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestSnapshotDescription(t *testing.T) {
+	snap := &Snapshot{Hostname: "node-1", Taken: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)}
+	if got := snap.Description(); got != "snapshot of node-1 at 2024-05-01 12:30:00" {
+		t.Errorf("Description() = %q", got)
+	}
+	snap.Hostname = ""
+	if got := snap.Description(); got != "snapshot at 2024-05-01 12:30:00" {
+		t.Errorf("Description() without hostname = %q", got)
+	}
+}
+
+// TestIntegrationBrowseSnapshot tests that a snapshot drives the map views,
+// decoding with its BTF and refusing edits.
+func TestIntegrationBrowseSnapshot(t *testing.T) {
+	snap := newTestSnapshot(t)
+	m := NewModel(NewSnapshotProgService(snap), NewSnapshotMapsService(snap))
+	m.SetSource(snap.Description())
+	if !containsString(m.View(), snap.Description()) {
+		t.Error("menu title should show the snapshot")
+	}
+
+	m = openMapDump(m)
+	if m.state != ViewMapDump || m.mapDump.GetEntryCount() != 1 {
+		t.Fatalf("expected the snapshot's map dump, got state %v with %d entries", m.state, m.mapDump.GetEntryCount())
+	}
+	if !m.mapDump.HasBTF() {
+		t.Error("dump should be decoded with the snapshot's BTF")
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = result.(Model)
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if !containsString(m.View(), "read-only snapshot") {
+		t.Error("edits should report that the snapshot is read-only")
+	}
+}
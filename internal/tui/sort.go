@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// sortKey is a column the programs and maps lists can be sorted by.
+type sortKey int
+
+const (
+	sortNone sortKey = iota // Kernel order
+	sortByID
+	sortByName
+	sortByType
+	sortByMemLock
+	sortByLoadTime
+	sortByUID
+	sortByMaxEntries
+	sortByKeySize
+	sortByValueSize
+)
+
+// String returns the column name shown in list titles.
+func (k sortKey) String() string {
+	switch k {
+	case sortByID:
+		return "ID"
+	case sortByName:
+		return "name"
+	case sortByType:
+		return "type"
+	case sortByMemLock:
+		return "memlock"
+	case sortByLoadTime:
+		return "load time"
+	case sortByUID:
+		return "UID"
+	case sortByMaxEntries:
+		return "max entries"
+	case sortByKeySize:
+		return "key size"
+	case sortByValueSize:
+		return "value size"
+	default:
+		return "none"
+	}
+}
+
+// progSortKeys and mapSortKeys are the columns each list cycles through.
+var (
+	progSortKeys = []sortKey{sortNone, sortByID, sortByName, sortByType, sortByMemLock, sortByLoadTime, sortByUID}
+	mapSortKeys  = []sortKey{sortNone, sortByID, sortByName, sortByType, sortByMemLock, sortByLoadTime, sortByUID,
+		sortByMaxEntries, sortByKeySize, sortByValueSize}
+)
+
+// listSort is the order of a list: a column and a direction.
+type listSort struct {
+	key  sortKey
+	desc bool
+}
+
+// next returns the order sorting by the column after the current one in
+// keys, ascending. After the last column, the list goes back to kernel order.
+func (s listSort) next(keys []sortKey) listSort {
+	i := slices.Index(keys, s.key)
+	return listSort{key: keys[(i+1)%len(keys)]}
+}
+
+// reversed returns the order with the direction flipped.
+func (s listSort) reversed() listSort {
+	s.desc = !s.desc
+	return s
+}
+
+// title returns the sort indicator appended to list titles, e.g.
+// " · memlock ↓", or "" in kernel order.
+func (s listSort) title() string {
+	if s.key == sortNone {
+		return ""
+	}
+	if s.desc {
+		return " · " + s.key.String() + " ↓"
+	}
+	return " · " + s.key.String() + " ↑"
+}
+
+// sortItems stably sorts list items by the column of s, where field returns
+// an item's value for a column. Ties keep kernel order.
+func sortItems(items []list.Item, s listSort, field func(list.Item, sortKey) sortField) {
+	if s.key == sortNone {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		c := field(a, s.key).compare(field(b, s.key))
+		if s.desc {
+			return -c
+		}
+		return c
+	})
+}
+
+// sortField is an item's value for a column: a number or a string.
+type sortField struct {
+	n uint64
+	s string
+}
+
+// compare compares two values of the same column. Strings compare case
+// insensitively.
+func (f sortField) compare(o sortField) int {
+	return cmp.Or(cmp.Compare(f.n, o.n), cmp.Compare(strings.ToLower(f.s), strings.ToLower(o.s)))
+}
+
+// progSortField returns a program list item's value for a column.
+func progSortField(item list.Item, k sortKey) sortField {
+	p := item.(progItem).info
+	switch k {
+	case sortByID:
+		return sortField{n: uint64(p.ID)}
+	case sortByName:
+		return sortField{s: p.Name}
+	case sortByType:
+		return sortField{s: p.Type}
+	case sortByMemLock:
+		return sortField{n: uint64(p.MemLock)}
+	case sortByLoadTime:
+		// Formatted as "2006-01-02 15:04:05", which sorts chronologically
+		return sortField{s: p.LoadedAt}
+	case sortByUID:
+		return sortField{n: uint64(p.UID)}
+	}
+	return sortField{}
+}
+
+// mapSortField returns a map list item's value for a column.
+func mapSortField(item list.Item, k sortKey) sortField {
+	m := item.(mapItem).info
+	switch k {
+	case sortByID:
+		return sortField{n: uint64(m.ID)}
+	case sortByName:
+		return sortField{s: m.Name}
+	case sortByType:
+		return sortField{s: m.Type}
+	case sortByMemLock:
+		return sortField{n: uint64(m.MemLock)}
+	case sortByLoadTime:
+		return sortField{s: m.LoadedAt}
+	case sortByUID:
+		return sortField{n: uint64(m.UID)}
+	case sortByMaxEntries:
+		return sortField{n: uint64(m.MaxEntries)}
+	case sortByKeySize:
+		return sortField{n: uint64(m.KeySize)}
+	case sortByValueSize:
+		return sortField{n: uint64(m.ValueSize)}
+	}
+	return sortField{}
+}
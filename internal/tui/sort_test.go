@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestListSortNext(t *testing.T) {
+	s := listSort{}
+	for _, want := range progSortKeys[1:] {
+		s = s.next(progSortKeys)
+		if s.key != want || s.desc {
+			t.Fatalf("next() = %v, want %v ascending", s, want)
+		}
+	}
+	if s = s.next(progSortKeys); s.key != sortNone {
+		t.Errorf("after the last column, expected kernel order, got %v", s.key)
+	}
+
+	s = listSort{key: sortByMemLock}.reversed()
+	if got := s.title(); got != " · memlock ↓" {
+		t.Errorf("title() = %q", got)
+	}
+	if got := s.reversed().next(mapSortKeys); got.key != sortByLoadTime || got.desc {
+		t.Errorf("a new column should sort ascending, got %v", got)
+	}
+}
+
+func mapNames(m mapListModel) []string {
+	var names []string
+	for _, item := range m.list.Items() {
+		names = append(names, item.(mapItem).info.Name)
+	}
+	return names
+}
+
+func TestMapListSort(t *testing.T) {
+	m := newMapListModel(80, 24)
+	maps := []MapInfo{
+		{ID: 1, Name: "small", MemLock: 4096, MaxEntries: 10},
+		{ID: 2, Name: "Big", MemLock: 1 << 20, MaxEntries: 1},
+		{ID: 3, Name: "mid", MemLock: 65536, MaxEntries: 100},
+	}
+	m.SetMaps(maps)
+
+	m.SetSort(listSort{key: sortByMemLock, desc: true})
+	if got := mapNames(m); got[0] != "Big" || got[1] != "mid" || got[2] != "small" {
+		t.Errorf("expected the biggest maps first, got %v", got)
+	}
+	if m.list.Title != "BPF Maps · memlock ↓" {
+		t.Errorf("title = %q", m.list.Title)
+	}
+
+	// Names sort case-insensitively
+	m.SetSort(listSort{key: sortByName})
+	if got := mapNames(m); got[0] != "Big" || got[2] != "small" {
+		t.Errorf("expected names in order, got %v", got)
+	}
+
+	// Refreshes keep the order and the selected map
+	m.SetSort(listSort{key: sortByMaxEntries})
+	m.list.Select(2) // mid
+	m.RefreshMaps(append(maps, MapInfo{ID: 4, Name: "new", MaxEntries: 50}))
+	if got := mapNames(m); got[2] != "new" {
+		t.Errorf("new maps should be sorted in, got %v", got)
+	}
+	if sel := m.SelectedItem(); sel == nil || sel.Name != "mid" {
+		t.Errorf("cursor should stay on mid, got %v", sel)
+	}
+
+	// Back to kernel order
+	m.SetSort(listSort{})
+	if got := mapNames(m); got[0] != "small" || m.list.Title != "BPF Maps" {
+		t.Errorf("expected kernel order, got %v titled %q", got, m.list.Title)
+	}
+}
+
+func TestProgListSort(t *testing.T) {
+	m := newProgListModel(80, 24)
+	m.SetPrograms([]ProgramInfo{
+		{ID: 1, Name: "a", LoadedAt: "2024-01-02 00:00:00", UID: 0},
+		{ID: 2, Name: "b", LoadedAt: "2024-01-01 09:30:00", UID: 1000},
+		{ID: 3, Name: "c", LoadedAt: "2024-01-01 10:00:00", UID: 0},
+	})
+
+	m.SetSort(listSort{key: sortByLoadTime})
+	var got []string
+	for _, item := range m.list.Items() {
+		got = append(got, item.(progItem).info.Name)
+	}
+	if got[0] != "b" || got[1] != "c" || got[2] != "a" {
+		t.Errorf("expected oldest programs first, got %v", got)
+	}
+
+	// Ties keep kernel order
+	m.SetSort(listSort{key: sortByUID, desc: true})
+	if first := m.list.Items()[0].(progItem).info.Name; first != "b" {
+		t.Errorf("expected UID 1000 first, got %s", first)
+	}
+}
+
+func TestMapListSortWithinProcesses(t *testing.T) {
+	m := newMapListModel(80, 24)
+	m.SetMaps([]MapInfo{{ID: 1, Name: "b"}, {ID: 2, Name: "unowned"}, {ID: 3, Name: "a"}})
+	m.SetProcesses([]ProcessInfo{{PID: 20, Comm: "agent", MapIDs: []uint32{1, 3}}})
+	m.SetByProcess(true)
+	m.SetSort(listSort{key: sortByName})
+
+	if got := mapNames(m); got[0] != "a" || got[1] != "b" || got[2] != "unowned" {
+		t.Errorf("expected maps sorted within their process, got %v", got)
+	}
+	if m.list.Title != "BPF Maps by Process · name ↑" {
+		t.Errorf("title = %q", m.list.Title)
+	}
+}
+
+// TestIntegrationMapListSortKeys tests sorting the maps list with s and r.
+func TestIntegrationMapListSortKeys(t *testing.T) {
+	svc := &mockMapsServiceWithDump{maps: []MapInfo{{ID: 1, Name: "x"}, {ID: 2, Name: "y"}}}
+	m := NewModel(&mockProgService{}, svc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if s := m.mapList.GetSort(); s.key != sortByID || !s.desc {
+		t.Fatalf("expected descending IDs, got %v", s)
+	}
+	if items := m.mapList.list.Items(); items[0].(mapItem).info.ID != 2 {
+		t.Errorf("expected map 2 first, got %v", items[0])
+	}
+	if view := m.View(); !containsString(view, "ID ↓") {
+		t.Errorf("title should show the order:\n%s", view)
+	}
+
+	// r does nothing in kernel order
+	m.mapList.SetSort(listSort{})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if m.mapList.GetSort().desc || m.mapList.list.Title != "BPF Maps" {
+		t.Error("r should only reverse a sorted list")
+	}
+}
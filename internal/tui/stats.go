@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// progSample is a program's cumulative run counters at a point in time.
+type progSample struct {
+	runCount uint64
+	runTime  time.Duration
+	at       time.Time
+}
+
+// newProgSample records p's counters as of at.
+func newProgSample(p ProgramInfo, at time.Time) progSample {
+	return progSample{runCount: p.RunCount, runTime: p.RunTime, at: at}
+}
+
+// progRate is a program's activity between two samples.
+type progRate struct {
+	valid      bool    // False until two samples have been taken
+	runsPerSec float64 // Runs per second over the interval
+	nsPerRun   float64 // Average run time of the runs in the interval, 0 if none
+}
+
+// newProgRate computes the activity between prev and curr. The rate is
+// invalid if the samples are out of order or the counters went backwards.
+func newProgRate(prev, curr progSample) progRate {
+	elapsed := curr.at.Sub(prev.at)
+	if elapsed <= 0 || curr.runCount < prev.runCount || curr.runTime < prev.runTime {
+		return progRate{}
+	}
+
+	runs := curr.runCount - prev.runCount
+	rate := progRate{valid: true, runsPerSec: float64(runs) / elapsed.Seconds()}
+	if runs > 0 {
+		rate.nsPerRun = float64(curr.runTime-prev.runTime) / float64(runs)
+	}
+	return rate
+}
+
+// avgRunTime returns p's average run time over its lifetime, or 0 if it never ran.
+func avgRunTime(p ProgramInfo) time.Duration {
+	if p.RunCount == 0 {
+		return 0
+	}
+	return p.RunTime / time.Duration(p.RunCount)
+}
+
+// formatCount formats a count with a metric suffix, e.g. 1234567 as "1.23M".
+func formatCount(n float64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.2fG", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.2fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.2fk", n/1e3)
+	case n == float64(int64(n)):
+		return fmt.Sprintf("%d", int64(n))
+	default:
+		return fmt.Sprintf("%.1f", n)
+	}
+}
+
+// formatStats summarizes a program's run statistics for the list: the rate
+// since the previous poll if known, otherwise the lifetime totals.
+func formatStats(p ProgramInfo, rate progRate) string {
+	if rate.valid {
+		if rate.nsPerRun == 0 {
+			return fmt.Sprintf("%s runs/s", formatCount(rate.runsPerSec))
+		}
+		return fmt.Sprintf("%s runs/s, %.0f ns/run", formatCount(rate.runsPerSec), rate.nsPerRun)
+	}
+	return fmt.Sprintf("%s runs, %d ns/run", formatCount(float64(p.RunCount)), avgRunTime(p).Nanoseconds())
+}
+
+// statsPrompt is shown while the kernel isn't collecting run statistics.
+const statsPrompt = "Run statistics are disabled (kernel.bpf_stats_enabled=0). Press S to enable them."
+
+// renderStatsPrompt renders the prompt to enable run statistics, or the
+// error from the last attempt if it failed.
+func renderStatsPrompt(err error) string {
+	if err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", err))
+	}
+	return dimStyle.Render(statsPrompt)
+}
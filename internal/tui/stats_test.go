@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewProgRate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	prev := progSample{runCount: 100, runTime: 10 * time.Microsecond, at: start}
+
+	rate := newProgRate(prev, progSample{runCount: 300, runTime: 30 * time.Microsecond, at: start.Add(2 * time.Second)})
+	if !rate.valid || rate.runsPerSec != 100 || rate.nsPerRun != 100 {
+		t.Errorf("rate = %+v, want 100 runs/s at 100 ns/run", rate)
+	}
+
+	// No runs in the interval
+	rate = newProgRate(prev, progSample{runCount: 100, runTime: 10 * time.Microsecond, at: start.Add(time.Second)})
+	if !rate.valid || rate.runsPerSec != 0 || rate.nsPerRun != 0 {
+		t.Errorf("idle rate = %+v, want zero", rate)
+	}
+
+	// Out-of-order samples and counters going backwards are discarded
+	if newProgRate(prev, progSample{runCount: 200, at: start}).valid {
+		t.Error("rate with no elapsed time should be invalid")
+	}
+	if newProgRate(prev, progSample{runCount: 50, at: start.Add(time.Second)}).valid {
+		t.Error("rate with decreasing counters should be invalid")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[float64]string{
+		0:       "0",
+		42:      "42",
+		2.5:     "2.5",
+		1500:    "1.50k",
+		2345678: "2.35M",
+		3e9:     "3.00G",
+	}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%v) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFormatStats(t *testing.T) {
+	p := ProgramInfo{RunCount: 2000, RunTime: 500 * time.Microsecond}
+
+	if got := formatStats(p, progRate{}); got != "2.00k runs, 250 ns/run" {
+		t.Errorf("lifetime stats = %q", got)
+	}
+	if got := formatStats(p, progRate{valid: true, runsPerSec: 1200, nsPerRun: 180.4}); got != "1.20k runs/s, 180 ns/run" {
+		t.Errorf("rate stats = %q", got)
+	}
+	if got := formatStats(p, progRate{valid: true}); got != "0 runs/s" {
+		t.Errorf("idle stats = %q", got)
+	}
+}
+
+func TestRenderStatsPrompt(t *testing.T) {
+	if got := renderStatsPrompt(nil); !strings.Contains(got, "Press S to enable") {
+		t.Errorf("prompt = %q", got)
+	}
+	if got := renderStatsPrompt(errors.New("operation not permitted")); !strings.Contains(got, "operation not permitted") {
+		t.Errorf("error prompt = %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"golang.org/x/sys/unix"
+)
+
+// bpfStatsSysctl is the sysctl that enables run statistics system-wide.
+const bpfStatsSysctl = "/proc/sys/kernel/bpf_stats_enabled"
+
+// addRunStats fills in a program's run statistics. Stats are best effort;
+// they stay zero if the program can't be opened.
+func addRunStats(p *ProgramInfo) {
+	prog, err := ebpf.NewProgramFromID(ebpf.ProgramID(p.ID))
+	if err != nil {
+		return
+	}
+	defer prog.Close()
+
+	stats, err := prog.Stats()
+	if err != nil {
+		return
+	}
+	p.RunCount = stats.RunCount
+	p.RunTime = stats.Runtime
+}
+
+// StatsEnabled reports whether the kernel is collecting run statistics,
+// either through the sysctl or through a stats fd held by this adapter.
+func (a *ProgServiceAdapter) StatsEnabled() bool {
+	a.mu.Lock()
+	held := a.stats != nil
+	a.mu.Unlock()
+	if held {
+		return true
+	}
+
+	data, err := os.ReadFile(bpfStatsSysctl)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) != "0"
+}
+
+// EnableStats turns on run statistics by holding a BPF_ENABLE_STATS fd. The
+// kernel collects statistics until the fd is closed by Close, without
+// changing the sysctl for other users.
+func (a *ProgServiceAdapter) EnableStats() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stats != nil {
+		return nil
+	}
+
+	fd, err := ebpf.EnableStats(uint32(unix.BPF_STATS_RUN_TIME))
+	if err != nil {
+		return fmt.Errorf("failed to enable run statistics: %w", err)
+	}
+	a.stats = fd
+	return nil
+}
+
+// Close releases the stats fd, if any, which stops run statistics unless
+// something else keeps them enabled.
+func (a *ProgServiceAdapter) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stats == nil {
+		return nil
+	}
+	err := a.stats.Close()
+	a.stats = nil
+	return err
+}
@@ -38,4 +38,32 @@ var (
 	// valueStyle is used for field values in detail views.
 	valueStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("252"))
+
+	// addedStyle marks list items that appeared since the last refresh.
+	addedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Bold(true)
+
+	// removedStyle marks list items that disappeared since the last refresh.
+	removedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Bold(true)
+
+	// changedStyle marks list items that differ from the baseline.
+	changedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Bold(true)
+
+	// linkStyle marks references that can be followed with Enter.
+	linkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("39"))
+
+	// matchStyle highlights search matches.
+	matchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("0")).
+			Background(lipgloss.Color("220"))
+
+	// spinnerStyle is used for the loading spinner.
+	spinnerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205"))
 )
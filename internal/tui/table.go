@@ -0,0 +1,364 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Table layout widths, in cells.
+const (
+	tableGutter      = 2  // Selection or change marker before each row
+	tableSpacing     = 2  // Between columns
+	tableMaxWidth    = 32 // Widest a column other than the flexible one gets
+	tableMinFlexWide = 12 // Narrowest the flexible column gets
+	tableHeaderLines = 3  // Title, blank line and column names
+)
+
+// tableColumn is a column of a list's table layout.
+type tableColumn struct {
+	name  string // Shown in the header and the column chooser
+	right bool   // Right-aligned, for numbers
+	flex  bool   // Takes the width the other columns leave
+	// Shown whenever any row has a value, rather than chosen by the user,
+	// e.g. the processes holding objects while grouping by process
+	auto  bool
+	cell  func(list.Item) string
+	width int // Set by layout
+}
+
+// tableItem is a list item that can be shown in a table.
+type tableItem interface {
+	list.Item
+	changeStatus() itemStatus
+}
+
+// listTable is the optional table layout of a list: a line per item, with
+// the columns the user chose.
+type listTable struct {
+	on      bool
+	columns []tableColumn
+	shown   []bool        // Columns the user chose, by index in columns
+	cols    []tableColumn // Laid out columns, set by apply
+	width   int
+	height  int
+	// Column chooser
+	choosing bool
+	cursor   int
+}
+
+// newListTable creates a table layout showing the given columns by default.
+func newListTable(columns []tableColumn, defaults ...string) listTable {
+	shown := make([]bool, len(columns))
+	for i, c := range columns {
+		shown[i] = slices.Contains(defaults, c.name)
+	}
+	return listTable{columns: columns, shown: shown}
+}
+
+// newItemDelegate creates the delegate rendering list items on two lines,
+// a title and a description.
+func newItemDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = selectedStyle
+	delegate.Styles.SelectedDesc = selectedStyle.Foreground(dimStyle.GetForeground())
+	return delegate
+}
+
+// apply lays out l as a table or as a regular list, and sizes it. It's
+// called whenever the items, the columns or the size change, as column
+// widths depend on all three.
+func (t *listTable) apply(l *list.Model) {
+	// Leave room for the title and help bar
+	height := max(t.height-6, 3)
+	if !t.on {
+		l.SetDelegate(newItemDelegate())
+		l.SetShowTitle(true)
+		l.SetShowFilter(true)
+		l.SetShowStatusBar(true)
+		l.SetSize(t.width, height)
+		return
+	}
+
+	// The title and the filter input are rendered above the column names
+	l.SetShowTitle(false)
+	l.SetShowFilter(false)
+	l.SetShowStatusBar(false)
+	l.SetSize(t.width, max(height-tableHeaderLines, 3))
+	t.cols = t.layout(l.Items(), t.width)
+	l.SetDelegate(tableDelegate{cols: t.cols})
+}
+
+// render renders l as a table, under its title or filter input and column
+// names, or the column chooser while it's open. noun names the items in the
+// count next to the title, e.g. "maps".
+func (t listTable) render(l list.Model, noun string) string {
+	if t.choosing {
+		return t.View()
+	}
+
+	var title string
+	switch l.FilterState() {
+	case list.Filtering:
+		title = l.FilterInput.View()
+	case list.FilterApplied:
+		title = titleStyle.Render(l.Title) + dimStyle.Render(fmt.Sprintf("  “%s” %d of %d %s",
+			l.FilterValue(), len(l.VisibleItems()), len(l.Items()), noun))
+	default:
+		title = titleStyle.Render(l.Title) + dimStyle.Render(fmt.Sprintf("  %d %s", len(l.Items()), noun))
+	}
+
+	header := t.header(t.cols)
+	return title + "\n\n" + header + "\n" + l.View()
+}
+
+// choosable returns the indexes of the columns the user can show or hide.
+func (t listTable) choosable() []int {
+	var idx []int
+	for i, c := range t.columns {
+		if !c.auto {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// toggle shows or hides the column under the chooser's cursor. The last
+// shown column can't be hidden.
+func (t *listTable) toggle() {
+	idx := t.choosable()
+	if t.cursor >= len(idx) {
+		return
+	}
+	i := idx[t.cursor]
+	if t.shown[i] && t.shownCount() == 1 {
+		return
+	}
+	t.shown[i] = !t.shown[i]
+}
+
+// shownCount returns the number of columns the user chose.
+func (t listTable) shownCount() int {
+	n := 0
+	for _, s := range t.shown {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// SetColumns shows the named columns and hides the others. Unknown names
+// are returned as an error, leaving the columns unchanged.
+func (t *listTable) SetColumns(names []string) error {
+	shown := make([]bool, len(t.columns))
+	for _, name := range names {
+		i := slices.IndexFunc(t.columns, func(c tableColumn) bool { return c.name == name && !c.auto })
+		if i < 0 {
+			return fmt.Errorf("unknown column %q", name)
+		}
+		shown[i] = true
+	}
+	if !slices.Contains(shown, true) {
+		return fmt.Errorf("no columns")
+	}
+	t.shown = shown
+	return nil
+}
+
+// layout returns the columns to show for items, with their widths, fitting
+// the table in width. Columns are as wide as their widest cell, up to
+// tableMaxWidth, and the flexible column takes the rest. Columns that don't
+// fit are left out, starting from the last one.
+func (t listTable) layout(items []list.Item, width int) []tableColumn {
+	var cols []tableColumn
+	var widths []int
+	for i, c := range t.columns {
+		w := 0
+		for _, item := range items {
+			w = max(w, lipgloss.Width(c.cell(item)))
+		}
+		if c.auto && w == 0 || !c.auto && !t.shown[i] {
+			continue
+		}
+		w = max(w, lipgloss.Width(c.name))
+		if !c.flex {
+			w = min(w, tableMaxWidth)
+		}
+		cols = append(cols, c)
+		widths = append(widths, w)
+	}
+
+	// Drop columns from the right until the fixed ones fit
+	fixed := func() int {
+		total := tableGutter
+		for j, c := range cols {
+			if j > 0 {
+				total += tableSpacing
+			}
+			if c.flex {
+				total += tableMinFlexWide
+			} else {
+				total += widths[j]
+			}
+		}
+		return total
+	}
+	for len(cols) > 1 && fixed() > width {
+		cols, widths = cols[:len(cols)-1], widths[:len(widths)-1]
+	}
+
+	// The flexible column takes what's left, but no more than it needs
+	if j := slices.IndexFunc(cols, func(c tableColumn) bool { return c.flex }); j >= 0 {
+		widths[j] = min(widths[j], max(tableMinFlexWide, width-fixed()+tableMinFlexWide))
+	}
+	for j := range cols {
+		cols[j].width = widths[j]
+	}
+	return cols
+}
+
+// header renders the column names.
+func (t listTable) header(cols []tableColumn) string {
+	cells := make([]string, len(cols))
+	for j, c := range cols {
+		cells[j] = strings.ToUpper(c.name)
+	}
+	return strings.Repeat(" ", tableGutter) + dimStyle.Bold(true).Render(tableRow(cols, cells))
+}
+
+// tableRow aligns cells to their columns' widths, truncating those too
+// long with an ellipsis.
+func tableRow(cols []tableColumn, cells []string) string {
+	var b strings.Builder
+	for j, c := range cols {
+		if j > 0 {
+			b.WriteString(strings.Repeat(" ", tableSpacing))
+		}
+		s := truncateCell(cells[j], c.width)
+		pad := strings.Repeat(" ", c.width-lipgloss.Width(s))
+		if c.right {
+			b.WriteString(pad + s)
+		} else if j < len(cols)-1 {
+			b.WriteString(s + pad)
+		} else {
+			// No trailing spaces after the last column
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// truncateCell shortens s to width cells, ending it with an ellipsis.
+func truncateCell(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && lipgloss.Width(string(r))+1 > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
+
+// View renders the column chooser.
+func (t listTable) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Columns"))
+	b.WriteString("\n\n")
+	for k, i := range t.choosable() {
+		box := "[ ] "
+		if t.shown[i] {
+			box = "[x] "
+		}
+		line := box + t.columns[i].name
+		if k == t.cursor {
+			b.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			b.WriteString(normalStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Update handles keys while the column chooser is open.
+func (t listTable) Update(msg tea.KeyMsg) listTable {
+	switch msg.String() {
+	case "up", "k":
+		t.cursor = max(t.cursor-1, 0)
+	case "down", "j":
+		t.cursor = min(t.cursor+1, len(t.choosable())-1)
+	case " ", "enter", "x":
+		t.toggle()
+	case "esc", "c":
+		t.choosing = false
+	}
+	return t
+}
+
+// tableDelegate renders list items as table rows, a line each.
+type tableDelegate struct {
+	cols []tableColumn
+}
+
+// Height implements list.ItemDelegate.
+func (d tableDelegate) Height() int { return 1 }
+
+// Spacing implements list.ItemDelegate.
+func (d tableDelegate) Spacing() int { return 0 }
+
+// Update implements list.ItemDelegate.
+func (d tableDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+// Render implements list.ItemDelegate. The selected row is marked with ▶,
+// others with their change marker.
+func (d tableDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	cells := make([]string, len(d.cols))
+	for j, c := range d.cols {
+		cells[j] = c.cell(item)
+	}
+	row := tableRow(d.cols, cells)
+
+	if index == m.Index() {
+		fmt.Fprint(w, selectedStyle.Render("▶ "+row))
+		return
+	}
+	gutter := strings.Repeat(" ", tableGutter)
+	if ti, ok := item.(tableItem); ok && ti.changeStatus() != itemUnchanged {
+		gutter = ti.changeStatus().marker()
+	}
+	fmt.Fprint(w, gutter+normalStyle.Render(row))
+}
+
+// formatSize formats a size in bytes compactly, e.g. "512", "4.0K" or
+// "12M", like ls -h.
+func formatSize(n uint32) string {
+	if n < 1024 {
+		return fmt.Sprint(n)
+	}
+	v := float64(n)
+	unit := 0
+	for v >= 1024 && unit < 3 {
+		v /= 1024
+		unit++
+	}
+	suffix := "KMG"[unit-1 : unit]
+	if v < 10 {
+		return fmt.Sprintf("%.1f%s", v, suffix)
+	}
+	return fmt.Sprintf("%.0f%s", v, suffix)
+}
+
+// formatPinned returns "yes" for pinned objects, and "" otherwise.
+func formatPinned(pinned bool) string {
+	if pinned {
+		return "yes"
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatSize(t *testing.T) {
+	tests := map[uint32]string{
+		0:          "0",
+		512:        "512",
+		4096:       "4.0K",
+		12 << 10:   "12K",
+		1536 << 10: "1.5M",
+		40 << 20:   "40M",
+		3 << 30:    "3.0G",
+	}
+	for n, want := range tests {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestTableLayout(t *testing.T) {
+	items := []list.Item{
+		mapItem{info: MapInfo{ID: 1, Name: "conntrack_v4_tcp", Type: "lru_hash", MaxEntries: 524288}},
+		mapItem{info: MapInfo{ID: 22, Name: "events", Type: "ringbuf"}},
+	}
+	table := newListTable(mapColumns, "id", "name", "type", "max")
+
+	cols := table.layout(items, 80)
+	if len(cols) != 4 || cols[1].width != len("conntrack_v4_tcp") || cols[2].width != len("lru_hash") {
+		t.Fatalf("layout() = %+v", cols)
+	}
+	row := tableRow(cols, []string{"1", "conntrack_v4_tcp", "lru_hash", "524288"})
+	if row != " 1  conntrack_v4_tcp  lru_hash  524288" {
+		t.Errorf("row = %q", row)
+	}
+
+	// Narrow terminals drop the last columns and shorten names
+	cols = table.layout(items, 30)
+	if len(cols) != 3 || cols[1].width != 14 {
+		t.Errorf("layout() in 30 cells = %+v", cols)
+	}
+	if got := truncateCell("conntrack_v4_tcp", tableMinFlexWide); got != "conntrack_v…" {
+		t.Errorf("truncateCell() = %q", got)
+	}
+
+	// The process column only shows while grouping by process
+	grouped := mapItem{info: MapInfo{ID: 3, Name: "x"}, byProcess: true, owners: []ProcessInfo{{PID: 1, Comm: "agent"}}}
+	cols = table.layout(append(items, grouped), 80)
+	if len(cols) != 5 || cols[2].name != "process" {
+		t.Errorf("expected a process column, got %+v", cols)
+	}
+}
+
+func TestListTableSetColumns(t *testing.T) {
+	table := newListTable(mapColumns, defaultMapColumns...)
+	if err := table.SetColumns([]string{"name", "memlock"}); err != nil {
+		t.Fatal(err)
+	}
+	if cols := table.layout(nil, 80); len(cols) != 2 || cols[1].name != "memlock" {
+		t.Errorf("expected name and memlock, got %+v", cols)
+	}
+	for _, names := range [][]string{{"name", "bogus"}, {"process"}, nil} {
+		if err := table.SetColumns(names); err == nil {
+			t.Errorf("SetColumns(%q) should fail", names)
+		}
+	}
+}
+
+func TestMapListTable(t *testing.T) {
+	m := newMapListModel(100, 24)
+	m.SetMaps([]MapInfo{
+		{ID: 1, Name: "conntrack", Type: "lru_hash", MemLock: 40 << 20},
+		{ID: 2, Name: "events", Type: "ringbuf", MemLock: 8192},
+	})
+	m.ToggleTable()
+
+	view := m.View()
+	for _, want := range []string{"BPF Maps", "2 maps", "MEMLOCK", "40M", "▶  1  conntrack"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("table should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Type: lru_hash") {
+		t.Error("table rows shouldn't have descriptions")
+	}
+
+	// Hide the memlock column
+	m.ChooseColumns()
+	if !m.IsChoosingColumns() || !strings.Contains(m.View(), "[x] memlock") {
+		t.Fatalf("expected the column chooser:\n%s", m.View())
+	}
+	for range 7 {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsChoosingColumns() || strings.Contains(m.View(), "MEMLOCK") {
+		t.Errorf("memlock should be hidden:\n%s", m.View())
+	}
+
+	// Resizing re-fits the columns
+	m.SetSize(40, 24)
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := len([]rune(line)); w > 40 {
+			t.Errorf("line wider than the terminal: %q", line)
+		}
+	}
+
+	m.ToggleTable()
+	if !strings.Contains(m.View(), "Type: lru_hash") {
+		t.Errorf("expected the two-line list again:\n%s", m.View())
+	}
+}
+
+func TestProgListTableChanges(t *testing.T) {
+	m := newProgListModel(100, 24)
+	m.SetPrograms([]ProgramInfo{{ID: 1, Name: "xdp_prog", Type: "xdp", RunCount: 10, RunTime: 1000}})
+	m.ToggleTable()
+	m.RefreshPrograms([]ProgramInfo{{ID: 2, Name: "tc_prog", Type: "sched_cls"}}, time.Now())
+
+	view := m.View()
+	if !strings.Contains(view, "+  2  tc_prog") {
+		t.Errorf("added programs should be marked:\n%s", view)
+	}
+}
+
+// TestIntegrationMapListTableKeys tests switching the maps list to the table
+// layout and opening the column chooser with t and c.
+func TestIntegrationMapListTableKeys(t *testing.T) {
+	svc := &mockMapsServiceWithDump{maps: []MapInfo{{ID: 1, Name: "x", Type: "hash"}}}
+	m := NewModel(&mockProgService{}, svc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !m.mapList.IsTable() || !containsString(m.View(), "MAX") {
+		t.Fatalf("t should switch to the table layout:\n%s", m.View())
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if !m.mapList.IsChoosingColumns() || !containsString(m.View(), "space: show/hide column") {
+		t.Fatalf("c should open the column chooser:\n%s", m.View())
+	}
+
+	// Sort keys are left alone while choosing columns
+	sort := m.mapList.GetSort()
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if m.mapList.GetSort() != sort {
+		t.Errorf("s and r shouldn't change the sort while choosing columns, got %+v", m.mapList.GetSort())
+	}
+
+	// q and esc close the chooser rather than quitting or going back
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = result.(Model)
+	if cmd != nil {
+		t.Error("q shouldn't quit while choosing columns")
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapList || m.mapList.IsChoosingColumns() {
+		t.Errorf("esc should close the chooser, state %v", m.state)
+	}
+}
@@ -1,9 +1,13 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -17,6 +21,15 @@ const (
 	ViewMapList
 	ViewMapDetail
 	ViewMapDump
+	ViewProgDisasm
+	ViewProgJIT
+	ViewLinkList
+	ViewLinkDetail
+	ViewBTFList
+	ViewBTFTypes
+	ViewBTFType
+	ViewPinTree
+	ViewMapEvents
 )
 
 // String returns a human-readable name for the view state.
@@ -34,6 +47,24 @@ func (v ViewState) String() string {
 		return "Map Detail"
 	case ViewMapDump:
 		return "Map Dump"
+	case ViewProgDisasm:
+		return "Program Disassembly"
+	case ViewProgJIT:
+		return "Program JIT Code"
+	case ViewLinkList:
+		return "Links"
+	case ViewLinkDetail:
+		return "Link Detail"
+	case ViewBTFList:
+		return "BTF Objects"
+	case ViewBTFTypes:
+		return "BTF Types"
+	case ViewBTFType:
+		return "BTF Type"
+	case ViewPinTree:
+		return "Pinned Objects"
+	case ViewMapEvents:
+		return "Map Events"
 	default:
 		return "Unknown"
 	}
@@ -52,6 +83,10 @@ type Model struct {
 	// Services for data access
 	progSvc ProgService
 	mapsSvc MapsService
+	linkSvc LinkService
+	btfSvc  BTFService
+	procSvc ProcessService
+	pinSvc  PinService
 
 	// Sub-models for each view
 	menu       menuModel
@@ -60,6 +95,15 @@ type Model struct {
 	mapList    mapListModel
 	mapDetail  mapDetailModel
 	mapDump    mapDumpModel
+	mapEvents  mapEventsModel
+	progDisasm progDisasmModel
+	progJIT    progJITModel
+	linkList   linkListModel
+	linkDetail linkDetailModel
+	btfList    btfListModel
+	btfTypes   btfTypesModel
+	btfType    btfTypeModel
+	pinTree    pinTreeModel
 
 	// Terminal dimensions
 	width  int
@@ -68,6 +112,22 @@ type Model struct {
 	// Error state
 	err error
 
+	// Whether the kernel is known not to be collecting program run statistics
+	statsOff bool
+
+	// Sequence number of the most recent asynchronous load.
+	// Results carrying an older sequence were cancelled or superseded.
+	loadSeq int
+
+	// How often the program and map lists are re-queried (0 disables)
+	refreshInterval time.Duration
+
+	// Loads the view the configuration starts in, run by Init
+	startCmd tea.Cmd
+
+	// Snapshot the programs, maps and map entries are compared against, or nil
+	baseline *Snapshot
+
 	// Key bindings
 	keys keyMap
 
@@ -88,10 +148,71 @@ func NewModel(progSvc ProgService, mapsSvc MapsService) Model {
 		mapList:    newMapListModel(80, 24),    // Default size, will be updated on WindowSizeMsg
 		mapDetail:  newMapDetailModel(80, 24),  // Default size, will be updated on WindowSizeMsg
 		mapDump:    newMapDumpModel(80, 24),    // Default size, will be updated on WindowSizeMsg
+		mapEvents:  newMapEventsModel(80, 24),  // Default size, will be updated on WindowSizeMsg
+		progDisasm: newProgDisasmModel(80, 24), // Default size, will be updated on WindowSizeMsg
+		progJIT:    newProgJITModel(80, 24),    // Default size, will be updated on WindowSizeMsg
+		linkList:   newLinkListModel(80, 24),   // Default size, will be updated on WindowSizeMsg
+		linkDetail: newLinkDetailModel(80, 24), // Default size, will be updated on WindowSizeMsg
+		btfList:    newBTFListModel(80, 24),    // Default size, will be updated on WindowSizeMsg
+		btfTypes:   newBTFTypesModel(80, 24),   // Default size, will be updated on WindowSizeMsg
+		btfType:    newBTFTypeModel(80, 24),    // Default size, will be updated on WindowSizeMsg
+		pinTree:    newPinTreeModel(80, 24),    // Default size, will be updated on WindowSizeMsg
 		keys:       defaultKeyMap,
 	}
 }
 
+// SetRefreshInterval sets how often the program and map lists are re-queried.
+// A zero or negative interval disables auto-refresh.
+func (m *Model) SetRefreshInterval(interval time.Duration) {
+	m.refreshInterval = interval
+}
+
+// SetLinkService sets the service used by the links views.
+// Without one, the links list is always empty.
+func (m *Model) SetLinkService(svc LinkService) {
+	m.linkSvc = svc
+}
+
+// SetProcessService sets the service used to find the processes holding
+// programs and maps. Without one, the detail views don't show processes and
+// the lists can't be grouped by process.
+func (m *Model) SetProcessService(svc ProcessService) {
+	m.procSvc = svc
+}
+
+// SetSource describes where the programs and maps come from, e.g. a snapshot
+// file, in the menu title. Empty means the live system.
+func (m *Model) SetSource(source string) {
+	m.menu.SetSource(source)
+}
+
+// SetBaseline sets a snapshot to compare against. The lists then mark
+// programs and maps added, removed or changed since the snapshot, and map
+// dumps mark entries the same way. Pass nil to stop comparing.
+func (m *Model) SetBaseline(snap *Snapshot) {
+	m.baseline = snap
+	if snap == nil {
+		m.progList.SetBaseline(nil)
+		m.mapList.SetBaseline(nil)
+		return
+	}
+	// Copy into non-nil slices, as nil means not comparing
+	m.progList.SetBaseline(append([]ProgramInfo{}, snap.Programs...))
+	m.mapList.SetBaseline(append([]MapInfo{}, snap.Maps...))
+}
+
+// SetPinService sets the service used by the pinned objects view.
+// Without one, the tree is always empty.
+func (m *Model) SetPinService(svc PinService) {
+	m.pinSvc = svc
+}
+
+// SetBTFService sets the service used by the BTF views.
+// Without one, the BTF list is always empty.
+func (m *Model) SetBTFService(svc BTFService) {
+	m.btfSvc = svc
+}
+
 // pushState saves the current state to history and transitions to a new state.
 func (m *Model) pushState(newState ViewState) {
 	m.history = append(m.history, m.state)
@@ -142,7 +263,10 @@ func (m Model) Init() tea.Cmd {
 	if err := m.checkPermissions(); err != nil {
 		m.err = err
 	}
-	return nil
+	if m.refreshInterval > 0 {
+		return tea.Batch(m.startCmd, refreshTickCmd(m.refreshInterval))
+	}
+	return m.startCmd
 }
 
 // Update implements tea.Model.
@@ -151,6 +275,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
 
+	case programsLoadedMsg:
+		return m.handleProgramsLoaded(msg)
+
+	case mapsLoadedMsg:
+		return m.handleMapsLoaded(msg)
+
+	case linksLoadedMsg:
+		return m.handleLinksLoaded(msg)
+
+	case programLoadedMsg:
+		return m.handleProgramLoaded(msg)
+
+	case linkLoadedMsg:
+		return m.handleLinkLoaded(msg)
+
+	case pinsLoadedMsg:
+		return m.handlePinsLoaded(msg)
+
+	case processesLoadedMsg:
+		return m.handleProcessesLoaded(msg)
+
+	case btfObjectsLoadedMsg:
+		return m.handleBTFObjectsLoaded(msg)
+
+	case btfTypesLoadedMsg:
+		return m.handleBTFTypesLoaded(msg)
+
+	case btfDefinitionLoadedMsg:
+		return m.handleBTFDefinitionLoaded(msg)
+
+	case mapLoadedMsg:
+		return m.handleMapLoaded(msg)
+
+	case mapDumpLoadedMsg:
+		return m.handleMapDumpLoaded(msg)
+
+	case mapDumpPageMsg:
+		return m.handleMapDumpPage(msg)
+
+	case mapEntryEditedMsg:
+		return m.handleMapEntryEdited(msg)
+
+	case mapTailStartedMsg:
+		return m.handleMapTailStarted(msg)
+
+	case mapEventsMsg:
+		return m, m.mapEvents.AddEvents(msg.reader, msg.events, msg.err)
+
+	case mapEventTypeMsg:
+		return m.handleMapEventType(msg)
+
+	case mapExportedMsg:
+		return m.handleMapExported(msg)
+
+	case mapLookupMsg:
+		return m.handleMapLookup(msg)
+
+	case progXlatedLoadedMsg:
+		return m.handleProgXlatedLoaded(msg)
+
+	case progJITLoadedMsg:
+		return m.handleProgJITLoaded(msg)
+
+	case programRefreshedMsg:
+		return m.handleProgramRefreshed(msg)
+
+	case statsEnabledMsg:
+		return m.handleStatsEnabled(msg)
+
+	case refreshTickMsg:
+		return m.handleRefreshTick()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -160,6 +356,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mapList.SetSize(msg.Width, msg.Height)
 		m.mapDetail.SetSize(msg.Width, msg.Height)
 		m.mapDump.SetSize(msg.Width, msg.Height)
+		m.mapEvents.SetSize(msg.Width, msg.Height)
+		m.progDisasm.SetSize(msg.Width, msg.Height)
+		m.progJIT.SetSize(msg.Width, msg.Height)
+		m.linkList.SetSize(msg.Width, msg.Height)
+		m.linkDetail.SetSize(msg.Width, msg.Height)
+		m.btfList.SetSize(msg.Width, msg.Height)
+		m.btfTypes.SetSize(msg.Width, msg.Height)
+		m.btfType.SetSize(msg.Width, msg.Height)
+		m.pinTree.SetSize(msg.Width, msg.Height)
 		return m, nil
 
 	default:
@@ -177,39 +382,84 @@ func (m Model) handleOtherMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case ViewProgList:
 		m.progList, cmd, _ = m.progList.Update(msg)
+	case ViewProgDetail:
+		// Only spinner ticks are relevant here; keys are handled separately
+		if _, ok := msg.(spinner.TickMsg); ok {
+			m.progDetail, cmd, _ = m.progDetail.Update(msg)
+		}
+	case ViewMapDetail:
+		// Only spinner ticks are relevant here; keys are handled separately
+		if _, ok := msg.(spinner.TickMsg); ok {
+			m.mapDetail, cmd, _ = m.mapDetail.Update(msg)
+		}
 	case ViewMapList:
 		m.mapList, cmd, _ = m.mapList.Update(msg)
 	case ViewMapDump:
-		m.mapDump, cmd = m.mapDump.Update(msg)
+		m.mapDump, cmd, _ = m.mapDump.Update(msg)
+	case ViewMapEvents:
+		m.mapEvents, cmd, _ = m.mapEvents.Update(msg)
+	case ViewProgDisasm:
+		m.progDisasm, cmd, _ = m.progDisasm.Update(msg)
+	case ViewProgJIT:
+		m.progJIT, cmd = m.progJIT.Update(msg)
+	case ViewLinkList:
+		m.linkList, cmd, _ = m.linkList.Update(msg)
+	case ViewBTFList:
+		m.btfList, cmd, _ = m.btfList.Update(msg)
+	case ViewBTFTypes:
+		m.btfTypes, cmd, _ = m.btfTypes.Update(msg)
+	case ViewBTFType:
+		m.btfType, cmd = m.btfType.Update(msg)
+	case ViewLinkDetail:
+		// Only spinner ticks are relevant here; keys are handled separately
+		if _, ok := msg.(spinner.TickMsg); ok {
+			m.linkDetail, cmd, _ = m.linkDetail.Update(msg)
+		}
+	case ViewPinTree:
+		m.pinTree, cmd, _ = m.pinTree.Update(msg)
 	}
 
 	return m, cmd
 }
 
+// isCapturingInput returns true if the active view is reading text input,
+// in which case global keys like q, ? and Esc must be passed through to it.
+func (m Model) isCapturingInput() bool {
+	switch m.state {
+	case ViewProgList:
+		return m.progList.IsFiltering() || m.progList.IsChoosingColumns()
+	case ViewMapList:
+		return m.mapList.IsFiltering() || m.mapList.IsChoosingColumns()
+	case ViewLinkList:
+		return m.linkList.IsFiltering()
+	case ViewBTFList:
+		return m.btfList.IsFiltering()
+	case ViewBTFTypes:
+		return m.btfTypes.IsSearching()
+	case ViewMapDetail:
+		return m.mapDetail.IsPrompting() || m.mapDetail.IsConfirmingTail()
+	case ViewMapDump:
+		return m.mapDump.IsEditing()
+	case ViewMapEvents:
+		return m.mapEvents.IsPrompting()
+	}
+	return false
+}
+
 // handleKeyMsg processes keyboard input.
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle quit from any view (but not when filtering)
-	if key.Matches(msg, m.keys.Quit) {
-		// Don't quit if we're in the middle of filtering
-		if m.state == ViewProgList && m.progList.IsFiltering() {
-			// Let the list handle it
-		} else if m.state == ViewMapList && m.mapList.IsFiltering() {
-			// Let the list handle it
-		} else {
-			return m, tea.Quit
-		}
+	// Handle quit from any view (but not when typing)
+	if key.Matches(msg, m.keys.Quit) && !m.isCapturingInput() {
+		// Remove bpftui's buffers from perf event arrays; the owner's
+		// buffers aren't restored
+		m.mapEvents.Stop()
+		return m, tea.Quit
 	}
 
-	// Handle help toggle (but not when filtering)
-	if key.Matches(msg, m.keys.Help) {
-		if m.state == ViewProgList && m.progList.IsFiltering() {
-			// Let the list handle it
-		} else if m.state == ViewMapList && m.mapList.IsFiltering() {
-			// Let the list handle it
-		} else {
-			m.showHelp = !m.showHelp
-			return m, nil
-		}
+	// Handle help toggle (but not when typing)
+	if key.Matches(msg, m.keys.Help) && !m.isCapturingInput() {
+		m.showHelp = !m.showHelp
+		return m, nil
 	}
 
 	// If help is showing, any key closes it
@@ -218,17 +468,40 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Handle back navigation (but not when filtering)
+	// Handle back navigation (but not when typing)
 	if key.Matches(msg, m.keys.Back) {
-		// Don't navigate back if we're filtering - let the list handle escape
-		if m.state == ViewProgList && m.progList.IsFiltering() {
-			return m.handleProgListKeys(msg)
-		}
-		if m.state == ViewMapList && m.mapList.IsFiltering() {
-			return m.handleMapListKeys(msg)
+		// Don't navigate back while typing - let the view handle escape
+		// (cancel the search or close the editor)
+		if m.isCapturingInput() {
+			switch m.state {
+			case ViewProgList:
+				return m.handleProgListKeys(msg)
+			case ViewMapList:
+				return m.handleMapListKeys(msg)
+			case ViewLinkList:
+				return m.handleLinkListKeys(msg)
+			case ViewBTFList:
+				return m.handleBTFListKeys(msg)
+			case ViewBTFTypes:
+				return m.handleBTFTypesKeys(msg)
+			case ViewMapDetail:
+				return m.handleMapDetailKeys(msg)
+			case ViewMapDump:
+				return m.handleMapDumpKeys(msg)
+			case ViewMapEvents:
+				return m.handleMapEventsKeys(msg)
+			}
 		}
 
 		if m.state != ViewMenu {
+			// Leaving a view abandons whatever it was loading
+			m.cancelLoad()
+			switch m.state {
+			case ViewMapDump:
+				m.mapDump.StopPaging()
+			case ViewMapEvents:
+				m.mapEvents.Stop()
+			}
 			m.state = m.popState()
 			m.err = nil // Clear any errors when navigating back
 		}
@@ -249,6 +522,24 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleMapDetailKeys(msg)
 	case ViewMapDump:
 		return m.handleMapDumpKeys(msg)
+	case ViewMapEvents:
+		return m.handleMapEventsKeys(msg)
+	case ViewProgDisasm:
+		return m.handleProgDisasmKeys(msg)
+	case ViewProgJIT:
+		return m.handleProgJITKeys(msg)
+	case ViewLinkList:
+		return m.handleLinkListKeys(msg)
+	case ViewLinkDetail:
+		return m.handleLinkDetailKeys(msg)
+	case ViewBTFList:
+		return m.handleBTFListKeys(msg)
+	case ViewBTFTypes:
+		return m.handleBTFTypesKeys(msg)
+	case ViewBTFType:
+		return m.handleBTFTypeKeys(msg)
+	case ViewPinTree:
+		return m.handlePinTreeKeys(msg)
 	}
 
 	return m, nil
@@ -262,30 +553,78 @@ func (m Model) handleMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// If a menu item was selected, navigate to that view
 	if targetView != nil {
-		m.pushState(*targetView)
-
-		// Load data for the target view
-		switch *targetView {
-		case ViewProgList:
-			m.loadPrograms()
-		case ViewMapList:
-			m.loadMaps()
-		}
+		return m, tea.Batch(cmd, m.openView(*targetView))
 	}
 
 	return m, cmd
 }
 
+// openView navigates to one of the views listed in the menu and returns the
+// command loading its data.
+func (m *Model) openView(view ViewState) tea.Cmd {
+	m.pushState(view)
+	switch view {
+	case ViewProgList:
+		return m.loadPrograms()
+	case ViewMapList:
+		return m.loadMaps()
+	case ViewLinkList:
+		return m.loadLinks()
+	case ViewBTFList:
+		return m.loadBTFObjects()
+	case ViewPinTree:
+		return m.loadPins()
+	}
+	return nil
+}
+
 // handleProgListKeys handles keyboard input in the programs list view.
 func (m Model) handleProgListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var selectedProg *ProgramInfo
+	if key.Matches(msg, m.keys.Stats) && !m.isCapturingInput() {
+		return m, m.enableStats()
+	}
+
+	// Switch layouts or choose the table's columns
+	if key.Matches(msg, m.keys.Table) && !m.isCapturingInput() {
+		m.progList.ToggleTable()
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Columns) && !m.isCapturingInput() {
+		m.progList.ChooseColumns()
+		return m, nil
+	}
+
+	// Sort by another column or flip the direction
+	if key.Matches(msg, m.keys.Sort) && !m.isCapturingInput() {
+		return m, m.progList.CycleSort()
+	}
+	if key.Matches(msg, m.keys.Order) && !m.isCapturingInput() {
+		return m, m.progList.ReverseSort()
+	}
+
+	// Toggle grouping by owning process
+	if key.Matches(msg, m.keys.Owners) && !m.isCapturingInput() {
+		if m.procSvc == nil {
+			return m, nil
+		}
+		byProcess := !m.progList.IsByProcess()
+		cmd = m.progList.SetByProcess(byProcess)
+		if byProcess {
+			return m, tea.Batch(cmd, listProcessesCmd(m.procSvc))
+		}
+		return m, cmd
+	}
+
 	m.progList, cmd, selectedProg = m.progList.Update(msg)
 
 	// If a program was selected, navigate to detail view
 	if selectedProg != nil {
 		m.pushState(ViewProgDetail)
 		m.progDetail.SetProgram(selectedProg)
+		m.progDetail.SetStatsOff(m.statsOff)
+		return m, tea.Batch(cmd, m.loadProcesses())
 	}
 
 	return m, cmd
@@ -293,6 +632,40 @@ func (m Model) handleProgListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleProgDetailKeys handles keyboard input in the program detail view.
 func (m Model) handleProgDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Open the disassembly of the displayed program
+	if key.Matches(msg, m.keys.Disasm) {
+		if prog := m.progDetail.GetProgram(); prog != nil {
+			m.pushState(ViewProgDisasm)
+			loadCmd := m.loadXlated(prog.ID, prog.Name)
+			return m, loadCmd
+		}
+		return m, nil
+	}
+
+	// Open the JIT-compiled native code of the displayed program
+	if key.Matches(msg, m.keys.JIT) {
+		if prog := m.progDetail.GetProgram(); prog != nil {
+			m.pushState(ViewProgJIT)
+			loadCmd := m.loadJITed(prog.ID, prog.Name)
+			return m, loadCmd
+		}
+		return m, nil
+	}
+
+	// Browse the functions of the displayed program
+	if key.Matches(msg, m.keys.BTF) {
+		if prog := m.progDetail.GetProgram(); prog != nil {
+			m.pushState(ViewBTFTypes)
+			loadCmd := m.loadProgFuncs(prog.ID, prog.Name)
+			return m, loadCmd
+		}
+		return m, nil
+	}
+
+	if key.Matches(msg, m.keys.Stats) {
+		return m, m.enableStats()
+	}
+
 	var cmd tea.Cmd
 	var selectedMapID *uint32
 	m.progDetail, cmd, selectedMapID = m.progDetail.Update(msg)
@@ -300,7 +673,8 @@ func (m Model) handleProgDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// If a map was selected, navigate to map detail view
 	if selectedMapID != nil {
 		m.pushState(ViewMapDetail)
-		m.loadMapByID(*selectedMapID)
+		loadCmd := m.loadMapByID(*selectedMapID)
+		return m, tea.Batch(cmd, loadCmd)
 	}
 
 	return m, cmd
@@ -310,112 +684,894 @@ func (m Model) handleProgDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) handleMapListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var selectedMap *MapInfo
+
+	// Switch layouts or choose the table's columns
+	if key.Matches(msg, m.keys.Table) && !m.isCapturingInput() {
+		m.mapList.ToggleTable()
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Columns) && !m.isCapturingInput() {
+		m.mapList.ChooseColumns()
+		return m, nil
+	}
+
+	// Sort by another column or flip the direction
+	if key.Matches(msg, m.keys.Sort) && !m.isCapturingInput() {
+		return m, m.mapList.CycleSort()
+	}
+	if key.Matches(msg, m.keys.Order) && !m.isCapturingInput() {
+		return m, m.mapList.ReverseSort()
+	}
+
+	// Toggle grouping by owning process
+	if key.Matches(msg, m.keys.Owners) && !m.isCapturingInput() {
+		if m.procSvc == nil {
+			return m, nil
+		}
+		byProcess := !m.mapList.IsByProcess()
+		cmd = m.mapList.SetByProcess(byProcess)
+		if byProcess {
+			return m, tea.Batch(cmd, listProcessesCmd(m.procSvc))
+		}
+		return m, cmd
+	}
+
 	m.mapList, cmd, selectedMap = m.mapList.Update(msg)
 
 	// If a map was selected, navigate to detail view
 	if selectedMap != nil {
 		m.pushState(ViewMapDetail)
 		m.mapDetail.SetMap(selectedMap)
+		return m, tea.Batch(cmd, m.loadProcesses())
 	}
 
 	return m, cmd
 }
 
+// handleLinkListKeys handles keyboard input in the links list view.
+func (m Model) handleLinkListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selectedLink *LinkInfo
+	m.linkList, cmd, selectedLink = m.linkList.Update(msg)
+
+	// If a link was selected, navigate to detail view
+	if selectedLink != nil {
+		m.pushState(ViewLinkDetail)
+		m.linkDetail.SetLink(selectedLink)
+	}
+
+	return m, cmd
+}
+
+// handleLinkDetailKeys handles keyboard input in the link detail view.
+func (m Model) handleLinkDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selectedProgID *uint32
+	m.linkDetail, cmd, selectedProgID = m.linkDetail.Update(msg)
+
+	// If the attached program was selected, navigate to program detail view
+	if selectedProgID != nil {
+		m.pushState(ViewProgDetail)
+		loadCmd := m.loadProgramByID(*selectedProgID)
+		return m, tea.Batch(cmd, loadCmd)
+	}
+
+	return m, cmd
+}
+
+// handlePinTreeKeys handles keyboard input in the pinned objects view.
+func (m Model) handlePinTreeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selected *PinInfo
+	m.pinTree, cmd, selected = m.pinTree.Update(msg)
+	if selected == nil {
+		return m, cmd
+	}
+
+	// Navigate to the pinned object's detail view
+	var loadCmd tea.Cmd
+	switch selected.Kind {
+	case "prog":
+		m.pushState(ViewProgDetail)
+		loadCmd = m.loadProgramByID(selected.ID)
+	case "map":
+		m.pushState(ViewMapDetail)
+		loadCmd = m.loadMapByID(selected.ID)
+	case "link":
+		m.pushState(ViewLinkDetail)
+		loadCmd = m.loadLinkByID(selected.ID)
+	}
+	return m, tea.Batch(cmd, loadCmd)
+}
+
+// handleBTFListKeys handles keyboard input in the BTF objects list view.
+func (m Model) handleBTFListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selected *BTFObjectInfo
+	m.btfList, cmd, selected = m.btfList.Update(msg)
+
+	// If an object was selected, browse its types
+	if selected != nil {
+		m.pushState(ViewBTFTypes)
+		loadCmd := m.loadBTFTypes(*selected)
+		return m, tea.Batch(cmd, loadCmd)
+	}
+
+	return m, cmd
+}
+
+// handleBTFTypesKeys handles keyboard input in the BTF type browser.
+func (m Model) handleBTFTypesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selected *BTFTypeInfo
+	m.btfTypes, cmd, selected = m.btfTypes.Update(msg)
+
+	// If a type was selected, show its definition
+	if selected != nil {
+		m.pushState(ViewBTFType)
+		loadCmd := m.loadBTFDefinition(*selected)
+		return m, tea.Batch(cmd, loadCmd)
+	}
+
+	return m, cmd
+}
+
+// handleBTFTypeKeys handles keyboard input in the BTF type definition view.
+func (m Model) handleBTFTypeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.btfType, cmd = m.btfType.Update(msg)
+	return m, cmd
+}
+
 // handleMapDetailKeys handles keyboard input in the map detail view.
 func (m Model) handleMapDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	var dumpSelected bool
-	m.mapDetail, cmd, dumpSelected = m.mapDetail.Update(msg)
+	var req *mapDetailRequest
+	m.mapDetail, cmd, req = m.mapDetail.Update(msg)
+	if req == nil {
+		return m, cmd
+	}
 
-	// If Dump was selected, navigate to map dump view
-	if dumpSelected {
+	switch req.action {
+	case mapActionDump:
+		// Navigate to map dump view
 		m.pushState(ViewMapDump)
-		m.loadMapDump(m.mapDetail.GetMapID())
+		loadCmd := m.loadMapDump(m.mapDetail.GetMapID())
+		return m, tea.Batch(cmd, loadCmd)
+
+	case mapActionTail:
+		m.pushState(ViewMapEvents)
+		loadCmd := m.loadMapEvents(m.mapDetail.GetMapID())
+		return m, tea.Batch(cmd, loadCmd)
+
+	case mapActionLookup:
+		mapInfo := m.mapDetail.GetMapInfo()
+		if m.mapsSvc == nil || mapInfo == nil {
+			return m, cmd
+		}
+		seq := m.nextLoadSeq()
+		startCmd := m.mapDetail.StartLookup()
+		return m, tea.Batch(cmd, startCmd, lookupMapCmd(m.mapsSvc, seq, *mapInfo, req.key))
+
+	case mapActionBTF:
+		mapInfo := m.mapDetail.GetMapInfo()
+		if mapInfo == nil {
+			return m, cmd
+		}
+		m.pushState(ViewBTFTypes)
+		loadCmd := m.loadMapTypes(mapInfo.ID, mapInfo.Name)
+		return m, tea.Batch(cmd, loadCmd)
+	}
+
+	return m, cmd
+}
+
+// handleProgDisasmKeys handles keyboard input in the disassembly view.
+func (m Model) handleProgDisasmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var selectedMapID *uint32
+	m.progDisasm, cmd, selectedMapID = m.progDisasm.Update(msg)
+
+	// If a map reference was followed, navigate to map detail view
+	if selectedMapID != nil {
+		m.pushState(ViewMapDetail)
+		loadCmd := m.loadMapByID(*selectedMapID)
+		return m, tea.Batch(cmd, loadCmd)
 	}
 
 	return m, cmd
 }
 
+// handleProgJITKeys handles keyboard input in the JIT view.
+func (m Model) handleProgJITKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.progJIT, cmd = m.progJIT.Update(msg)
+	return m, cmd
+}
+
 // handleMapDumpKeys handles keyboard input in the map dump view.
 func (m Model) handleMapDumpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	m.mapDump, cmd = m.mapDump.Update(msg)
+	var edit *mapEdit
+	m.mapDump, cmd, edit = m.mapDump.Update(msg)
+
+	// If an edit was confirmed, apply it to the map
+	if edit != nil && m.mapsSvc != nil {
+		seq := m.nextLoadSeq()
+		return m, tea.Batch(cmd, editMapEntryCmd(m.mapsSvc, seq, m.mapDump.GetMapID(), *edit))
+	}
+
 	return m, cmd
 }
 
-// loadPrograms fetches programs from the service and updates the list.
-func (m *Model) loadPrograms() {
+// handleMapEventsKeys handles keyboard input in the map events view.
+func (m Model) handleMapEventsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var typeName *string
+	m.mapEvents, cmd, typeName = m.mapEvents.Update(msg)
+
+	// Look up the type to decode records with
+	if typeName != nil && m.mapsSvc != nil {
+		seq := m.nextLoadSeq()
+		return m, tea.Batch(cmd, eventTypeCmd(m.mapsSvc, seq, m.mapEvents.GetMapID(), *typeName))
+	}
+
+	return m, cmd
+}
+
+// nextLoadSeq starts a new asynchronous load, superseding any in flight.
+func (m *Model) nextLoadSeq() int {
+	m.loadSeq++
+	return m.loadSeq
+}
+
+// cancelLoad abandons any in-flight load and clears the loading state of all views.
+// The service call itself cannot be interrupted; its result is discarded on arrival.
+func (m *Model) cancelLoad() {
+	m.loadSeq++
+	m.progList.SetLoading(false)
+	m.mapList.SetLoading(false)
+	m.mapDetail.SetLoading(false)
+	m.mapDump.SetLoading(false)
+	m.progDisasm.SetLoading(false)
+	m.progJIT.SetLoading(false)
+	m.linkList.SetLoading(false)
+	m.progDetail.SetLoading(false)
+	m.btfList.SetLoading(false)
+	m.btfTypes.SetLoading(false)
+	m.btfType.SetLoading(false)
+	m.linkDetail.SetLoading(false)
+	m.pinTree.SetLoading(false)
+}
+
+// loadPrograms starts fetching programs from the service.
+func (m *Model) loadPrograms() tea.Cmd {
 	// Reset any existing filter when entering the list
 	m.progList.ResetFilter()
 
 	if m.progSvc == nil {
 		m.progList.SetPrograms([]ProgramInfo{})
-		return
+		return nil
 	}
 
-	programs, err := m.progSvc.List()
-	if err != nil {
-		m.progList.SetError(err)
-		return
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.progList.SetLoading(true), listProgramsCmd(m.progSvc, seq))
+}
+
+// handleProgramsLoaded updates the programs list with a completed load.
+func (m Model) handleProgramsLoaded(msg programsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
 	}
 
-	m.progList.SetPrograms(programs)
+	if msg.err != nil {
+		m.progList.SetError(msg.err)
+		return m, nil
+	}
+
+	m.setStatsOff(msg.statsOff)
+	if msg.refresh {
+		return m, m.progList.RefreshPrograms(msg.programs, msg.at)
+	}
+	m.progList.SetPrograms(msg.programs)
+	return m, nil
 }
 
-// loadMaps fetches maps from the service and updates the list.
-func (m *Model) loadMaps() {
+// loadMaps starts fetching maps from the service.
+func (m *Model) loadMaps() tea.Cmd {
 	// Reset any existing filter when entering the list
 	m.mapList.ResetFilter()
 
 	if m.mapsSvc == nil {
 		m.mapList.SetMaps([]MapInfo{})
-		return
+		return nil
 	}
 
-	maps, err := m.mapsSvc.List()
-	if err != nil {
-		m.mapList.SetError(err)
-		return
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.mapList.SetLoading(true), listMapsCmd(m.mapsSvc, seq))
+}
+
+// handleMapsLoaded updates the maps list with a completed load.
+func (m Model) handleMapsLoaded(msg mapsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.mapList.SetError(msg.err)
+		return m, nil
+	}
+
+	if msg.refresh {
+		return m, m.mapList.RefreshMaps(msg.maps)
+	}
+	m.mapList.SetMaps(msg.maps)
+	return m, nil
+}
+
+// loadLinks starts fetching links from the service.
+func (m *Model) loadLinks() tea.Cmd {
+	// Reset any existing filter when entering the list
+	m.linkList.ResetFilter()
+
+	if m.linkSvc == nil {
+		m.linkList.SetLinks([]LinkInfo{})
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.linkList.SetLoading(true), listLinksCmd(m.linkSvc, seq))
+}
+
+// handleLinksLoaded updates the links list with a completed load.
+func (m Model) handleLinksLoaded(msg linksLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.linkList.SetError(msg.err)
+		return m, nil
+	}
+
+	if msg.refresh {
+		return m, m.linkList.RefreshLinks(msg.links)
+	}
+	m.linkList.SetLinks(msg.links)
+	return m, nil
+}
+
+// loadPins starts walking the mounted bpffs instances.
+func (m *Model) loadPins() tea.Cmd {
+	if m.pinSvc == nil {
+		m.pinTree.SetPins(nil)
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.pinTree.SetLoading(true), listPinsCmd(m.pinSvc, seq))
+}
+
+// handlePinsLoaded updates the pinned objects view with a completed load.
+func (m Model) handlePinsLoaded(msg pinsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.pinTree.SetError(msg.err)
+		return m, nil
+	}
+
+	m.pinTree.SetPins(msg.pins)
+	return m, nil
+}
+
+// loadLinkByID starts fetching a specific link by ID for the link detail view.
+func (m *Model) loadLinkByID(id uint32) tea.Cmd {
+	if m.linkSvc == nil {
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.linkDetail.SetLoading(true), getLinkCmd(m.linkSvc, seq, id))
+}
+
+// handleLinkLoaded sets a completed link lookup in the link detail view.
+func (m Model) handleLinkLoaded(msg linkLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.linkDetail.SetLoading(false)
+		m.err = msg.err
+		return m, nil
+	}
+
+	m.linkDetail.SetLink(msg.link)
+	return m, nil
+}
+
+// loadProcesses starts scanning for the processes holding programs and maps,
+// if there's a service to do so.
+func (m *Model) loadProcesses() tea.Cmd {
+	if m.procSvc == nil {
+		return nil
+	}
+	return listProcessesCmd(m.procSvc)
+}
+
+// handleProcessesLoaded shows a completed process scan in the detail views
+// and the lists. The lists keep their previous grouping on error.
+func (m Model) handleProcessesLoaded(msg processesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.progDetail.SetProcesses(msg.procs, msg.err)
+	m.mapDetail.SetProcesses(msg.procs, msg.err)
+	if msg.err != nil {
+		return m, nil
+	}
+	return m, tea.Batch(m.progList.SetProcesses(msg.procs), m.mapList.SetProcesses(msg.procs))
+}
+
+// loadBTFObjects starts fetching BTF objects from the service.
+func (m *Model) loadBTFObjects() tea.Cmd {
+	// Reset any existing filter when entering the list
+	m.btfList.ResetFilter()
+
+	if m.btfSvc == nil {
+		m.btfList.SetObjects([]BTFObjectInfo{})
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.btfList.SetLoading(true), listBTFCmd(m.btfSvc, seq))
+}
+
+// handleBTFObjectsLoaded updates the BTF objects list with a completed load.
+func (m Model) handleBTFObjectsLoaded(msg btfObjectsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.btfList.SetError(msg.err)
+		return m, nil
+	}
+
+	m.btfList.SetObjects(msg.objects)
+	return m, nil
+}
+
+// loadBTFTypes starts fetching the types of a BTF object for the type browser.
+func (m *Model) loadBTFTypes(obj BTFObjectInfo) tea.Cmd {
+	title := fmt.Sprintf("BTF: %s (ID: %d)", btfItem{info: obj}.displayName(), obj.ID)
+	if m.btfSvc == nil {
+		return m.startBTFTypes(title, obj.ID, nil)
+	}
+	return m.startBTFTypes(title, obj.ID, m.btfSvc.Types)
+}
+
+// loadMapTypes starts fetching a map's key and value types for the type browser.
+func (m *Model) loadMapTypes(id uint32, name string) tea.Cmd {
+	title := fmt.Sprintf("Map BTF: %s (ID: %d)", name, id)
+	if m.btfSvc == nil {
+		return m.startBTFTypes(title, id, nil)
+	}
+	return m.startBTFTypes(title, id, m.btfSvc.MapTypes)
+}
+
+// loadProgFuncs starts fetching a program's functions for the type browser.
+func (m *Model) loadProgFuncs(id uint32, name string) tea.Cmd {
+	title := fmt.Sprintf("Program BTF: %s (ID: %d)", name, id)
+	if m.btfSvc == nil {
+		return m.startBTFTypes(title, id, nil)
+	}
+	return m.startBTFTypes(title, id, m.btfSvc.ProgFuncs)
+}
+
+// startBTFTypes starts loading the type browser with fetch(id).
+// Without a fetch function, the browser is left empty.
+func (m *Model) startBTFTypes(title string, id uint32, fetch func(uint32) ([]BTFTypeInfo, error)) tea.Cmd {
+	startCmd := m.btfTypes.StartLoading(title)
+	if fetch == nil {
+		m.btfTypes.SetTypes(nil)
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(startCmd, btfTypesCmd(seq, id, fetch))
+}
+
+// handleBTFTypesLoaded sets completed types in the type browser.
+func (m Model) handleBTFTypesLoaded(msg btfTypesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.btfTypes.SetError(msg.err)
+		return m, nil
+	}
+
+	m.btfTypes.SetTypes(msg.types)
+	return m, nil
+}
+
+// loadBTFDefinition starts rendering a type's definition.
+func (m *Model) loadBTFDefinition(info BTFTypeInfo) tea.Cmd {
+	startCmd := m.btfType.StartLoading(info)
+	if m.btfSvc == nil {
+		m.btfType.SetDefinition("")
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(startCmd, btfDefinitionCmd(m.btfSvc, seq, info))
+}
+
+// handleBTFDefinitionLoaded sets a completed definition in the definition view.
+func (m Model) handleBTFDefinitionLoaded(msg btfDefinitionLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.btfType.SetError(msg.err)
+		return m, nil
+	}
+
+	m.btfType.SetDefinition(msg.def)
+	return m, nil
+}
+
+// handleRefreshTick re-queries the visible list, if any, and schedules the next tick.
+func (m Model) handleRefreshTick() (tea.Model, tea.Cmd) {
+	if m.refreshInterval <= 0 {
+		return m, nil
+	}
+	next := refreshTickCmd(m.refreshInterval)
+
+	switch m.state {
+	case ViewProgList:
+		// Don't race the initial load
+		if m.progSvc != nil && !m.progList.IsLoading() {
+			seq := m.nextLoadSeq()
+			var ownersCmd tea.Cmd
+			if m.progList.IsByProcess() {
+				ownersCmd = m.loadProcesses()
+			}
+			return m, tea.Batch(next, refreshProgramsCmd(m.progSvc, seq), ownersCmd)
+		}
+	case ViewProgDetail:
+		// Don't race a load by ID
+		if prog := m.progDetail.GetProgram(); m.progSvc != nil && prog != nil && !m.progDetail.IsLoading() {
+			seq := m.nextLoadSeq()
+			return m, tea.Batch(next, refreshProgramCmd(m.progSvc, seq, prog.ID))
+		}
+	case ViewMapList:
+		if m.mapsSvc != nil && !m.mapList.IsLoading() {
+			seq := m.nextLoadSeq()
+			var ownersCmd tea.Cmd
+			if m.mapList.IsByProcess() {
+				ownersCmd = m.loadProcesses()
+			}
+			return m, tea.Batch(next, refreshMapsCmd(m.mapsSvc, seq), ownersCmd)
+		}
+	case ViewLinkList:
+		if m.linkSvc != nil && !m.linkList.IsLoading() {
+			seq := m.nextLoadSeq()
+			return m, tea.Batch(next, refreshLinksCmd(m.linkSvc, seq))
+		}
+	}
+
+	return m, next
+}
+
+// handleProgramRefreshed updates the program detail view with a periodic refresh.
+// Errors, such as the program having been unloaded, keep the last data shown.
+func (m Model) handleProgramRefreshed(msg programRefreshedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq || msg.err != nil {
+		return m, nil
+	}
+
+	m.setStatsOff(msg.statsOff)
+	m.progDetail.RefreshProgram(msg.program, msg.at)
+	return m, nil
+}
+
+// setStatsOff records whether run statistics are disabled and updates the
+// prompts in the program views.
+func (m *Model) setStatsOff(off bool) {
+	m.statsOff = off
+	m.progList.SetStatsOff(off)
+	m.progDetail.SetStatsOff(off)
+}
+
+// enableStats starts turning on run statistics, if they are off and the
+// service can control them.
+func (m *Model) enableStats() tea.Cmd {
+	statsSvc, ok := m.progSvc.(ProgStatsService)
+	if !m.statsOff || !ok {
+		return nil
+	}
+	return enableStatsCmd(statsSvc)
+}
+
+// handleStatsEnabled hides the stats prompt once statistics are on, or shows
+// why they couldn't be enabled. Counters fill in from the next refresh.
+func (m Model) handleStatsEnabled(msg statsEnabledMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.progList.SetStatsError(msg.err)
+		m.progDetail.SetStatsError(msg.err)
+		return m, nil
+	}
+
+	m.setStatsOff(false)
+	return m, nil
+}
+
+// loadXlated starts fetching a program's xlated instructions for the disassembly view.
+func (m *Model) loadXlated(id uint32, name string) tea.Cmd {
+	if m.progSvc == nil {
+		m.progDisasm.StartLoading(id, name)
+		m.progDisasm.SetInstructions(nil)
+		return nil
 	}
 
-	m.mapList.SetMaps(maps)
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.progDisasm.StartLoading(id, name), xlatedCmd(m.progSvc, seq, id))
 }
 
-// loadMapByID fetches a specific map by ID and sets it in the map detail view.
-func (m *Model) loadMapByID(id uint32) {
+// handleProgXlatedLoaded sets completed instructions in the disassembly view.
+func (m Model) handleProgXlatedLoaded(msg progXlatedLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.progDisasm.SetError(msg.err)
+		return m, nil
+	}
+
+	m.progDisasm.SetInstructions(msg.insns)
+	return m, nil
+}
+
+// loadJITed starts fetching a program's JIT image for the JIT view.
+func (m *Model) loadJITed(id uint32, name string) tea.Cmd {
+	if m.progSvc == nil {
+		m.progJIT.StartLoading(id, name)
+		m.progJIT.SetImage(nil)
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.progJIT.StartLoading(id, name), jitedCmd(m.progSvc, seq, id))
+}
+
+// handleProgJITLoaded disassembles a completed JIT image into the JIT view.
+func (m Model) handleProgJITLoaded(msg progJITLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.progJIT.SetError(msg.err)
+		return m, nil
+	}
+
+	m.progJIT.SetImage(msg.image)
+	return m, nil
+}
+
+// loadProgramByID starts fetching a specific program by ID for the program detail view.
+func (m *Model) loadProgramByID(id uint32) tea.Cmd {
+	if m.progSvc == nil {
+		return nil
+	}
+
+	m.progDetail.SetStatsOff(m.statsOff)
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.progDetail.SetLoading(true), getProgramCmd(m.progSvc, seq, id))
+}
+
+// handleProgramLoaded sets a completed program lookup in the program detail view.
+func (m Model) handleProgramLoaded(msg programLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.progDetail.SetLoading(false)
+		m.err = msg.err
+		return m, nil
+	}
+
+	m.setStatsOff(msg.statsOff)
+	m.progDetail.SetProgram(msg.program)
+	return m, m.loadProcesses()
+}
+
+// loadMapByID starts fetching a specific map by ID for the map detail view.
+func (m *Model) loadMapByID(id uint32) tea.Cmd {
 	if m.mapsSvc == nil {
-		return
+		return nil
 	}
 
-	mapInfo, err := m.mapsSvc.Get(id)
-	if err != nil {
-		m.err = err
-		return
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.mapDetail.SetLoading(true), getMapCmd(m.mapsSvc, seq, id))
+}
+
+// handleMapLoaded sets a completed map lookup in the map detail view.
+func (m Model) handleMapLoaded(msg mapLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.mapDetail.SetLoading(false)
+		m.err = msg.err
+		return m, nil
+	}
+
+	m.mapDetail.SetMap(msg.mapInfo)
+	return m, m.loadProcesses()
+}
+
+// handleMapLookup shows a completed key lookup in the map detail view.
+func (m Model) handleMapLookup(msg mapLookupMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
 	}
 
-	m.mapDetail.SetMap(mapInfo)
+	m.mapDetail.SetLookupResult(msg.result)
+	return m, nil
 }
 
-// loadMapDump fetches map entries and sets them in the map dump view.
-func (m *Model) loadMapDump(id uint32) {
+// loadMapDump starts fetching map entries for the map dump view.
+func (m *Model) loadMapDump(id uint32) tea.Cmd {
 	if m.mapsSvc == nil {
 		m.mapDump.SetMapDump(id, "", []MapEntry{})
-		return
+		return nil
 	}
 
-	// Get map name from current map detail if available
+	// Get map name and entry sizes from current map detail if available
 	mapName := ""
 	if mapInfo := m.mapDetail.GetMapInfo(); mapInfo != nil && mapInfo.ID == id {
 		mapName = mapInfo.Name
+		m.mapDump.SetEntrySizes(mapInfo.KeySize, mapInfo.ValueSize)
+		m.mapDump.SetPerCPU(isPerCPUMapType(mapInfo.Type))
+	} else {
+		m.mapDump.SetEntrySizes(0, 0)
+		m.mapDump.SetPerCPU(false)
 	}
 
-	entries, err := m.mapsSvc.Dump(id)
-	if err != nil {
-		m.mapDump.SetError(err)
-		return
+	seq := m.nextLoadSeq()
+	return tea.Batch(m.mapDump.StartLoading(id, mapName), dumpMapCmd(m.mapsSvc, seq, id, dumpPageSize))
+}
+
+// handleMapDumpLoaded sets completed map entries in the map dump view.
+func (m Model) handleMapDumpLoaded(msg mapDumpLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		if msg.iter != nil {
+			msg.iter.Close()
+		}
+		return m, nil
 	}
 
-	m.mapDump.SetMapDump(id, mapName, entries)
+	if msg.err != nil {
+		m.mapDump.StopPaging()
+		m.mapDump.SetError(msg.err)
+		return m, nil
+	}
+
+	svc := m.mapsSvc
+	m.mapDump.SetMapDump(msg.mapID, m.mapDump.mapName, msg.entries)
+	m.mapDump.SetRemaining(msg.iter, func() (MapIterator, error) { return iterateMap(svc, msg.mapID) })
+	m.mapDump.SetBTF(msg.btf)
+	if m.baseline != nil {
+		m.mapDump.SetBaseline(m.baselineEntries(msg.mapID))
+	}
+	return m, nil
+}
+
+// baselineEntries returns the baseline entries of the map with the given ID,
+// or nil if they aren't known.
+func (m Model) baselineEntries(id uint32) []MapEntry {
+	var info *MapInfo
+	if detail := m.mapDetail.GetMapInfo(); detail != nil && detail.ID == id {
+		info = detail
+	} else if i := slices.IndexFunc(m.mapList.maps, func(mi MapInfo) bool { return mi.ID == id }); i >= 0 {
+		info = &m.mapList.maps[i]
+	}
+	if info == nil {
+		return nil
+	}
+	return baselineEntries(m.baseline, m.mapList.maps, *info)
+}
+
+// loadMapEvents starts attaching a reader to a ring buffer or perf event
+// array for the map events view.
+func (m *Model) loadMapEvents(id uint32) tea.Cmd {
+	mapName := ""
+	if mapInfo := m.mapDetail.GetMapInfo(); mapInfo != nil && mapInfo.ID == id {
+		mapName = mapInfo.Name
+	}
+	startCmd := m.mapEvents.StartLoading(id, mapName)
+	if m.mapsSvc == nil {
+		m.mapEvents.SetError(errors.New("no maps service"))
+		return nil
+	}
+
+	seq := m.nextLoadSeq()
+	return tea.Batch(startCmd, tailMapCmd(m.mapsSvc, seq, id))
+}
+
+// handleMapTailStarted starts reading records once the reader is attached.
+// A reader attached after the user left the view is closed right away.
+func (m Model) handleMapTailStarted(msg mapTailStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		if msg.reader != nil {
+			msg.reader.Close()
+		}
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.mapEvents.SetError(msg.err)
+		return m, nil
+	}
+	return m, m.mapEvents.SetReader(msg.reader)
+}
+
+// handleMapEventType sets the type the events view decodes records with.
+func (m Model) handleMapEventType(msg mapEventTypeMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+	m.mapEvents.SetEventType(msg.name, msg.t, msg.err)
+	return m, nil
+}
+
+// handleMapDumpPage adds a further page of entries to the map dump view.
+func (m Model) handleMapDumpPage(msg mapDumpPageMsg) (tea.Model, tea.Cmd) {
+	return m, m.mapDump.AppendPage(msg.iter, msg.entries, msg.done, msg.err)
+}
+
+// handleMapEntryEdited reports the result of an edit and applies it to the
+// loaded entries, keeping the selection and any pages still to be read.
+func (m Model) handleMapEntryEdited(msg mapEntryEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.loadSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		m.mapDump.SetStatus(fmt.Sprintf("Error: %v", msg.err), true)
+		return m, nil
+	}
+
+	switch msg.op {
+	case mapEditDelete:
+		m.mapDump.RemoveEntry(msg.key)
+		m.mapDump.SetStatus("Entry deleted", false)
+	case mapEditInsert:
+		m.mapDump.PutEntry(msg.key, msg.value)
+		m.mapDump.SetStatus("Entry inserted", false)
+	default:
+		m.mapDump.PutEntry(msg.key, msg.value)
+		m.mapDump.SetStatus("Entry saved", false)
+	}
+	return m, nil
+}
+
+// handleMapExported reports the result of exporting the dump to a file.
+func (m Model) handleMapExported(msg mapExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.mapDump.SetStatus(fmt.Sprintf("Export failed: %v", msg.err), true)
+		return m, nil
+	}
+	m.mapDump.SetStatus(fmt.Sprintf("Exported %d entries to %s", msg.count, msg.path), false)
+	return m, nil
 }
 
 // View implements tea.Model.
@@ -444,6 +1600,24 @@ func (m Model) View() string {
 		return m.renderMapDetail()
 	case ViewMapDump:
 		return m.renderMapDump()
+	case ViewMapEvents:
+		return m.renderMapEvents()
+	case ViewProgDisasm:
+		return m.renderProgDisasm()
+	case ViewProgJIT:
+		return m.renderProgJIT()
+	case ViewLinkList:
+		return m.renderLinkList()
+	case ViewLinkDetail:
+		return m.renderLinkDetail()
+	case ViewBTFList:
+		return m.renderBTFList()
+	case ViewBTFTypes:
+		return m.renderBTFTypes()
+	case ViewBTFType:
+		return m.renderBTFType()
+	case ViewPinTree:
+		return m.renderPinTree()
 	default:
 		return "Unknown view"
 	}
@@ -475,27 +1649,128 @@ func (m Model) renderHelp() string {
 		content += "\nMenu:\n"
 		content += "  Enter    Open selected option\n"
 
-	case ViewProgList, ViewMapList:
+	case ViewProgList, ViewMapList, ViewLinkList, ViewBTFList:
 		content += "\nList:\n"
 		content += "  /        Start fuzzy search\n"
+		if m.state == ViewProgList || m.state == ViewMapList {
+			content += "           or query, e.g. type:xdp uid:0 memlock>1M name~conntrack\n"
+		}
 		content += "  Esc      Exit search / Go back\n"
 		content += "  Enter    View details\n"
+		if m.state != ViewBTFList {
+			content += "  + / -    Appeared / disappeared since last refresh\n"
+		}
+		if m.state == ViewProgList || m.state == ViewMapList {
+			content += "  P        Group by owning process\n"
+			content += "  s        Sort by next column (ID, name, type, memlock, ...)\n"
+			content += "  r        Reverse sort order\n"
+			content += "  t        Toggle table layout\n"
+			content += "  c        Choose table columns\n"
+		}
+		if m.state == ViewProgList {
+			content += "  S        Enable run statistics\n"
+		}
 
 	case ViewProgDetail:
 		content += "\nProgram Detail:\n"
 		content += "  ↑/↓      Navigate associated maps\n"
 		content += "  Enter    View selected map\n"
+		content += "  d        Disassemble (xlated instructions)\n"
+		content += "  J        Disassemble JIT-compiled native code\n"
+		content += "  B        Browse BTF functions\n"
+		content += "  S        Enable run statistics\n"
+		content += "  Esc      Go back to list\n"
+
+	case ViewLinkDetail:
+		content += "\nLink Detail:\n"
+		content += "  ↑/↓      Scroll\n"
+		content += "  Enter    View attached program\n"
 		content += "  Esc      Go back to list\n"
 
+	case ViewBTFTypes:
+		content += "\nBTF Types:\n"
+		content += "  /        Search type names\n"
+		content += "  PgUp/Dn  Scroll by page\n"
+		content += "  g / G    First / last type\n"
+		content += "  Enter    View type definition\n"
+		content += "  Esc      Clear search / Go back\n"
+
+	case ViewPinTree:
+		content += "\nPinned Objects:\n"
+		content += "  Enter    Expand or collapse directory / View object\n"
+		content += "  →/l      Expand directory\n"
+		content += "  ←/h      Collapse directory / Go to parent\n"
+		content += "  g / G    First / last entry\n"
+		content += "  Esc      Go back to menu\n"
+
+	case ViewBTFType:
+		content += "\nBTF Type:\n"
+		content += "  ↑/↓      Scroll\n"
+		content += "  PgUp/Dn  Scroll by page\n"
+		content += "  Esc      Go back to types\n"
+
+	case ViewProgDisasm:
+		content += "\nDisassembly:\n"
+		content += "  ↑/↓      Select instruction\n"
+		content += "  PgUp/Dn  Scroll\n"
+		content += "  n / N    Next / previous map reference\n"
+		content += "  Enter    View referenced map\n"
+		content += "  Esc      Go back / Cancel loading\n"
+
+	case ViewProgJIT:
+		content += "\nJIT Code:\n"
+		content += "  ↑/↓      Scroll\n"
+		content += "  PgUp/Dn  Scroll by page\n"
+		content += "  n / N    Next / previous function\n"
+		content += "  Esc      Go back / Cancel loading\n"
+
 	case ViewMapDetail:
 		content += "\nMap Detail:\n"
-		content += "  Enter    Dump map contents\n"
-		content += "  Esc      Go back\n"
+		content += "  ↑/↓      Select action\n"
+		content += "  Enter    Dump map contents / Look up a key / Browse BTF types\n"
+		content += "  Esc      Go back / Cancel lookup\n"
+		content += "\nLookup keys may be entered as:\n"
+		content += "  42             Decimal integer\n"
+		content += "  10.0.0.1       IPv4 address\n"
+		content += "  0a 0b 0c 0d    Hex bytes (or 0x0a0b0c0d)\n"
+		content += "  {\"pid\": 1}     BTF-structured JSON\n"
 
 	case ViewMapDump:
 		content += "\nMap Dump:\n"
-		content += "  ↑/↓      Scroll through entries\n"
-		content += "  Esc      Go back to map detail\n"
+		content += "  ↑/↓      Select entry\n"
+		content += "  PgUp/Dn  Scroll\n"
+		content += "  g/G      First / last loaded entry\n"
+		content += "  e        Edit selected value\n"
+		content += "  i        Insert new entry\n"
+		content += "  d        Delete selected entry\n"
+		content += "  s        Export entries to a file\n"
+		content += "  /        Search keys and values\n"
+		content += "  n/N      Next / previous match\n"
+		content += "  x        Toggle BTF-decoded / raw hex\n"
+		content += "  m/M      Next value / key display mode\n"
+		content += "  o/O      Swap value / key byte order\n"
+		content += "  a        Toggle per-CPU rows / totals only\n"
+		content += "  Esc      Go back / Cancel loading or edit\n"
+		content += "\nDisplay modes:\n"
+		content += "  hex            Hex bytes\n"
+		content += "  xxd            Hexdump with offsets and ASCII\n"
+		content += "  u8-u64/s8-s64  Unsigned / signed integers\n"
+		content += "  string         Printable strings, other bytes as hex\n"
+		content += "\nSearch patterns:\n"
+		content += "  0a 0b 0c       Hex bytes (or 0x0a0b0c)\n"
+		content += "  \"eth0\"         Text\n"
+		content += "  42             32-bit integer, native byte order\n"
+		content += "  u16be:80       Sized integer: u/s 8-64, le/be optional\n"
+
+	case ViewMapEvents:
+		content += "\nTail Events:\n"
+		content += "  Space/p  Pause / resume\n"
+		content += "  ↑/↓      Scroll back / forward\n"
+		content += "  g/G      Oldest record / follow new records\n"
+		content += "  t        Decode records as a BTF type\n"
+		content += "  x        Toggle BTF-decoded / raw hex\n"
+		content += "  c        Clear the buffer\n"
+		content += "  Esc      Stop and go back\n"
 	}
 
 	// Global shortcuts
@@ -538,26 +1813,115 @@ func (m Model) renderMapDump() string {
 	return m.mapDump.View() + "\n" + m.renderHelpBar()
 }
 
+// renderMapEvents displays the records read from a ring buffer or perf event array.
+func (m Model) renderMapEvents() string {
+	return m.mapEvents.View() + "\n" + m.renderHelpBar()
+}
+
+// renderProgDisasm displays program instructions.
+func (m Model) renderProgDisasm() string {
+	return m.progDisasm.View() + "\n" + m.renderHelpBar()
+}
+
+// renderProgJIT displays a program's native code.
+func (m Model) renderProgJIT() string {
+	return m.progJIT.View() + "\n" + m.renderHelpBar()
+}
+
+// renderLinkList displays the links list.
+func (m Model) renderLinkList() string {
+	return m.linkList.View() + "\n" + m.renderHelpBar()
+}
+
+// renderLinkDetail displays link details.
+func (m Model) renderLinkDetail() string {
+	return m.linkDetail.View() + "\n" + m.renderHelpBar()
+}
+
+// renderBTFList displays the BTF objects list.
+func (m Model) renderBTFList() string {
+	return m.btfList.View() + "\n" + m.renderHelpBar()
+}
+
+// renderBTFTypes displays the BTF type browser.
+func (m Model) renderBTFTypes() string {
+	return m.btfTypes.View() + "\n" + m.renderHelpBar()
+}
+
+// renderPinTree displays the pinned objects tree.
+func (m Model) renderPinTree() string {
+	return m.pinTree.View() + "\n" + m.renderHelpBar()
+}
+
+// renderBTFType displays a BTF type definition.
+func (m Model) renderBTFType() string {
+	return m.btfType.View() + "\n" + m.renderHelpBar()
+}
+
 // renderHelpBar displays context-appropriate shortcuts at the bottom.
 func (m Model) renderHelpBar() string {
 	var shortcuts string
 	switch m.state {
 	case ViewMenu:
 		shortcuts = "↑/↓: navigate • enter: select • q: quit • ?: help"
-	case ViewProgList, ViewMapList:
-		if m.state == ViewProgList && m.progList.IsFiltering() {
-			shortcuts = "↑/↓: navigate • enter: select • esc: cancel search"
-		} else if m.state == ViewMapList && m.mapList.IsFiltering() {
+	case ViewProgList, ViewMapList, ViewLinkList, ViewBTFList:
+		if (m.state == ViewProgList && m.progList.IsChoosingColumns()) || (m.state == ViewMapList && m.mapList.IsChoosingColumns()) {
+			shortcuts = "↑/↓: navigate • space: show/hide column • esc: done"
+		} else if m.isCapturingInput() {
 			shortcuts = "↑/↓: navigate • enter: select • esc: cancel search"
+		} else if m.state == ViewProgList || m.state == ViewMapList {
+			shortcuts = "↑/↓: navigate • enter: select • /: search • s/r: sort • t: table • c: columns • P: by process • esc: back • q: quit • ?: help"
 		} else {
 			shortcuts = "↑/↓: navigate • enter: select • /: search • esc: back • q: quit • ?: help"
 		}
 	case ViewProgDetail:
-		shortcuts = "↑/↓: select map • enter: view map • esc: back • q: quit • ?: help"
+		shortcuts = "↑/↓: select map • enter: view map • d: disassemble • J: JIT code • B: BTF • esc: back • q: quit • ?: help"
+		if m.statsOff {
+			shortcuts = "↑/↓: select map • enter: view map • d: disassemble • J: JIT code • B: BTF • S: enable stats • esc: back • q: quit • ?: help"
+		}
+	case ViewLinkDetail:
+		shortcuts = "↑/↓: scroll • enter: view program • esc: back • q: quit • ?: help"
+	case ViewBTFTypes:
+		if m.btfTypes.IsSearching() {
+			shortcuts = "↑/↓: navigate • enter: done • esc: clear search"
+		} else {
+			shortcuts = "↑/↓: navigate • enter: definition • /: search • esc: back • q: quit • ?: help"
+		}
+	case ViewBTFType:
+		shortcuts = "↑/↓: scroll • pgup/pgdn: page • esc: back • q: quit • ?: help"
+	case ViewPinTree:
+		shortcuts = "↑/↓: navigate • enter: open • ←/→: collapse/expand • esc: back • q: quit • ?: help"
+	case ViewProgDisasm:
+		shortcuts = "↑/↓: select • n/N: next/prev map • enter: view map • esc: back • q: quit • ?: help"
+	case ViewProgJIT:
+		shortcuts = "↑/↓: scroll • n/N: next/prev function • esc: back • q: quit • ?: help"
 	case ViewMapDetail:
-		shortcuts = "enter: dump contents • esc: back • q: quit • ?: help"
+		if m.mapDetail.IsPrompting() {
+			shortcuts = "enter: look up • esc: cancel"
+		} else if m.mapDetail.IsConfirmingTail() {
+			shortcuts = "y: tail • n/esc: cancel"
+		} else {
+			shortcuts = "↑/↓: select action • enter: dump contents / lookup key / BTF types • esc: back • q: quit • ?: help"
+			if info := m.mapDetail.GetMapInfo(); info != nil && isEventMapType(info.Type) {
+				shortcuts = "↑/↓: select action • enter: tail events / BTF types • esc: back • q: quit • ?: help"
+			}
+		}
 	case ViewMapDump:
-		shortcuts = "↑/↓: scroll • esc: back • q: quit • ?: help"
+		if m.mapDump.IsEditing() {
+			shortcuts = "enter: confirm • esc: cancel"
+		} else if m.mapDump.HasBTF() {
+			shortcuts = "↑/↓: select • pgup/pgdn: scroll • e: edit • i: insert • d: delete • s: export • /: search • n/N: next/prev • m/M: mode • x: toggle hex • esc: back • q: quit • ?: help"
+		} else {
+			shortcuts = "↑/↓: select • pgup/pgdn: scroll • e: edit • i: insert • d: delete • s: export • /: search • n/N: next/prev • m/M: mode • esc: back • q: quit • ?: help"
+		}
+	case ViewMapEvents:
+		if m.mapEvents.IsPrompting() {
+			shortcuts = "enter: decode • esc: cancel"
+		} else if m.mapEvents.IsDecoding() {
+			shortcuts = "space: pause • ↑/↓: scroll • G: follow • t: type • x: toggle hex • c: clear • esc: back • q: quit • ?: help"
+		} else {
+			shortcuts = "space: pause • ↑/↓: scroll • G: follow • t: decode as type • c: clear • esc: back • q: quit • ?: help"
+		}
 	default:
 		shortcuts = "↑/↓: navigate • enter: select • esc: back • q: quit • ?: help"
 	}
@@ -570,10 +1934,58 @@ func Run() error {
 	return RunWithServices(nil, nil)
 }
 
+// Options configures the TUI application.
+type Options struct {
+	// RefreshInterval is how often the program and map lists are re-queried.
+	// Zero disables auto-refresh.
+	RefreshInterval time.Duration
+
+	// LinkService provides the links views. If nil, no links are shown.
+	LinkService LinkService
+
+	// BTFService provides the BTF views. If nil, no BTF is shown.
+	BTFService BTFService
+
+	// ProcessService finds the processes holding programs and maps.
+	// If nil, no processes are shown.
+	ProcessService ProcessService
+
+	// PinService provides the pinned objects view. If nil, no pins are shown.
+	PinService PinService
+
+	// Source describes where the data comes from, e.g. a snapshot file.
+	// Empty means the live system.
+	Source string
+
+	// Baseline is a snapshot to compare against. If nil, no comparison is shown.
+	Baseline *Snapshot
+
+	// Config is the user configuration. If nil, the defaults are used.
+	Config *Config
+}
+
 // RunWithServices starts the TUI application with the provided services.
 // If services are nil, the TUI will run in a limited mode.
 func RunWithServices(progSvc ProgService, mapsSvc MapsService) error {
+	return RunWithOptions(progSvc, mapsSvc, Options{RefreshInterval: DefaultRefreshInterval})
+}
+
+// RunWithOptions starts the TUI application with the provided services and options.
+func RunWithOptions(progSvc ProgService, mapsSvc MapsService, opts Options) error {
+	if opts.Config != nil {
+		opts.Config.ApplyTheme()
+	}
 	m := NewModel(progSvc, mapsSvc)
+	m.SetRefreshInterval(opts.RefreshInterval)
+	m.SetLinkService(opts.LinkService)
+	m.SetBTFService(opts.BTFService)
+	m.SetProcessService(opts.ProcessService)
+	m.SetPinService(opts.PinService)
+	m.SetSource(opts.Source)
+	m.SetBaseline(opts.Baseline)
+	if opts.Config != nil {
+		m.startCmd = opts.Config.apply(&m)
+	}
 
 	// Check permissions before starting
 	if err := m.checkPermissions(); err != nil {
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -61,6 +63,22 @@ func (m *mockMapsService) Dump(id uint32) ([]MapEntry, error) {
 	return nil, nil
 }
 
+func (m *mockMapsService) Lookup(id uint32, key []byte) ([]byte, error) {
+	return nil, ErrKeyNotFound
+}
+
+func (m *mockMapsService) Update(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsService) Insert(id uint32, key, value []byte) error {
+	return nil
+}
+
+func (m *mockMapsService) Delete(id uint32, key []byte) error {
+	return nil
+}
+
 func TestNewModel(t *testing.T) {
 	progSvc := &mockProgService{}
 	mapsSvc := &mockMapsService{}
@@ -92,6 +110,14 @@ func TestViewStateString(t *testing.T) {
 		{ViewMapList, "Maps"},
 		{ViewMapDetail, "Map Detail"},
 		{ViewMapDump, "Map Dump"},
+		{ViewProgDisasm, "Program Disassembly"},
+		{ViewProgJIT, "Program JIT Code"},
+		{ViewLinkList, "Links"},
+		{ViewLinkDetail, "Link Detail"},
+		{ViewBTFList, "BTF Objects"},
+		{ViewBTFTypes, "BTF Types"},
+		{ViewBTFType, "BTF Type"},
+		{ViewPinTree, "Pinned Objects"},
 		{ViewState(99), "Unknown"},
 	}
 
@@ -454,7 +480,7 @@ func TestRenderHelpOverlayContextSpecific(t *testing.T) {
 		{ViewMapList, []string{"Navigation", "List", "Global", "fuzzy search"}},
 		{ViewProgDetail, []string{"Navigation", "Program Detail", "Global", "Navigate associated maps"}},
 		{ViewMapDetail, []string{"Navigation", "Map Detail", "Global", "Dump map contents"}},
-		{ViewMapDump, []string{"Navigation", "Map Dump", "Global", "Scroll through entries"}},
+		{ViewMapDump, []string{"Navigation", "Map Dump", "Global", "Select entry", "Delete selected entry"}},
 	}
 
 	for _, tt := range tests {
@@ -650,8 +676,7 @@ func TestIntegrationErrorInProgListDoesNotCrash(t *testing.T) {
 
 	// Navigate to programs list
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should be at programs list
 	if m.state != ViewProgList {
@@ -672,7 +697,7 @@ func TestIntegrationErrorInProgListDoesNotCrash(t *testing.T) {
 
 	// Should still be able to navigate back
 	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
-	result, _ = m.Update(escMsg)
+	result, _ := m.Update(escMsg)
 	m = result.(Model)
 
 	if m.state != ViewMenu {
@@ -696,8 +721,7 @@ func TestIntegrationErrorInMapListDoesNotCrash(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should be at maps list
 	if m.state != ViewMapList {
@@ -738,8 +762,7 @@ func TestIntegrationEmptyProgramsList(t *testing.T) {
 
 	// Navigate to programs list
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// View should show empty state message
 	view := m.View()
@@ -765,8 +788,7 @@ func TestIntegrationEmptyMapsList(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// View should show empty state message
 	view := m.View()
@@ -795,12 +817,10 @@ func TestIntegrationEmptyMapDump(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Select the map
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should be at map detail
 	if m.state != ViewMapDetail {
@@ -808,8 +828,7 @@ func TestIntegrationEmptyMapDump(t *testing.T) {
 	}
 
 	// Navigate to dump
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should be at map dump
 	if m.state != ViewMapDump {
@@ -843,16 +862,13 @@ func TestIntegrationErrorInMapDumpDoesNotCrash(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Select the map
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Navigate to dump
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Should be at map dump
 	if m.state != ViewMapDump {
@@ -940,10 +956,12 @@ func TestIntegrationNilServicesDoNotCrash(t *testing.T) {
 }
 
 // mockMapsServiceWithDump is a mock that supports configurable dump behavior.
+// Lookup, Update and Delete operate on entries, keyed by the hex of the key.
 type mockMapsServiceWithDump struct {
-	maps    []MapInfo
-	entries []MapEntry
-	dumpErr error
+	maps     []MapInfo
+	entries  []MapEntry
+	dumpErr  error
+	writeErr error
 }
 
 func (m *mockMapsServiceWithDump) List() ([]MapInfo, error) {
@@ -966,6 +984,55 @@ func (m *mockMapsServiceWithDump) Dump(id uint32) ([]MapEntry, error) {
 	return m.entries, nil
 }
 
+func (m *mockMapsServiceWithDump) Lookup(id uint32, key []byte) ([]byte, error) {
+	for _, e := range m.entries {
+		if FormatHex(e.Key) == FormatHex(key) {
+			return e.Value, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (m *mockMapsServiceWithDump) Update(id uint32, key, value []byte) error {
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+	for i, e := range m.entries {
+		if FormatHex(e.Key) == FormatHex(key) {
+			m.entries[i].Value = value
+			return nil
+		}
+	}
+	m.entries = append(m.entries, MapEntry{Key: key, Value: value})
+	return nil
+}
+
+func (m *mockMapsServiceWithDump) Insert(id uint32, key, value []byte) error {
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+	for _, e := range m.entries {
+		if FormatHex(e.Key) == FormatHex(key) {
+			return ErrKeyExists
+		}
+	}
+	m.entries = append(m.entries, MapEntry{Key: key, Value: value})
+	return nil
+}
+
+func (m *mockMapsServiceWithDump) Delete(id uint32, key []byte) error {
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+	for i, e := range m.entries {
+		if FormatHex(e.Key) == FormatHex(key) {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			return nil
+		}
+	}
+	return ErrKeyNotFound
+}
+
 // ============================================================================
 // Integration Tests for Full Navigation Flow
 // ============================================================================
@@ -996,8 +1063,7 @@ func TestIntegrationFullNavigationFlowMenuToMapDumpAndBack(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at MapList
 	if m.state != ViewMapList {
@@ -1008,8 +1074,7 @@ func TestIntegrationFullNavigationFlowMenuToMapDumpAndBack(t *testing.T) {
 	}
 
 	// Select the map to go to MapDetail
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at MapDetail
 	if m.state != ViewMapDetail {
@@ -1020,8 +1085,7 @@ func TestIntegrationFullNavigationFlowMenuToMapDumpAndBack(t *testing.T) {
 	}
 
 	// Navigate to MapDump
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at MapDump
 	if m.state != ViewMapDump {
@@ -1094,8 +1158,7 @@ func TestIntegrationFullNavigationFlowMenuToProgDetailAndBack(t *testing.T) {
 
 	// Navigate to Programs list (Enter on first item)
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at ProgList
 	if m.state != ViewProgList {
@@ -1103,8 +1166,7 @@ func TestIntegrationFullNavigationFlowMenuToProgDetailAndBack(t *testing.T) {
 	}
 
 	// Select the program to go to ProgDetail
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at ProgDetail
 	if m.state != ViewProgDetail {
@@ -1122,7 +1184,7 @@ func TestIntegrationFullNavigationFlowMenuToProgDetailAndBack(t *testing.T) {
 
 	// Navigate back to ProgList
 	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
-	result, _ = m.Update(escMsg)
+	result, _ := m.Update(escMsg)
 	m = result.(Model)
 
 	if m.state != ViewProgList {
@@ -1157,11 +1219,9 @@ func TestIntegrationProgDetailToMapDetailAndBack(t *testing.T) {
 
 	// Navigate: Menu → ProgList → ProgDetail
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg) // Menu → ProgList
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // Menu → ProgList
 
-	result, _ = m.Update(enterMsg) // ProgList → ProgDetail
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // ProgList → ProgDetail
 
 	// Verify at ProgDetail
 	if m.state != ViewProgDetail {
@@ -1175,8 +1235,7 @@ func TestIntegrationProgDetailToMapDetailAndBack(t *testing.T) {
 	}
 
 	// Select the associated map to navigate to MapDetail
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Verify at MapDetail
 	if m.state != ViewMapDetail {
@@ -1191,7 +1250,7 @@ func TestIntegrationProgDetailToMapDetailAndBack(t *testing.T) {
 
 	// Navigate back - should return to ProgDetail, NOT MapList
 	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
-	result, _ = m.Update(escMsg)
+	result, _ := m.Update(escMsg)
 	m = result.(Model)
 
 	if m.state != ViewProgDetail {
@@ -1227,17 +1286,13 @@ func TestIntegrationProgDetailToMapDetailToDumpAndBack(t *testing.T) {
 
 	// Navigate: Menu → ProgList → ProgDetail → MapDetail → MapDump
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg) // Menu → ProgList
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // Menu → ProgList
 
-	result, _ = m.Update(enterMsg) // ProgList → ProgDetail
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // ProgList → ProgDetail
 
-	result, _ = m.Update(enterMsg) // ProgDetail → MapDetail (via associated map)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // ProgDetail → MapDetail (via associated map)
 
-	result, _ = m.Update(enterMsg) // MapDetail → MapDump
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg) // MapDetail → MapDump
 
 	// Verify at MapDump
 	if m.state != ViewMapDump {
@@ -1255,7 +1310,7 @@ func TestIntegrationProgDetailToMapDetailToDumpAndBack(t *testing.T) {
 
 	// Navigate back: MapDump → MapDetail
 	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
-	result, _ = m.Update(escMsg)
+	result, _ := m.Update(escMsg)
 	m = result.(Model)
 
 	if m.state != ViewMapDetail {
@@ -1379,12 +1434,11 @@ func TestTerminalResizeWithData(t *testing.T) {
 
 	// Navigate to ProgList and load data
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ := m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Resize while viewing list
 	msg := tea.WindowSizeMsg{Width: 150, Height: 50}
-	result, _ = m.Update(msg)
+	result, _ := m.Update(msg)
 	m = result.(Model)
 
 	// View should still show data correctly
@@ -1394,8 +1448,7 @@ func TestTerminalResizeWithData(t *testing.T) {
 	}
 
 	// Navigate to detail and resize again
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	msg = tea.WindowSizeMsg{Width: 80, Height: 24}
 	result, _ = m.Update(msg)
@@ -1439,8 +1492,7 @@ func TestTerminalResizeDuringNavigation(t *testing.T) {
 	m = result.(Model)
 
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Resize
 	msg := tea.WindowSizeMsg{Width: 120, Height: 40}
@@ -1448,8 +1500,7 @@ func TestTerminalResizeDuringNavigation(t *testing.T) {
 	m = result.(Model)
 
 	// Continue navigation
-	result, _ = m.Update(enterMsg)
-	m = result.(Model)
+	m = updateAndRun(m, enterMsg)
 
 	// Resize again
 	msg = tea.WindowSizeMsg{Width: 80, Height: 24}
@@ -1497,3 +1548,1232 @@ func TestBackspaceNavigationEquivalentToEscape(t *testing.T) {
 		t.Errorf("expected ViewMenu after backspace, got %v", m.state)
 	}
 }
+
+// ============================================================================
+// Integration Tests for Asynchronous Loading
+// ============================================================================
+
+// TestAsyncLoadShowsLoadingState tests that entering a list shows a loading
+// state until the load result arrives.
+func TestAsyncLoadShowsLoadingState(t *testing.T) {
+	mockSvc := &mockProgService{
+		programs: []ProgramInfo{{ID: 1, Name: "prog1", Type: "kprobe", Tag: "tag1"}},
+	}
+
+	m := NewModel(mockSvc, nil)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	result, cmd := m.Update(enterMsg)
+	m = result.(Model)
+
+	if cmd == nil {
+		t.Fatal("expected load command, got nil")
+	}
+	if !m.progList.IsLoading() {
+		t.Error("program list should be loading before the result arrives")
+	}
+	if !containsString(m.View(), "Loading BPF programs") {
+		t.Error("view should show loading message")
+	}
+
+	for _, msg := range runCmd(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+
+	if m.progList.IsLoading() {
+		t.Error("program list should not be loading after the result arrives")
+	}
+	if !containsString(m.View(), "prog1") {
+		t.Error("view should show loaded programs")
+	}
+}
+
+// TestAsyncLoadCancelWithEsc tests that Esc during a load navigates back and
+// discards the result when it eventually arrives.
+func TestAsyncLoadCancelWithEsc(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 8, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{0x01}, Value: []byte{0x02}}},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+
+	downMsg := tea.KeyMsg{Type: tea.KeyDown}
+	result, _ := m.Update(downMsg)
+	m = result.(Model)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	m = updateAndRun(m, enterMsg) // Menu → MapList
+	m = updateAndRun(m, enterMsg) // MapList → MapDetail
+
+	// Start the dump but don't deliver the result yet
+	result, cmd := m.Update(enterMsg)
+	m = result.(Model)
+
+	if m.state != ViewMapDump {
+		t.Fatalf("expected ViewMapDump, got %v", m.state)
+	}
+	if !m.mapDump.IsLoading() {
+		t.Fatal("map dump should be loading")
+	}
+
+	// Cancel with Esc
+	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
+	result, _ = m.Update(escMsg)
+	m = result.(Model)
+
+	if m.state != ViewMapDetail {
+		t.Fatalf("expected ViewMapDetail after cancel, got %v", m.state)
+	}
+	if m.mapDump.IsLoading() {
+		t.Error("map dump should no longer be loading after cancel")
+	}
+
+	// The stale result must be ignored
+	for _, msg := range runCmd(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+
+	if m.mapDump.GetEntryCount() != 0 {
+		t.Errorf("cancelled dump result should be discarded, got %d entries", m.mapDump.GetEntryCount())
+	}
+}
+
+// TestAsyncLoadStaleResultIgnored tests that a result from a superseded load
+// does not overwrite the current one.
+func TestAsyncLoadStaleResultIgnored(t *testing.T) {
+	m := NewModel(&mockProgService{}, nil)
+	m.pushState(ViewProgList)
+
+	stale := m.nextLoadSeq()
+	current := m.nextLoadSeq()
+
+	result, _ := m.Update(programsLoadedMsg{
+		seq:      stale,
+		programs: []ProgramInfo{{ID: 1, Name: "stale_prog"}},
+	})
+	m = result.(Model)
+
+	if containsString(m.View(), "stale_prog") {
+		t.Error("stale load result should be ignored")
+	}
+
+	result, _ = m.Update(programsLoadedMsg{
+		seq:      current,
+		programs: []ProgramInfo{{ID: 2, Name: "current_prog"}},
+	})
+	m = result.(Model)
+
+	if !containsString(m.View(), "current_prog") {
+		t.Error("current load result should be applied")
+	}
+}
+
+// TestAsyncLoadMapByIDError tests that a failed map lookup from program
+// detail surfaces the error.
+func TestAsyncLoadMapByIDError(t *testing.T) {
+	mockProgSvc := &mockProgService{
+		programs: []ProgramInfo{
+			{ID: 1, Name: "test_prog", Type: "kprobe", Tag: "abc123", MapIDs: []uint32{99}},
+		},
+	}
+	mockMapsSvc := &mockMapsServiceWithDump{}
+
+	m := NewModel(mockProgSvc, mockMapsSvc)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	m = updateAndRun(m, enterMsg) // Menu → ProgList
+	m = updateAndRun(m, enterMsg) // ProgList → ProgDetail
+	m = updateAndRun(m, enterMsg) // ProgDetail → MapDetail
+
+	if m.err == nil {
+		t.Fatal("expected error for missing map")
+	}
+	if m.mapDetail.IsLoading() {
+		t.Error("map detail should not be loading after error")
+	}
+}
+
+// mockMapsServiceWithBTF additionally implements MapBTFService.
+type mockMapsServiceWithBTF struct {
+	mockMapsServiceWithDump
+	btf *MapBTF
+}
+
+func (m *mockMapsServiceWithBTF) MapBTF(id uint32) (*MapBTF, error) {
+	return m.btf, nil
+}
+
+// TestIntegrationMapDumpUsesBTF tests that the dump view decodes entries with
+// BTF when the maps service provides it.
+func TestIntegrationMapDumpUsesBTF(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithBTF{
+		mockMapsServiceWithDump: mockMapsServiceWithDump{
+			maps: []MapInfo{
+				{ID: 1, Name: "btf_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+			},
+			entries: []MapEntry{{Key: []byte{0x07, 0, 0, 0}, Value: []byte{0x01, 0, 0, 0}}},
+		},
+		btf: &MapBTF{
+			Key: &BTFType{Kind: BTFKindInt, Size: 4},
+			Value: &BTFType{Kind: BTFKindEnum, Size: 4, Values: []BTFEnumValue{
+				{Name: "FLAG_ON", Value: 1},
+			}},
+		},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	m = updateAndRun(m, enterMsg) // Menu → MapList
+	m = updateAndRun(m, enterMsg) // MapList → MapDetail
+	m = updateAndRun(m, enterMsg) // MapDetail → MapDump
+
+	if !m.mapDump.HasBTF() {
+		t.Fatal("map dump should have BTF")
+	}
+	if !containsString(m.View(), "FLAG_ON") {
+		t.Error("map dump should show BTF-decoded value")
+	}
+	if !containsString(m.renderHelpBar(), "toggle hex") {
+		t.Error("help bar should offer the hex toggle")
+	}
+}
+
+// openMapDump navigates from the menu to the dump of the first map.
+func openMapDump(m Model) Model {
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	m = updateAndRun(m, enterMsg) // Menu → MapList
+	m = updateAndRun(m, enterMsg) // MapList → MapDetail
+	m = updateAndRun(m, enterMsg) // MapDetail → MapDump
+	return m
+}
+
+// confirmEdit sends msg, which confirms an edit in the dump view, then runs
+// the edit.
+func confirmEdit(m Model, msg tea.Msg) Model {
+	result, cmd := m.Update(msg)
+	m = result.(Model)
+	for _, msg := range runCmd(cmd) {
+		m = updateAndRun(m, msg)
+	}
+	return m
+}
+
+func TestIntegrationMapDumpDeleteEntry(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{
+			{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0, 0, 0}},
+			{Key: []byte{2, 0, 0, 0}, Value: []byte{0x0b, 0, 0, 0}},
+		},
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+	if m.mapDump.GetEntryCount() != 2 {
+		t.Fatalf("expected 2 entries, got %d", m.mapDump.GetEntryCount())
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = result.(Model)
+	if !m.isCapturingInput() {
+		t.Fatal("delete confirmation should capture input")
+	}
+
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	if len(mockMapsSvc.entries) != 1 {
+		t.Fatalf("expected the entry to be deleted from the map, %d left", len(mockMapsSvc.entries))
+	}
+	if m.mapDump.GetEntryCount() != 1 {
+		t.Errorf("the deleted entry should be dropped from the dump, got %d entries", m.mapDump.GetEntryCount())
+	}
+	if !containsString(m.View(), "Entry deleted") {
+		t.Error("view should report the delete")
+	}
+}
+
+func TestIntegrationMapDumpEditValue(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0, 0, 0}}},
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = result.(Model)
+	m.mapDump.input.SetValue("ff 00 00 00")
+
+	// q and ? are typed into the editor rather than quitting or opening help
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = result.(Model)
+	if m.mapDump.input.Value() != "ff 00 00 00q" {
+		t.Fatalf("q should be typed into the editor, got %q", m.mapDump.input.Value())
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = result.(Model)
+	if m.state != ViewMapDump {
+		t.Fatal("backspace should not navigate back while editing")
+	}
+
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if mockMapsSvc.entries[0].Value[0] != 0xff {
+		t.Errorf("expected the value to be updated, got %v", mockMapsSvc.entries[0].Value)
+	}
+	if !containsString(m.View(), "ff 00 00 00") {
+		t.Error("view should show the updated value")
+	}
+	if !containsString(m.View(), "Entry saved") {
+		t.Error("view should report the update")
+	}
+}
+
+func TestIntegrationMapDumpEditError(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "array", KeySize: 4, ValueSize: 4, MaxEntries: 1},
+		},
+		entries:  []MapEntry{{Key: []byte{0, 0, 0, 0}, Value: []byte{0, 0, 0, 0}}},
+		writeErr: errors.New("operation not permitted"),
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = result.(Model)
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+
+	if m.err != nil {
+		t.Fatal("a failed edit should not replace the view with an error screen")
+	}
+	if !containsString(m.View(), "operation not permitted") {
+		t.Error("view should show the edit error")
+	}
+	if m.mapDump.GetEntryCount() != 1 {
+		t.Error("entries should be kept after a failed edit")
+	}
+}
+
+func TestIntegrationMapDumpInsertExistingKey(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0, 0, 0}}},
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = result.(Model)
+	m.mapDump.input.SetValue("01 00 00 00")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	m.mapDump.input.SetValue("ff 00 00 00")
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !containsString(m.View(), "key already exists") {
+		t.Error("view should report that the key already exists")
+	}
+	if mockMapsSvc.entries[0].Value[0] != 0x0a {
+		t.Errorf("inserting an existing key should not replace its value, got %v", mockMapsSvc.entries[0].Value)
+	}
+
+	// A new key is inserted
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = result.(Model)
+	m.mapDump.input.SetValue("02 00 00 00")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	m.mapDump.input.SetValue("0b 00 00 00")
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(mockMapsSvc.entries) != 2 {
+		t.Fatalf("expected the new entry to be inserted, got %d entries", len(mockMapsSvc.entries))
+	}
+	if !containsString(m.View(), "Entry inserted") {
+		t.Error("view should report the insert")
+	}
+}
+
+func TestIntegrationMapDumpExport(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{0x0a, 0, 0, 0}}},
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = result.(Model)
+	path := filepath.Join(t.TempDir(), "dump.json")
+	m.mapDump.input.SetValue(path)
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !containsString(m.View(), "Exported 1 entries to "+path) {
+		t.Error("view should report the export")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("export file not written: %v", err)
+	}
+
+	// Failures are reported without leaving the view
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = result.(Model)
+	m.mapDump.input.SetValue(filepath.Join(t.TempDir(), "missing", "dump.json"))
+	m = confirmEdit(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.err != nil || m.state != ViewMapDump {
+		t.Fatal("a failed export should not leave the dump view")
+	}
+	if !containsString(m.View(), "Export failed") {
+		t.Error("view should show the export error")
+	}
+}
+
+func TestIntegrationMapDumpEscClosesEditorFirst(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{1, 0, 0, 0}, Value: []byte{0, 0, 0, 0}}},
+	}
+
+	m := openMapDump(NewModel(nil, mockMapsSvc))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = result.(Model)
+	if !containsString(m.renderHelpBar(), "esc: cancel") {
+		t.Error("help bar should show editor shortcuts")
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapDump || m.mapDump.IsEditing() {
+		t.Fatal("esc should close the editor and stay in the dump view")
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapDetail {
+		t.Errorf("second esc should go back, got %v", m.state)
+	}
+}
+
+// lookupKey runs the Lookup Key action in the map detail view with input.
+func lookupKey(m Model, input string) Model {
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, r := range input {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	return updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestIntegrationMapDetailLookup(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "ip_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{{Key: []byte{10, 0, 0, 1}, Value: []byte{0xaa, 0xbb, 0xcc, 0xdd}}},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // MapList → MapDetail
+
+	// Esc closes the prompt before navigating back
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.isCapturingInput() {
+		t.Fatal("lookup prompt should capture input")
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapDetail {
+		t.Fatal("esc should close the prompt, not navigate back")
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = result.(Model)
+
+	m = lookupKey(m, "10.0.0.1")
+	lookup := m.mapDetail.GetLookupResult()
+	if lookup == nil || lookup.err != nil {
+		t.Fatalf("expected a successful lookup, got %+v", lookup)
+	}
+	if !containsString(m.View(), "aa bb cc dd") {
+		t.Error("view should show the looked up value")
+	}
+}
+
+func TestIntegrationMapDetailLookupNotFound(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithDump{
+		maps: []MapInfo{
+			{ID: 1, Name: "test_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+		},
+		entries: []MapEntry{},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m = lookupKey(m, "7")
+	if !containsString(m.View(), "Key not found") {
+		t.Error("view should report the key as not found")
+	}
+}
+
+func TestIntegrationMapDetailLookupWithBTF(t *testing.T) {
+	mockMapsSvc := &mockMapsServiceWithBTF{
+		mockMapsServiceWithDump: mockMapsServiceWithDump{
+			maps: []MapInfo{
+				{ID: 1, Name: "btf_map", Type: "hash", KeySize: 4, ValueSize: 4, MaxEntries: 100},
+			},
+			entries: []MapEntry{{Key: []byte{0x07, 0, 0, 0}, Value: []byte{0x01, 0, 0, 0}}},
+		},
+		btf: &MapBTF{
+			Key: &BTFType{Kind: BTFKindStruct, Name: "key", Size: 4, Members: []BTFMember{
+				{Name: "pid", Offset: 0, Type: &BTFType{Kind: BTFKindInt, Size: 4}},
+			}},
+			Value: &BTFType{Kind: BTFKindEnum, Size: 4, Values: []BTFEnumValue{
+				{Name: "FLAG_ON", Value: 1},
+			}},
+		},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m = lookupKey(m, `{"pid": 7}`)
+	if !containsString(m.View(), "FLAG_ON") {
+		t.Error("view should show the BTF-decoded value")
+	}
+}
+
+// mockProgServiceWithDisasm adds ProgDisasmService to mockProgService.
+type mockProgServiceWithDisasm struct {
+	mockProgService
+	insns []Instruction
+	jit   *JITImage
+}
+
+func (m *mockProgServiceWithDisasm) Xlated(id uint32) ([]Instruction, error) {
+	return m.insns, nil
+}
+
+func (m *mockProgServiceWithDisasm) JITed(id uint32) (*JITImage, error) {
+	if m.jit == nil {
+		return nil, errors.New("no JIT image available")
+	}
+	return m.jit, nil
+}
+
+func TestIntegrationProgDisasmToMapDetail(t *testing.T) {
+	mockProgSvc := &mockProgServiceWithDisasm{
+		mockProgService: mockProgService{
+			programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp", MapIDs: []uint32{5}}},
+		},
+		insns: []Instruction{
+			{Offset: 0, OpCode: 0x18, Dst: 1, Src: bpfPseudoMapFD, Imm: 5, MapID: 5, Func: "xdp_prog"},
+			{Offset: 2, OpCode: 0x85, Imm: 1, Call: "bpf_map_lookup_elem"},
+			{Offset: 3, OpCode: 0x95},
+		},
+	}
+	mockMapsSvc := &mockMapsService{
+		maps: []MapInfo{{ID: 5, Name: "xdp_stats", Type: "array"}},
+	}
+
+	m := NewModel(mockProgSvc, mockMapsSvc)
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	m = updateAndRun(m, enterMsg) // Menu → ProgList
+	m = updateAndRun(m, enterMsg) // ProgList → ProgDetail
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.state != ViewProgDisasm {
+		t.Fatalf("expected ViewProgDisasm, got %v", m.state)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Xlated: xdp_prog (ID: 10)", "xdp_prog:", "r1 = map[id:5]", "call bpf_map_lookup_elem#1", "exit"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	// Enter on the map load jumps to the map
+	m = updateAndRun(m, enterMsg)
+	if m.state != ViewMapDetail {
+		t.Fatalf("expected ViewMapDetail, got %v", m.state)
+	}
+	if m.mapDetail.GetMapID() != 5 {
+		t.Errorf("expected map 5, got %d", m.mapDetail.GetMapID())
+	}
+
+	// Back returns to the disassembly
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewProgDisasm {
+		t.Errorf("expected ViewProgDisasm after back, got %v", m.state)
+	}
+}
+
+func TestIntegrationProgDisasmUnsupported(t *testing.T) {
+	mockProgSvc := &mockProgService{
+		programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	if !m.progDisasm.HasError() {
+		t.Error("disassembly should fail when the service doesn't support it")
+	}
+	if !containsString(m.View(), "not supported") {
+		t.Error("view should explain that disassembly is unsupported")
+	}
+}
+
+func TestIntegrationProgJIT(t *testing.T) {
+	mockProgSvc := &mockProgServiceWithDisasm{
+		mockProgService: mockProgService{
+			programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp", BytesJIT: 6}},
+		},
+		jit: testJITImage,
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+
+	if m.state != ViewProgJIT {
+		t.Fatalf("expected ViewProgJIT, got %v", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"JIT: xdp_prog (ID: 10) [amd64]", "prog:", "subprog:", "push %rbp"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewProgDetail {
+		t.Errorf("expected ViewProgDetail after back, got %v", m.state)
+	}
+}
+
+func TestIntegrationProgJITError(t *testing.T) {
+	mockProgSvc := &mockProgServiceWithDisasm{
+		mockProgService: mockProgService{
+			programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+		},
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+
+	if !m.progJIT.HasError() || !containsString(m.View(), "no JIT image available") {
+		t.Error("view should show the JIT error")
+	}
+}
+
+// mockProgServiceWithStats adds ProgStatsService to mockProgService.
+type mockProgServiceWithStats struct {
+	mockProgService
+	enabled   bool
+	enableErr error
+}
+
+func (m *mockProgServiceWithStats) StatsEnabled() bool {
+	return m.enabled
+}
+
+func (m *mockProgServiceWithStats) EnableStats() error {
+	if m.enableErr != nil {
+		return m.enableErr
+	}
+	m.enabled = true
+	return nil
+}
+
+func TestIntegrationEnableStats(t *testing.T) {
+	mockProgSvc := &mockProgServiceWithStats{
+		mockProgService: mockProgService{
+			programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+		},
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+	if !containsString(m.View(), "Press S to enable") {
+		t.Fatal("list should prompt to enable stats")
+	}
+
+	// S does nothing while the column chooser is open
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if mockProgSvc.enabled {
+		t.Fatal("S should not enable stats while choosing columns")
+	}
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if !mockProgSvc.enabled {
+		t.Fatal("S should enable stats")
+	}
+	if containsString(m.View(), "Press S to enable") {
+		t.Error("prompt should be hidden once stats are enabled")
+	}
+}
+
+func TestIntegrationEnableStatsError(t *testing.T) {
+	mockProgSvc := &mockProgServiceWithStats{
+		mockProgService: mockProgService{
+			programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+		},
+		enableErr: errors.New("operation not permitted"),
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // ProgList → ProgDetail
+	if !containsString(m.View(), "Press S to enable") {
+		t.Fatal("detail should prompt to enable stats")
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if !containsString(m.View(), "operation not permitted") {
+		t.Error("detail should show why stats couldn't be enabled")
+	}
+}
+
+func TestIntegrationStatsPromptRequiresService(t *testing.T) {
+	m := NewModel(&mockProgService{programs: []ProgramInfo{{ID: 1, Name: "prog"}}}, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if containsString(m.View(), "Press S to enable") {
+		t.Error("services without stats control should not prompt")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}}); cmd != nil {
+		t.Error("S should do nothing without stats control")
+	}
+}
+
+// mockLinkService is a mock implementation of LinkService for testing.
+type mockLinkService struct {
+	links []LinkInfo
+	err   error
+}
+
+func (m *mockLinkService) List() ([]LinkInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.links, nil
+}
+
+func (m *mockLinkService) Get(id uint32) (*LinkInfo, error) {
+	for _, l := range m.links {
+		if l.ID == id {
+			return &l, nil
+		}
+	}
+	return nil, errors.New("link not found")
+}
+
+// openLinks navigates from the menu to the links list.
+func openLinks(m Model) Model {
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	return updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestIntegrationLinkToProgram(t *testing.T) {
+	mockProgSvc := &mockProgService{
+		programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+	}
+	m := NewModel(mockProgSvc, nil)
+	m.SetLinkService(&mockLinkService{
+		links: []LinkInfo{{ID: 3, Type: "xdp", ProgID: 10, Target: "eth0"}},
+	})
+
+	m = openLinks(m)
+	if m.state != ViewLinkList {
+		t.Fatalf("expected ViewLinkList, got %v", m.state)
+	}
+	if !containsString(m.View(), "[3] xdp") {
+		t.Error("list should show the link")
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewLinkDetail {
+		t.Fatalf("expected ViewLinkDetail, got %v", m.state)
+	}
+	if !containsString(m.View(), "eth0") {
+		t.Error("detail should show the netdev")
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewProgDetail {
+		t.Fatalf("expected ViewProgDetail, got %v", m.state)
+	}
+	if prog := m.progDetail.GetProgram(); prog == nil || prog.ID != 10 {
+		t.Fatalf("expected program 10 to be loaded, got %v", prog)
+	}
+	if !containsString(m.View(), "xdp_prog") {
+		t.Error("detail should show the program")
+	}
+
+	// Back returns to the link, not the programs list
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewLinkDetail {
+		t.Errorf("expected ViewLinkDetail after back, got %v", m.state)
+	}
+}
+
+func TestIntegrationLinkToMissingProgram(t *testing.T) {
+	m := NewModel(&mockProgService{}, nil)
+	m.SetLinkService(&mockLinkService{
+		links: []LinkInfo{{ID: 3, Type: "xdp", ProgID: 10}},
+	})
+
+	m = openLinks(m)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.progDetail.IsLoading() {
+		t.Error("loading should stop on error")
+	}
+	if !containsString(m.View(), "program not found") {
+		t.Error("view should show the error")
+	}
+}
+
+func TestIntegrationLinksError(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.SetLinkService(&mockLinkService{err: errors.New("permission denied")})
+
+	m = openLinks(m)
+	if !containsString(m.View(), "permission denied") {
+		t.Error("list should show the error")
+	}
+}
+
+func TestIntegrationLinksWithoutService(t *testing.T) {
+	m := openLinks(NewModel(nil, nil))
+	if m.state != ViewLinkList || !containsString(m.View(), "No BPF links loaded") {
+		t.Error("links list should be empty without a service")
+	}
+}
+
+// mockBTFService is a mock implementation of BTFService for testing.
+type mockBTFService struct {
+	objects []BTFObjectInfo
+	types   []BTFTypeInfo
+	err     error
+}
+
+func (m *mockBTFService) List() ([]BTFObjectInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.objects, nil
+}
+
+func (m *mockBTFService) Types(id uint32) ([]BTFTypeInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.types, nil
+}
+
+func (m *mockBTFService) Definition(id, typeID uint32) (string, error) {
+	for _, t := range m.types {
+		if t.ObjID == id && t.ID == typeID {
+			return "struct " + t.Name + " {};", nil
+		}
+	}
+	return "", errors.New("type not found")
+}
+
+func (m *mockBTFService) MapTypes(mapID uint32) ([]BTFTypeInfo, error) {
+	return []BTFTypeInfo{
+		{ObjID: 5, ID: 1, Kind: "int", Name: "u32", Label: "key"},
+		{ObjID: 5, ID: 2, Kind: "struct", Name: "event", Label: "value"},
+	}, nil
+}
+
+func (m *mockBTFService) ProgFuncs(progID uint32) ([]BTFTypeInfo, error) {
+	return []BTFTypeInfo{{ObjID: 5, ID: 3, Kind: "func", Name: "xdp_main", Label: "entry"}}, nil
+}
+
+// openBTF navigates from the menu to the BTF objects list.
+func openBTF(m Model) Model {
+	for range 3 {
+		m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	return updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestIntegrationBTFTypeSearch(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.SetBTFService(&mockBTFService{
+		objects: []BTFObjectInfo{{ID: 1, Name: "vmlinux", Kind: "vmlinux"}},
+		types: []BTFTypeInfo{
+			{ObjID: 1, ID: 10, Kind: "struct", Name: "sk_buff"},
+			{ObjID: 1, ID: 11, Kind: "struct", Name: "task_struct"},
+			{ObjID: 1, ID: 12, Kind: "typedef", Name: "u32"},
+		},
+	})
+
+	m = openBTF(m)
+	if m.state != ViewBTFList || !containsString(m.View(), "[1] vmlinux") {
+		t.Fatalf("expected the BTF list with vmlinux, got state %v", m.state)
+	}
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewBTFTypes || !containsString(m.View(), "3 of 3 types") {
+		t.Fatalf("expected the type browser with 3 types, got state %v", m.state)
+	}
+
+	// Search keystrokes go to the search, not the global key bindings
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !m.isCapturingInput() {
+		t.Fatal("type search should capture input")
+	}
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("task")})
+	if m.state != ViewBTFTypes || !containsString(m.View(), "1 of 3 types") {
+		t.Fatalf("search should narrow the types, got state %v", m.state)
+	}
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.state != ViewBTFType {
+		t.Fatalf("expected ViewBTFType, got %v", m.state)
+	}
+	if !containsString(m.View(), "struct task_struct {};") {
+		t.Error("view should show the definition")
+	}
+
+	// Back keeps the search
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewBTFTypes || !containsString(m.View(), "1 of 3 types") {
+		t.Errorf("back should return to the searched types, got state %v", m.state)
+	}
+}
+
+func TestIntegrationBTFError(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.SetBTFService(&mockBTFService{err: errors.New("permission denied")})
+
+	m = openBTF(m)
+	if !containsString(m.View(), "permission denied") {
+		t.Error("view should show the error")
+	}
+}
+
+func TestIntegrationBTFWithoutService(t *testing.T) {
+	m := openBTF(NewModel(nil, nil))
+	if m.state != ViewBTFList || !containsString(m.View(), "No BTF objects loaded") {
+		t.Error("BTF list should be empty without a service")
+	}
+}
+
+func TestIntegrationMapDetailBrowseBTF(t *testing.T) {
+	mockMapsSvc := &mockMapsService{
+		maps: []MapInfo{{ID: 1, Name: "events", Type: "hash", KeySize: 4, ValueSize: 8}},
+	}
+
+	m := NewModel(nil, mockMapsSvc)
+	m.SetBTFService(&mockBTFService{})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // MapList → MapDetail
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.state != ViewBTFTypes {
+		t.Fatalf("expected ViewBTFTypes, got %v", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"Map BTF: events (ID: 1)", "key:", "u32", "value:", "event"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewMapDetail {
+		t.Errorf("expected ViewMapDetail after back, got %v", m.state)
+	}
+}
+
+func TestIntegrationProgDetailBrowseBTF(t *testing.T) {
+	mockProgSvc := &mockProgService{
+		programs: []ProgramInfo{{ID: 10, Name: "xdp_prog", Type: "xdp"}},
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m.SetBTFService(&mockBTFService{})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+
+	if m.state != ViewBTFTypes {
+		t.Fatalf("expected ViewBTFTypes, got %v", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"Program BTF: xdp_prog (ID: 10)", "entry:", "xdp_main"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+// mockProcessService is a mock implementation of ProcessService for testing.
+type mockProcessService struct {
+	procs []ProcessInfo
+	err   error
+}
+
+func (m *mockProcessService) List() ([]ProcessInfo, error) {
+	return m.procs, m.err
+}
+
+func TestIntegrationProcessOwners(t *testing.T) {
+	mockProgSvc := &mockProgService{
+		programs: []ProgramInfo{
+			{ID: 1, Name: "orphan", Type: "kprobe"},
+			{ID: 2, Name: "xdp_lb", Type: "xdp"},
+		},
+	}
+
+	m := NewModel(mockProgSvc, nil)
+	m.SetProcessService(&mockProcessService{
+		procs: []ProcessInfo{{PID: 1234, Comm: "cilium-agent", Cgroup: "/system.slice/cilium.service", ProgIDs: []uint32{2}}},
+	})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → ProgList
+
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if !m.progList.IsByProcess() {
+		t.Fatal("P should group the list by process")
+	}
+	if !containsString(m.View(), "Held by: cilium-agent (1234)") {
+		t.Error("list should show the owning process")
+	}
+
+	// The cursor follows orphan to the end; xdp_lb is grouped first
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyUp})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewProgDetail {
+		t.Fatalf("expected ViewProgDetail, got %v", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"Program: xdp_lb", "1234 (cilium-agent)", "/system.slice/cilium.service"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestIntegrationProcessOwnersError(t *testing.T) {
+	mockMapsSvc := &mockMapsService{maps: []MapInfo{{ID: 1, Name: "events", Type: "hash"}}}
+
+	m := NewModel(nil, mockMapsSvc)
+	m.SetProcessService(&mockProcessService{err: errors.New("permission denied")})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // Menu → MapList
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter}) // MapList → MapDetail
+
+	if !containsString(m.View(), "permission denied") {
+		t.Error("map detail should show the process scan error")
+	}
+}
+
+func TestIntegrationGroupByProcessWithoutService(t *testing.T) {
+	m := NewModel(&mockProgService{programs: []ProgramInfo{{ID: 1, Name: "prog"}}}, nil)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+
+	if m.progList.IsByProcess() {
+		t.Error("grouping by process needs a process service")
+	}
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if containsString(m.View(), "Processes") {
+		t.Error("detail should not show processes without a service")
+	}
+}
+
+// mockPinService is a mock implementation of PinService for testing.
+type mockPinService struct {
+	pins []PinInfo
+	err  error
+}
+
+func (m *mockPinService) List() ([]PinInfo, error) {
+	return m.pins, m.err
+}
+
+// openPins navigates from the menu to the pinned objects view.
+func openPins(m Model) Model {
+	for range 4 {
+		m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	return updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+var testPins = []PinInfo{
+	{Path: "/sys/fs/bpf", Dir: true},
+	{Path: "/sys/fs/bpf/tc", Dir: true},
+	{Path: "/sys/fs/bpf/tc/globals", Dir: true},
+	{Path: "/sys/fs/bpf/tc/globals/events", Kind: "map", ID: 7, Name: "events"},
+	{Path: "/sys/fs/bpf/xdp_lb", Kind: "prog", ID: 10, Name: "xdp_lb"},
+	{Path: "/sys/fs/bpf/xdp_link", Kind: "link", ID: 3, Name: "xdp"},
+}
+
+func TestIntegrationPinToProgram(t *testing.T) {
+	m := NewModel(&mockProgService{programs: []ProgramInfo{{ID: 10, Name: "xdp_lb", Type: "xdp"}}}, nil)
+	m.SetPinService(&mockPinService{pins: testPins})
+
+	m = openPins(m)
+	if m.state != ViewPinTree {
+		t.Fatalf("expected ViewPinTree, got %v", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"/sys/fs/bpf/", "tc/", "xdp_lb", "prog [10] xdp_lb", "3 pinned files in 1 bpffs mounts"} {
+		if !containsString(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	// Rows: /sys/fs/bpf, tc, xdp_lb, xdp_link
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewProgDetail || !containsString(m.View(), "Program: xdp_lb") {
+		t.Fatalf("expected the program's details, got state %v", m.state)
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.state != ViewPinTree {
+		t.Errorf("expected ViewPinTree after back, got %v", m.state)
+	}
+}
+
+func TestIntegrationPinToMapAndLink(t *testing.T) {
+	m := NewModel(nil, &mockMapsService{maps: []MapInfo{{ID: 7, Name: "events", Type: "perf_event_array"}}})
+	m.SetPinService(&mockPinService{pins: testPins})
+	m.SetLinkService(&mockLinkService{links: []LinkInfo{{ID: 3, Type: "xdp", ProgID: 10, Target: "eth0"}}})
+
+	m = openPins(m)
+	// Expand tc and tc/globals, then open the map
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewMapDetail || !containsString(m.View(), "events") {
+		t.Fatalf("expected the map's details, got state %v", m.state)
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m = updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != ViewLinkDetail || !containsString(m.View(), "Link: [3] xdp") {
+		t.Fatalf("expected the link's details, got state %v", m.state)
+	}
+}
+
+func TestIntegrationPinsError(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.SetPinService(&mockPinService{err: errors.New("permission denied")})
+
+	m = openPins(m)
+	if !containsString(m.View(), "permission denied") {
+		t.Error("view should show the error")
+	}
+}
+
+func TestIntegrationPinsWithoutService(t *testing.T) {
+	m := openPins(NewModel(nil, nil))
+	if m.state != ViewPinTree || !containsString(m.View(), "No bpffs mounted") {
+		t.Error("pinned objects should be empty without a service")
+	}
+}
+
+// updateAndRun sends msg to the model, then runs the returned command and
+// feeds the resulting messages back, mimicking one round-trip of the Bubble
+// Tea runtime. Commands returned by those follow-up updates (such as the
+// spinner's next tick) are not run, so the helper never blocks.
+func updateAndRun(m Model, msg tea.Msg) Model {
+	result, cmd := m.Update(msg)
+	m = result.(Model)
+	for _, msg := range runCmd(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	return m
+}
+
+// runCmd executes cmd, flattening batches, and returns the produced messages.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
@@ -1,26 +1,145 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
+	"time"
 
+	"github.com/viveksb007/bpftui/internal/cli"
 	"github.com/viveksb007/bpftui/internal/tui"
 	"github.com/viveksb007/gobpftool/pkg/maps"
 	"github.com/viveksb007/gobpftool/pkg/prog"
 )
 
 func main() {
-	// Create the real BPF services
-	progSvc := prog.NewService()
-	mapsSvc := maps.NewService()
-
-	// Create adapters for the TUI
-	progAdapter := tui.NewProgServiceAdapter(progSvc)
-	mapsAdapter := tui.NewMapsServiceAdapter(mapsSvc)
+	refresh := flag.Duration("refresh", tui.DefaultRefreshInterval,
+		"interval for auto-refreshing program and map lists (0 disables)")
+	snapshotPath := flag.String("snapshot", "",
+		"capture all programs, maps and map contents to this JSON file and exit")
+	openPath := flag.String("open", "",
+		"browse a snapshot file instead of the live system (no root needed)")
+	diffPath := flag.String("diff", "",
+		"compare the live system, or the -open snapshot, against this snapshot file")
+	configPath := flag.String("config", "",
+		"read settings from this file instead of $XDG_CONFIG_HOME/bpftui/config.toml")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), cli.Usage)
+		fmt.Fprintln(flag.CommandLine.Output(), "\nFlags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
-	// Run the TUI with real services
-	if err := tui.RunWithServices(progAdapter, mapsAdapter); err != nil {
+	// The -refresh flag takes precedence over the configuration file
+	refreshSet := false
+	flag.Visit(func(f *flag.Flag) { refreshSet = refreshSet || f.Name == "refresh" })
+	err := run(*refresh, refreshSet, *snapshotPath, *openPath, *diffPath, *configPath)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, cli.ErrUsage) {
+			os.Exit(2)
+		}
 		os.Exit(1)
 	}
 }
+
+// loadConfig reads the configuration file at path, or at the default path if
+// empty. A missing file at the default path leaves the defaults, and returns
+// a nil config.
+func loadConfig(path string) (*tui.Config, error) {
+	if path != "" {
+		return tui.LoadConfig(path)
+	}
+	path, err := tui.DefaultConfigPath()
+	if err != nil {
+		// No home directory to look in
+		return nil, nil
+	}
+	cfg, err := tui.LoadConfig(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return cfg, err
+}
+
+// run browses, captures or prints either the live system or the snapshot at
+// openPath. When browsing, diffPath names a snapshot to compare against and
+// the settings are read from configPath, or the default configuration file
+// if empty; refreshSet means refresh was given and overrides them.
+func run(refresh time.Duration, refreshSet bool, snapshotPath, openPath, diffPath, configPath string) error {
+	var progSvc tui.ProgService
+	var mapsSvc tui.MapsService
+	opts := tui.Options{RefreshInterval: refresh}
+
+	if openPath != "" {
+		// Serve everything from the snapshot; the live-only views stay empty
+		snap, err := tui.ReadSnapshotFile(openPath)
+		if err != nil {
+			return err
+		}
+		progSvc = tui.NewSnapshotProgService(snap)
+		mapsSvc = tui.NewSnapshotMapsService(snap)
+		opts.RefreshInterval = 0
+		opts.Source = snap.Description()
+	} else {
+		// Create adapters for the real BPF services
+		progAdapter := tui.NewProgServiceAdapter(prog.NewService())
+		// Stop any run statistics enabled from the TUI
+		defer progAdapter.Close()
+		progSvc = progAdapter
+		mapsSvc = tui.NewMapsServiceAdapter(maps.NewService())
+		opts.LinkService = tui.NewLinkServiceAdapter()
+		opts.BTFService = tui.NewBTFServiceAdapter()
+		opts.ProcessService = tui.NewProcessServiceAdapter()
+		opts.PinService = tui.NewPinServiceAdapter()
+	}
+
+	if diffPath != "" {
+		baseline, err := tui.ReadSnapshotFile(diffPath)
+		if err != nil {
+			return err
+		}
+		opts.Baseline = baseline
+		source := opts.Source
+		if source == "" {
+			source = "live"
+		}
+		opts.Source = source + " vs " + baseline.Description()
+	}
+
+	switch {
+	case snapshotPath != "":
+		return captureSnapshot(snapshotPath, progSvc, mapsSvc)
+	case flag.NArg() > 0:
+		// Subcommands print their output and exit instead of starting the TUI
+		return cli.Run(flag.Args(), progSvc, mapsSvc, os.Stdout)
+	default:
+		// Only the TUI reads the configuration file, so a broken one doesn't
+		// stop snapshots and subcommands
+		cfg, err := loadConfig(configPath)
+		if err != nil {
+			return err
+		}
+		if cfg != nil && cfg.RefreshInterval != nil && !refreshSet && openPath == "" {
+			opts.RefreshInterval = *cfg.RefreshInterval
+		}
+		opts.Config = cfg
+		return tui.RunWithOptions(progSvc, mapsSvc, opts)
+	}
+}
+
+// captureSnapshot writes a snapshot of the services to path.
+func captureSnapshot(path string, progSvc tui.ProgService, mapsSvc tui.MapsService) error {
+	snap, err := tui.CaptureSnapshot(progSvc, mapsSvc)
+	if err != nil {
+		return err
+	}
+	if err := tui.WriteSnapshotFile(path, snap); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Captured %d programs and %d maps (%d not dumpable) to %s\n",
+		len(snap.Programs), len(snap.Maps), len(snap.DumpErrors), path)
+	return nil
+}